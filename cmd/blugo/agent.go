@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ivangsm/blugo/internal/agent"
+	"github.com/ivangsm/blugo/internal/bluetooth"
+	"github.com/ivangsm/blugo/internal/config"
+)
+
+// runAgent implements "blugo agent": it registers the pairing agent with
+// the headless policy from the configuration and answers requests without
+// the TUI until it receives SIGINT or SIGTERM. Decisions are logged to
+// stderr, which systemd forwards to the journal.
+func runAgent(adapter string, args []string) error {
+	fs := flag.NewFlagSet("agent", flag.ExitOnError)
+	capability := fs.String("capability", "", "IO capability to register with (default: agent_capability from the config)")
+	logFormat := fs.String("log-format", "text", "Log format: text or json")
+	_ = fs.Parse(args)
+
+	logger, err := newLogger(os.Stderr, *logFormat)
+	if err != nil {
+		return err
+	}
+	if *capability != "" {
+		config.Global.AgentCapability = *capability
+	}
+
+	manager, err := bluetooth.NewManager(adapter)
+	if err != nil {
+		return err
+	}
+	defer manager.Close()
+	manager.SetTimeouts(bluetooth.TimeoutsFromConfig())
+
+	// Signals let the agent register again after bluetoothd restarts
+	if err := manager.Watch(); err != nil {
+		logger.Warn("BlueZ signals unavailable, bluetoothd restarts will not be noticed", "error", err)
+	}
+
+	policy := agent.HeadlessPolicyFromConfig()
+	btAgent := agent.NewHeadlessAgent(policy, logger)
+	btAgent.SetDeviceLookup(manager.GetDevice)
+	if err := btAgent.Register(manager.GetConnection()); err != nil {
+		return err
+	}
+	registered, isDefault := btAgent.Registration()
+	logger.Info("agent registered",
+		"capability", registered,
+		"default", isDefault,
+		"just_works", policy.JustWorks,
+		"allowed_devices", len(policy.AllowedDevices),
+		"pin_code", policy.PinCode != "")
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	events := manager.Events()
+	for {
+		select {
+		case <-ctx.Done():
+			btAgent.Unregister(manager.GetConnection())
+			logger.Info("agent stopped")
+			return nil
+
+		case ev, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			switch ev.Kind {
+			case bluetooth.ServiceLost:
+				logger.Warn("bluetoothd left the bus")
+			case bluetooth.ServiceRestored:
+				if err := btAgent.Register(manager.GetConnection()); err != nil {
+					logger.Error("could not register the agent again", "error", err)
+					continue
+				}
+				registered, isDefault := btAgent.Registration()
+				logger.Info("agent registered", "capability", registered, "default", isDefault)
+			}
+		}
+	}
+}
+
+// newLogger returns a structured logger writing to w in the given format.
+func newLogger(w io.Writer, format string) (*slog.Logger, error) {
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, nil)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, nil)), nil
+	}
+	return nil, fmt.Errorf("unknown log format %q", format)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/ivangsm/blugo/internal/bluetooth"
+	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// runDevice implements "blugo connect" and "blugo disconnect": it
+// connects or disconnects the device with the address given, or only one
+// of its profiles with --profile, without the TUI.
+func runDevice(adapter, command string, args []string) error {
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	profile := fs.String("profile", "", "Only this profile, by name ("+strings.Join(models.ProfileNames(), ", ")+") or UUID")
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: blugo %s [--profile NAME] ADDRESS", command)
+	}
+
+	var uuid string
+	if *profile != "" {
+		var ok bool
+		if uuid, ok = models.ProfileUUID(*profile); !ok {
+			return fmt.Errorf(i18n.T.ErrorUnknownProfile, *profile, strings.Join(models.ProfileNames(), ", "))
+		}
+	}
+
+	manager, err := bluetooth.NewManager(adapter)
+	if err != nil {
+		return err
+	}
+	defer manager.Close()
+	manager.SetTimeouts(bluetooth.TimeoutsFromConfig())
+
+	dev, err := findDevice(manager, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	name := dev.GetDisplayName()
+	var done string
+	switch {
+	case command == "connect" && uuid != "":
+		err = manager.ConnectProfile(dev.Path, uuid)
+		done = fmt.Sprintf(i18n.T.ProfileConnected, models.ProfileLabel(uuid), name)
+	case command == "connect":
+		err = manager.ConnectDevice(dev.Path)
+		done = fmt.Sprintf(i18n.T.Connected, name)
+	case uuid != "":
+		err = manager.DisconnectProfile(dev.Path, uuid)
+		done = fmt.Sprintf(i18n.T.ProfileDisconnected, models.ProfileLabel(uuid), name)
+	default:
+		err = manager.DisconnectDevice(dev.Path)
+		done = fmt.Sprintf(i18n.T.Disconnected, name)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(done)
+	return nil
+}
+
+// findDevice returns the device of the active adapter with address,
+// written with or without separators.
+func findDevice(manager *bluetooth.Manager, address string) (*models.Device, error) {
+	// Devices whose properties could not all be decoded are still usable
+	devices, err := manager.GetDevices()
+	for _, dev := range devices {
+		if models.NormalizeMAC(dev.Address) == models.NormalizeMAC(address) {
+			return dev, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf(i18n.T.ErrorDeviceNotFound, address)
+}
@@ -4,8 +4,10 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ivangsm/blugo/internal/bluetooth"
 	"github.com/ivangsm/blugo/internal/config"
 	"github.com/ivangsm/blugo/internal/i18n"
 	"github.com/ivangsm/blugo/internal/ui"
@@ -18,6 +20,13 @@ func main() {
 	// Parse command line flags
 	showVersion := flag.Bool("version", false, "Show version information")
 	flag.BoolVar(showVersion, "v", false, "Show version information (shorthand)")
+	adapterName := flag.String("adapter", "", "Bluetooth adapter to use (e.g. hci1)")
+	transport := flag.String("transport", "", "Discovery transport: auto, bredr or le")
+	rssi := flag.Int("rssi", 0, "Minimum RSSI in dBm reported while scanning (0 = off)")
+	pathloss := flag.Int("pathloss", 0, "Maximum pathloss in dB reported while scanning (0 = off)")
+	uuids := flag.String("uuids", "", "Comma-separated service UUIDs to scan for (e.g. 180d,180f)")
+	pattern := flag.String("pattern", "", "Only discover devices whose name or address starts with this")
+	duplicateData := flag.Bool("duplicate-data", true, "Report every advertisement instead of only changes")
 	flag.Parse()
 
 	if *showVersion {
@@ -30,9 +39,53 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Command line flags override config values for this session only
+	adapter := config.Global.Adapter
+	if *adapterName != "" {
+		adapter = *adapterName
+	}
+	session := ui.Session{
+		Adapter: adapter,
+		Filter: func(filter *bluetooth.DiscoveryFilter) {
+			flag.Visit(func(f *flag.Flag) {
+				switch f.Name {
+				case "transport":
+					filter.Transport = *transport
+				case "rssi":
+					filter.RSSI = *rssi
+				case "pathloss":
+					filter.Pathloss = *pathloss
+				case "uuids":
+					filter.UUIDs = splitList(*uuids)
+				case "pattern":
+					filter.Pattern = *pattern
+				case "duplicate-data":
+					filter.DuplicateData = *duplicateData
+				}
+			})
+		},
+	}
+
 	// Set language from config
 	i18n.InitFromConfig(config.Global.Language)
 
+	// "blugo agent" runs the pairing agent without the TUI, and
+	// "blugo connect" and "blugo disconnect" act on one device
+	var command func() error
+	switch flag.Arg(0) {
+	case "agent":
+		command = func() error { return runAgent(adapter, flag.Args()[1:]) }
+	case "connect", "disconnect":
+		command = func() error { return runDevice(adapter, flag.Arg(0), flag.Args()[1:]) }
+	}
+	if command != nil {
+		if err := command(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Initialize theme from config
 	themeMode := ui.ThemeMode(config.Global.ThemeMode)
 	if err := ui.InitializeTheme(themeMode); err != nil {
@@ -52,7 +105,7 @@ func main() {
 
 	// Inicializar en segundo plano
 	go func() {
-		initCmd := ui.InitializeCmd(p)
+		initCmd := ui.InitializeCmd(p, session)
 		msg := initCmd()
 		p.Send(msg)
 	}()
@@ -63,3 +116,14 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
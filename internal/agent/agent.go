@@ -1,20 +1,34 @@
 package agent
 
 import (
-	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/godbus/dbus/v5"
 	"github.com/godbus/dbus/v5/introspect"
+	"github.com/ivangsm/blugo/internal/config"
 	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
 )
 
 const (
 	bluezService = "org.bluez"
 	agentIface   = "org.bluez.Agent1"
+
+	errRejected = "org.bluez.Error.Rejected"
+	errCanceled = "org.bluez.Error.Canceled"
 )
 
+// defaultTimeout bounds a prompt when no configuration is loaded.
+const defaultTimeout = 60 * time.Second
+
+// maxPasskey is the largest passkey; passkeys are shown as 6 digits.
+const maxPasskey = 999999
+
 var agentPath = dbus.ObjectPath("/org/bluez/agent_gob")
 
 const agentIntrospection = `
@@ -59,108 +73,251 @@ const agentIntrospection = `
 </node>
 `
 
+// DeviceLookup returns the device at an object path, or nil if unknown.
+type DeviceLookup func(path dbus.ObjectPath) (*models.Device, error)
+
+// Sender delivers the agent's messages to the user interface; it is
+// satisfied by *tea.Program.
+type Sender interface {
+	Send(msg tea.Msg)
+}
+
 // Agent handles BlueZ pairing requests.
 type Agent struct {
-	program        *tea.Program
-	passkeyChannel chan uint32
-	confirmChannel chan bool
+	program  Sender
+	lookup   DeviceLookup
+	pinCodes func(dev *models.Device) []string // PINs tried before asking
+
+	// justWorks, when set, decides pairings without a code instead of the
+	// authorization policy
+	justWorks func(dev *models.Device) bool
+
+	mu         sync.Mutex
+	sessions   map[dbus.ObjectPath]*session
+	pins       map[dbus.ObjectPath]*pinState
+	capability string // IO capability registered with; empty when not registered
+	isDefault  bool   // BlueZ sends incoming pairing requests to this agent
 }
 
-// NewAgent creates a new agent instance.
-func NewAgent(program *tea.Program) *Agent {
+// NewAgent creates a new agent instance. program receives the prompts and
+// notifications; without one every request needing the user is rejected.
+func NewAgent(program Sender) *Agent {
 	return &Agent{
-		program:        program,
-		passkeyChannel: make(chan uint32, 1),
-		confirmChannel: make(chan bool, 1),
+		program:  program,
+		pinCodes: PinCodes,
+		sessions: make(map[dbus.ObjectPath]*session),
+		pins:     make(map[dbus.ObjectPath]*pinState),
 	}
 }
 
-// GetPasskeyChannel returns the passkey channel.
-func (a *Agent) GetPasskeyChannel() <-chan uint32 {
-	return a.passkeyChannel
+// SetDeviceLookup sets how the agent finds out which device is asking.
+// Without one, only the address encoded in the object path is known.
+func (a *Agent) SetDeviceLookup(lookup DeviceLookup) {
+	a.lookup = lookup
 }
 
-// GetConfirmChannel returns the confirmation channel.
-func (a *Agent) GetConfirmChannel() chan<- bool {
-	return a.confirmChannel
-}
-
-// Release is called when the agent is unregistered.
+// Release is called by BlueZ when it drops the agent, e.g. because
+// bluetoothd is shutting down.
 func (a *Agent) Release() *dbus.Error {
+	a.released()
 	return nil
 }
 
-// RequestPinCode requests an old PIN (4 digits).
+// TryPinCodes makes the agent hand out the configured PINs when device
+// asks for one during the pairing about to start, before asking the user.
+// Pairings started by the remote device skip them and go straight to the
+// user. Call ResetPinCode once the pairing has finished.
+func (a *Agent) TryPinCodes(device dbus.ObjectPath) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pins[device] = &pinState{}
+}
+
+// RetryPinCode reports whether a pairing with device that failed
+// authentication should be attempted again: the PIN that was used came
+// from the configured list, and either another one is left or the user
+// can still be asked.
+func (a *Agent) RetryPinCode(device dbus.ObjectPath) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	state, ok := a.pins[device]
+	return ok && state.next > 0 && !state.prompted
+}
+
+// ResetPinCode forgets the PINs tried with device; call it once pairing
+// has finished, successfully or not.
+func (a *Agent) ResetPinCode(device dbus.ObjectPath) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.pins, device)
+}
+
+// RequestPinCode returns the PIN for a legacy device. In a pairing set up
+// with TryPinCodes the configured PINs are handed out one per pairing
+// attempt; once they are exhausted, or for a pairing the remote device
+// started, the user is asked, and the request is cancelled if nobody
+// answers in time.
 func (a *Agent) RequestPinCode(device dbus.ObjectPath) (string, *dbus.Error) {
-	return "0000", nil
+	dev := a.device(device)
+
+	a.mu.Lock()
+	if state, ok := a.pins[device]; ok {
+		codes := a.pinCodes(dev)
+		if state.next < len(codes) {
+			pin := codes[state.next]
+			state.next++
+			a.mu.Unlock()
+			a.send(PinCodeTryMsg{Device: dev, PinCode: pin})
+			return pin, nil
+		}
+		state.prompted = true
+	}
+	a.mu.Unlock()
+
+	r, err := a.ask(dev, PinCodeRequestMsg{Device: dev})
+	return r.value, err
 }
 
-// DisplayPinCode displays a PIN in the interface.
+// DisplayPinCode shows the PIN the user has to type on the remote
+// device. BlueZ only waits for the method to return before going on with
+// the pairing, so the UI is notified and the call returns right away.
 func (a *Agent) DisplayPinCode(device dbus.ObjectPath, pincode string) *dbus.Error {
-	if a.program != nil {
-		// TODO: Send message to UI
-		fmt.Printf("PIN: %s\n", pincode)
-	}
+	a.send(DisplayPinCodeMsg{Device: a.device(device), PinCode: pincode})
 	return nil
 }
 
-// RequestPasskey requests a passkey from the user.
+// RequestPasskey asks the user for the 6-digit passkey shown by the
+// remote device.
 func (a *Agent) RequestPasskey(device dbus.ObjectPath) (uint32, *dbus.Error) {
-	return 0, dbus.MakeFailedError(errors.New(i18n.T.ErrorRequestPasskey))
+	dev := a.device(device)
+	r, err := a.ask(dev, PasskeyRequestMsg{Device: dev})
+	if err != nil {
+		return 0, err
+	}
+
+	passkey, parseErr := strconv.ParseUint(r.value, 10, 32)
+	if parseErr != nil || passkey > maxPasskey {
+		return 0, dbus.NewError(errRejected, []interface{}{i18n.T.ErrorInvalidPasskey})
+	}
+	return uint32(passkey), nil
 }
 
-// DisplayPasskey shows a 6-digit passkey.
+// DisplayPasskey shows the passkey the user has to type on the remote
+// device, typically a keyboard. BlueZ calls it again for every key
+// pressed there, with entered holding the number of digits typed so far,
+// so it only notifies the UI and returns.
 func (a *Agent) DisplayPasskey(device dbus.ObjectPath, passkey uint32, entered uint16) *dbus.Error {
-	if a.program != nil {
-		a.passkeyChannel <- passkey
+	a.send(DisplayPasskeyMsg{Device: a.device(device), Passkey: passkey, Entered: entered})
+	return nil
+}
+
+// RequestConfirmation asks the user to confirm that device shows the
+// same passkey.
+func (a *Agent) RequestConfirmation(device dbus.ObjectPath, passkey uint32) *dbus.Error {
+	dev := a.device(device)
+	_, err := a.ask(dev, ConfirmPasskeyMsg{Device: dev, Passkey: passkey})
+	if err != nil && err.Name == errRejected {
+		return dbus.NewError(errRejected, []interface{}{i18n.T.ErrorConfirmRejected})
 	}
+	return err
+}
 
-	// Wait for user confirmation
-	confirmed := <-a.confirmChannel
-	if !confirmed {
-		return dbus.MakeFailedError(errors.New(i18n.T.ErrorPairingCancelled))
+// RequestAuthorization is called when a device pairs with us without any
+// code to show or compare (Just Works), and is answered according to the
+// authorization policy.
+func (a *Agent) RequestAuthorization(device dbus.ObjectPath) *dbus.Error {
+	return a.authorize(a.device(device), "")
+}
+
+// AuthorizeService is called when a device that is not trusted wants to
+// use the service uuid, and is answered according to the authorization
+// policy.
+func (a *Agent) AuthorizeService(device dbus.ObjectPath, uuid string) *dbus.Error {
+	return a.authorize(a.device(device), uuid)
+}
+
+// authorize applies the authorization policy to dev's request to use the
+// service uuid, or to pair when uuid is empty.
+func (a *Agent) authorize(dev *models.Device, uuid string) *dbus.Error {
+	if uuid == "" && a.justWorks != nil {
+		if a.justWorks(dev) {
+			return nil
+		}
+		return dbus.NewError(errRejected, []interface{}{i18n.T.ErrorAuthorizationDenied})
 	}
 
-	return nil
+	switch AuthorizationMode(dev, uuid) {
+	case AuthorizeAllow:
+		return nil
+	case AuthorizeTrusted:
+		if dev.Trusted {
+			return nil
+		}
+	case AuthorizePrompt:
+		_, err := a.ask(dev, AuthorizeRequestMsg{Device: dev, UUID: uuid})
+		if err != nil && err.Name == errRejected {
+			return dbus.NewError(errRejected, []interface{}{i18n.T.ErrorAuthorizationDenied})
+		}
+		return err
+	}
+
+	a.send(AuthorizationDeniedMsg{Device: dev, UUID: uuid})
+	return dbus.NewError(errRejected, []interface{}{i18n.T.ErrorAuthorizationDenied})
 }
 
-// RequestConfirmation requests confirmation of a passkey.
-func (a *Agent) RequestConfirmation(device dbus.ObjectPath, passkey uint32) *dbus.Error {
+// send delivers msg to the program, if there is one.
+func (a *Agent) send(msg tea.Msg) {
 	if a.program != nil {
-		a.passkeyChannel <- passkey
+		a.program.Send(msg)
 	}
+}
 
-	confirmed := <-a.confirmChannel
-	if !confirmed {
-		return dbus.MakeFailedError(errors.New(i18n.T.ErrorConfirmRejected))
+// device describes the device at path for the UI. It falls back to the
+// address encoded in the object path when the device cannot be looked up.
+func (a *Agent) device(path dbus.ObjectPath) *models.Device {
+	if a.lookup != nil {
+		if dev, _ := a.lookup(path); dev != nil {
+			return dev
+		}
 	}
-
-	return nil
+	return &models.Device{Path: path, Address: addressFromPath(path)}
 }
 
-// RequestAuthorization requests authorization for a device.
-func (a *Agent) RequestAuthorization(device dbus.ObjectPath) *dbus.Error {
-	return nil
+// formatPasskey renders a passkey the way it is typed.
+func formatPasskey(passkey uint32) string {
+	return strconv.FormatUint(uint64(passkey), 10)
 }
 
-// AuthorizeService requests authorization for a service.
-func (a *Agent) AuthorizeService(device dbus.ObjectPath, uuid string) *dbus.Error {
-	return nil
+// addressFromPath extracts the address from a device object path such as
+// /org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF.
+func addressFromPath(path dbus.ObjectPath) string {
+	s := string(path)
+	i := strings.LastIndex(s, "/dev_")
+	if i < 0 {
+		return ""
+	}
+	return strings.ReplaceAll(s[i+len("/dev_"):], "_", ":")
 }
 
-// Cancel cancels the current pairing process.
-func (a *Agent) Cancel() *dbus.Error {
-	if a.confirmChannel != nil {
-		select {
-		case a.confirmChannel <- false:
-		default:
-		}
+// timeout returns how long a prompt waits for the user.
+func timeout() time.Duration {
+	if config.Global == nil || config.Global.PairingTimeout <= 0 {
+		return defaultTimeout
 	}
-	return nil
+	return time.Duration(config.Global.PairingTimeout) * time.Second
 }
 
-// Register registers the agent with BlueZ.
+// Register registers the agent with BlueZ using the configured IO
+// capability, falling back to NoInputNoOutput when BlueZ refuses it. It
+// only asks to become the default agent, which takes incoming pairing
+// requests away from a desktop agent, when configured to.
 func (a *Agent) Register(conn *dbus.Conn) error {
+	configured, requestDefault := configuredCapability()
+	capability, ok := ParseCapability(configured)
+	if !ok {
+		return fmt.Errorf(i18n.T.ErrorAgentCapability, configured)
+	}
+
 	// Unregister any existing agent
 	a.Unregister(conn)
 
@@ -180,24 +337,48 @@ func (a *Agent) Register(conn *dbus.Conn) error {
 	// Register the agent with BlueZ
 	obj := conn.Object(bluezService, dbus.ObjectPath("/org/bluez"))
 
-	// Try KeyboardDisplay first
-	err = obj.Call("org.bluez.AgentManager1.RegisterAgent", 0, agentPath, "KeyboardDisplay").Err
+	err = obj.Call("org.bluez.AgentManager1.RegisterAgent", 0, agentPath, capability).Err
+	if err != nil && capability != CapabilityNoInputNoOutput {
+		capability = CapabilityNoInputNoOutput
+		err = obj.Call("org.bluez.AgentManager1.RegisterAgent", 0, agentPath, capability).Err
+	}
 	if err != nil {
-		// If it fails, try NoInputNoOutput
-		err = obj.Call("org.bluez.AgentManager1.RegisterAgent", 0, agentPath, "NoInputNoOutput").Err
-		if err != nil {
-			return fmt.Errorf("%s: %w", i18n.T.ErrorRegisterAgent, err)
-		}
+		return fmt.Errorf("%s: %w", i18n.T.ErrorRegisterAgent, err)
 	}
 
-	// Set as default agent
-	_ = obj.Call("org.bluez.AgentManager1.RequestDefaultAgent", 0, agentPath).Err
+	// Set as default agent; failing is not fatal, pairings started from
+	// blugo still reach the agent
+	isDefault := false
+	if requestDefault {
+		isDefault = obj.Call("org.bluez.AgentManager1.RequestDefaultAgent", 0, agentPath).Err == nil
+	}
 
+	a.mu.Lock()
+	a.capability, a.isDefault = capability, isDefault
+	a.mu.Unlock()
 	return nil
 }
 
-// Unregister unregisters the agent from BlueZ.
+// Unregister unregisters the agent from BlueZ and stops answering calls.
 func (a *Agent) Unregister(conn *dbus.Conn) {
 	obj := conn.Object(bluezService, dbus.ObjectPath("/org/bluez"))
 	_ = obj.Call("org.bluez.AgentManager1.UnregisterAgent", 0, agentPath).Err
+	_ = conn.Export(nil, agentPath, agentIface)
+	_ = conn.Export(nil, agentPath, "org.freedesktop.DBus.Introspectable")
+	a.released()
+}
+
+// Registration returns the IO capability the agent is registered with,
+// empty when it is not registered, and whether it is the default agent.
+func (a *Agent) Registration() (capability string, isDefault bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.capability, a.isDefault
+}
+
+// released forgets the registration.
+func (a *Agent) released() {
+	a.mu.Lock()
+	a.capability, a.isDefault = "", false
+	a.mu.Unlock()
 }
@@ -0,0 +1,257 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/config"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+const testDevice = dbus.ObjectPath("/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF")
+
+// recorder stands in for the Bubble Tea program and collects what the
+// agent sends to it.
+type recorder struct {
+	msgs chan tea.Msg
+}
+
+func (r *recorder) Send(msg tea.Msg) {
+	r.msgs <- msg
+}
+
+// next returns the next message sent by the agent.
+func (r *recorder) next(t *testing.T) tea.Msg {
+	t.Helper()
+	select {
+	case msg := <-r.msgs:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("the agent sent no message")
+		return nil
+	}
+}
+
+// newTestAgent returns an agent talking to a recorder, with a device
+// lookup that knows a single keyboard and no configured PINs.
+func newTestAgent(t *testing.T) (*Agent, *recorder) {
+	t.Helper()
+	saved := config.Global
+	t.Cleanup(func() { config.Global = saved })
+	config.Global = config.Default()
+	config.Global.PinCodes = nil
+
+	r := &recorder{msgs: make(chan tea.Msg, 10)}
+	a := NewAgent(r)
+	a.SetDeviceLookup(func(path dbus.ObjectPath) (*models.Device, error) {
+		if path != testDevice {
+			return nil, nil
+		}
+		return &models.Device{Path: path, Address: "AA:BB:CC:DD:EE:FF", Name: "Keyboard"}, nil
+	})
+	return a, r
+}
+
+func TestAgent_DisplayPinCode(t *testing.T) {
+	a, r := newTestAgent(t)
+
+	if err := a.DisplayPinCode(testDevice, "482913"); err != nil {
+		t.Fatalf("DisplayPinCode() error = %v", err)
+	}
+
+	msg, ok := r.next(t).(DisplayPinCodeMsg)
+	if !ok {
+		t.Fatalf("expected a DisplayPinCodeMsg")
+	}
+	if msg.PinCode != "482913" {
+		t.Errorf("PinCode = %q, want 482913", msg.PinCode)
+	}
+	if msg.Device == nil || msg.Device.Name != "Keyboard" {
+		t.Errorf("Device = %+v, want the keyboard", msg.Device)
+	}
+}
+
+func TestAgent_DisplayPinCode_UnknownDevice(t *testing.T) {
+	a, r := newTestAgent(t)
+
+	_ = a.DisplayPinCode("/org/bluez/hci0/dev_11_22_33_44_55_66", "0000")
+
+	msg := r.next(t).(DisplayPinCodeMsg)
+	if msg.Device.Address != "11:22:33:44:55:66" {
+		t.Errorf("Address = %q, want the one from the object path", msg.Device.Address)
+	}
+}
+
+func TestAgent_DisplayPasskey_Progress(t *testing.T) {
+	a, r := newTestAgent(t)
+
+	for entered := uint16(0); entered <= 6; entered++ {
+		if err := a.DisplayPasskey(testDevice, 123456, entered); err != nil {
+			t.Fatalf("DisplayPasskey() error = %v", err)
+		}
+		msg := r.next(t).(DisplayPasskeyMsg)
+		if msg.Passkey != 123456 || msg.Entered != entered {
+			t.Errorf("got passkey %d entered %d, want 123456 entered %d", msg.Passkey, msg.Entered, entered)
+		}
+	}
+}
+
+func TestAgent_RequestPasskey(t *testing.T) {
+	a, r := newTestAgent(t)
+
+	go func() {
+		if _, ok := r.next(t).(PasskeyRequestMsg); ok {
+			a.AnswerPasskey(testDevice, 4821)
+		}
+	}()
+
+	passkey, err := a.RequestPasskey(testDevice)
+	if err != nil {
+		t.Fatalf("RequestPasskey() error = %v", err)
+	}
+	if passkey != 4821 {
+		t.Errorf("RequestPasskey() = %d, want 4821", passkey)
+	}
+}
+
+func TestAgent_RequestPinCode_Prompt(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  func(a *Agent)
+		want    string
+		wantErr string
+	}{
+		{"answered", func(a *Agent) { a.AnswerPinCode(testDevice, "8642") }, "8642", ""},
+		{"cancelled by the user", func(a *Agent) { a.Reject(testDevice) }, "", errRejected},
+		{"cancelled by BlueZ", func(a *Agent) { _ = a.Cancel() }, "", errCanceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, r := newTestAgent(t)
+
+			go func() {
+				msg := r.next(t).(PinCodeRequestMsg)
+				if msg.Device.Name != "Keyboard" {
+					t.Errorf("request names %q, want Keyboard", msg.Device.Name)
+				}
+				tt.answer(a)
+			}()
+
+			pin, err := a.RequestPinCode(testDevice)
+			if pin != tt.want {
+				t.Errorf("RequestPinCode() = %q, want %q", pin, tt.want)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("RequestPinCode() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Name != tt.wantErr) {
+				t.Errorf("RequestPinCode() error = %v, want %s", err, tt.wantErr)
+			}
+			if tt.wantErr == errCanceled {
+				if _, ok := r.next(t).(RequestCancelledMsg); !ok {
+					t.Errorf("the UI should be told to close the prompt")
+				}
+			}
+		})
+	}
+}
+
+func TestAgent_RequestConfirmation(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  func(a *Agent, device dbus.ObjectPath)
+		wantErr string
+	}{
+		{"confirmed", func(a *Agent, device dbus.ObjectPath) { a.Confirm(device) }, ""},
+		{"rejected", func(a *Agent, device dbus.ObjectPath) { a.Reject(device) }, errRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, r := newTestAgent(t)
+
+			go func() {
+				msg := r.next(t).(ConfirmPasskeyMsg)
+				if msg.Passkey != 123456 || msg.Device.Name != "Keyboard" {
+					t.Errorf("got %+v, want passkey 123456 from the keyboard", msg)
+				}
+				tt.answer(a, msg.Device.Path)
+			}()
+
+			err := a.RequestConfirmation(testDevice, 123456)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("RequestConfirmation() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Name != tt.wantErr) {
+				t.Errorf("RequestConfirmation() error = %v, want %s", err, tt.wantErr)
+			}
+			if a.Pending(testDevice) {
+				t.Errorf("the session should be closed")
+			}
+		})
+	}
+}
+
+func TestAgent_SessionsPerDevice(t *testing.T) {
+	a, r := newTestAgent(t)
+	other := dbus.ObjectPath("/org/bluez/hci0/dev_11_22_33_44_55_66")
+
+	errs := make(chan *dbus.Error, 2)
+	go func() { errs <- a.RequestConfirmation(testDevice, 111111) }()
+	go func() { errs <- a.RequestConfirmation(other, 222222) }()
+	r.next(t)
+	r.next(t)
+
+	// Answers only reach the session of their own device
+	a.Reject(other)
+	if err := <-errs; err == nil || err.Name != errRejected {
+		t.Fatalf("rejected session error = %v, want %s", err, errRejected)
+	}
+	if !a.Pending(testDevice) {
+		t.Fatalf("the other session should still be waiting")
+	}
+
+	a.Confirm(testDevice)
+	if err := <-errs; err != nil {
+		t.Errorf("confirmed session error = %v", err)
+	}
+}
+
+func TestAgent_Cancel(t *testing.T) {
+	a, r := newTestAgent(t)
+
+	errs := make(chan *dbus.Error, 1)
+	go func() { errs <- a.RequestConfirmation(testDevice, 123456) }()
+	r.next(t)
+
+	_ = a.Cancel()
+	if err := <-errs; err == nil || err.Name != errCanceled {
+		t.Errorf("RequestConfirmation() error = %v, want %s", err, errCanceled)
+	}
+	if msg, ok := r.next(t).(RequestCancelledMsg); !ok || msg.TimedOut {
+		t.Errorf("the UI should be told BlueZ cancelled the request, got %+v", msg)
+	}
+}
+
+func TestAgent_Deadline(t *testing.T) {
+	a, r := newTestAgent(t)
+	config.Global.PairingTimeout = 1
+
+	// Nobody answers, as for a device pairing while the UI looks elsewhere
+	start := time.Now()
+	err := a.RequestConfirmation(testDevice, 123456)
+	if err == nil || err.Name != errCanceled {
+		t.Errorf("RequestConfirmation() error = %v, want %s", err, errCanceled)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("the request waited %v, past its deadline", elapsed)
+	}
+
+	r.next(t) // the request itself
+	if msg, ok := r.next(t).(RequestCancelledMsg); !ok || !msg.TimedOut {
+		t.Errorf("the UI should be told the request timed out, got %+v", msg)
+	}
+}
@@ -0,0 +1,85 @@
+package agent
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ivangsm/blugo/internal/config"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// Authorization modes accepted by the authorization settings.
+const (
+	AuthorizeAllow   = "allow"   // Authorize without asking
+	AuthorizeTrusted = "trusted" // Authorize trusted devices only
+	AuthorizePrompt  = "prompt"  // Ask the user
+	AuthorizeDeny    = "deny"    // Refuse without asking
+)
+
+// ValidAuthorizationMode reports whether mode is one of the
+// authorization modes.
+func ValidAuthorizationMode(mode string) bool {
+	switch strings.ToLower(mode) {
+	case AuthorizeAllow, AuthorizeTrusted, AuthorizePrompt, AuthorizeDeny:
+		return true
+	}
+	return false
+}
+
+// AuthorizationMode returns how to answer dev's request to use the
+// service uuid, or to pair when uuid is empty. The most specific rule
+// wins: the one for the device's address, then the one for the service,
+// then the default mode. When several keys name the same device or
+// service, e.g. "110b" and "0x110B", the first one in sorted order is
+// used. Unknown modes deny, so a typo never opens the machine up.
+func AuthorizationMode(dev *models.Device, uuid string) string {
+	if config.Global == nil {
+		return AuthorizePrompt
+	}
+
+	mode := config.Global.Authorization
+	if uuid != "" {
+		for _, key := range sortedKeys(config.Global.AuthorizationByService) {
+			if sameUUID(key, uuid) {
+				mode = config.Global.AuthorizationByService[key]
+				break
+			}
+		}
+	}
+	address := models.NormalizeMAC(dev.Address)
+	for _, key := range sortedKeys(config.Global.AuthorizationByDevice) {
+		if models.NormalizeMAC(key) == address {
+			mode = config.Global.AuthorizationByDevice[key]
+			break
+		}
+	}
+
+	if !ValidAuthorizationMode(mode) {
+		return AuthorizeDeny
+	}
+	return strings.ToLower(mode)
+}
+
+// sameUUID reports whether a configured service key names uuid. Keys are
+// full UUIDs or, for assigned numbers, the short "110b" or "0x110B" form.
+func sameUUID(key, uuid string) bool {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if strings.EqualFold(key, uuid) {
+		return true
+	}
+	short, ok := models.ShortUUID(uuid)
+	if !ok {
+		return false
+	}
+	value, err := strconv.ParseUint(strings.TrimPrefix(key, "0x"), 16, 16)
+	return err == nil && uint16(value) == short
+}
+
+// sortedKeys returns the keys of a configured map in sorted order, so
+// rules matching through different spellings of a key are always looked
+// at in the same order.
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
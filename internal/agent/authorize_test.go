@@ -0,0 +1,150 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/config"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+const (
+	audioSink  = "0000110b-0000-1000-8000-00805f9b34fb"
+	objectPush = "00001105-0000-1000-8000-00805f9b34fb"
+)
+
+func TestAuthorizationMode(t *testing.T) {
+	saved := config.Global
+	defer func() { config.Global = saved }()
+
+	config.Global = config.Default()
+	config.Global.Authorization = "trusted"
+	config.Global.AuthorizationByService = map[string]string{
+		"110b":                                 "allow",
+		"0x1105":                               "DENY",
+		"1234-typo":                            "allow",
+		"0000180f-0000-1000-8000-00805F9B34FB": "prompt",
+	}
+	config.Global.AuthorizationByDevice = map[string]string{
+		"aa-bb-cc-dd-ee-ff": "prompt",
+		"11:22:33:44:55:66": "sometimes",
+	}
+
+	lab := &models.Device{Address: "00:00:00:00:00:01"}
+	tests := []struct {
+		name string
+		dev  *models.Device
+		uuid string
+		want string
+	}{
+		{"default mode", lab, "", AuthorizeTrusted},
+		{"short service key", lab, audioSink, AuthorizeAllow},
+		{"prefixed key in another case", lab, objectPush, AuthorizeDeny},
+		{"full service key", lab, "0000180f-0000-1000-8000-00805f9b34fb", AuthorizePrompt},
+		{"unlisted service", lab, "0000110a-0000-1000-8000-00805f9b34fb", AuthorizeTrusted},
+		{"device rule wins", &models.Device{Address: "AA:BB:CC:DD:EE:FF"}, audioSink, AuthorizePrompt},
+		{"unknown mode denies", &models.Device{Address: "11:22:33:44:55:66"}, audioSink, AuthorizeDeny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AuthorizationMode(tt.dev, tt.uuid); got != tt.want {
+				t.Errorf("AuthorizationMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizationMode_DuplicateKeys(t *testing.T) {
+	saved := config.Global
+	defer func() { config.Global = saved }()
+
+	config.Global = config.Default()
+	config.Global.AuthorizationByService = map[string]string{
+		"110b":   "allow",
+		"0x110B": "deny",
+	}
+	config.Global.AuthorizationByDevice = map[string]string{
+		"aa:bb:cc:dd:ee:ff": "allow",
+		"AA-BB-CC-DD-EE-FF": "deny",
+	}
+
+	// Map order changes from run to run; the first key in sorted order
+	// must win every time
+	for i := 0; i < 20; i++ {
+		if got := AuthorizationMode(&models.Device{Address: "00:00:00:00:00:01"}, audioSink); got != AuthorizeDeny {
+			t.Fatalf("service rule = %q, want the one for 0x110B", got)
+		}
+		if got := AuthorizationMode(&models.Device{Address: "AA:BB:CC:DD:EE:FF"}, ""); got != AuthorizeDeny {
+			t.Fatalf("device rule = %q, want the one for AA-BB-CC-DD-EE-FF", got)
+		}
+	}
+}
+
+func TestAgent_AuthorizeService(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		trusted bool
+		wantErr bool
+	}{
+		{"allow", AuthorizeAllow, false, false},
+		{"trusted device", AuthorizeTrusted, true, false},
+		{"untrusted device", AuthorizeTrusted, false, true},
+		{"deny", AuthorizeDeny, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, r := newTestAgent(t)
+			config.Global.Authorization = tt.mode
+			a.SetDeviceLookup(func(path dbus.ObjectPath) (*models.Device, error) {
+				return &models.Device{Path: path, Address: "AA:BB:CC:DD:EE:FF", Trusted: tt.trusted}, nil
+			})
+
+			err := a.AuthorizeService(testDevice, audioSink)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AuthorizeService() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if err.Name != errRejected {
+					t.Errorf("error name = %s, want %s", err.Name, errRejected)
+				}
+				if msg, ok := r.next(t).(AuthorizationDeniedMsg); !ok || msg.UUID != audioSink {
+					t.Errorf("the UI should be told about the refusal, got %+v", msg)
+				}
+			}
+		})
+	}
+}
+
+func TestAgent_RequestAuthorization_Prompt(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  func(a *Agent, device dbus.ObjectPath)
+		wantErr bool
+	}{
+		{"allowed", func(a *Agent, device dbus.ObjectPath) { a.Confirm(device) }, false},
+		{"denied", func(a *Agent, device dbus.ObjectPath) { a.Reject(device) }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, r := newTestAgent(t)
+			config.Global.Authorization = AuthorizePrompt
+
+			go func() {
+				msg := r.next(t).(AuthorizeRequestMsg)
+				if msg.UUID != "" || msg.Device.Name != "Keyboard" {
+					t.Errorf("got %+v, want a pairing request from the keyboard", msg)
+				}
+				tt.answer(a, msg.Device.Path)
+			}()
+
+			err := a.RequestAuthorization(testDevice)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RequestAuthorization() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
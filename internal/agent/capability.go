@@ -0,0 +1,49 @@
+package agent
+
+import (
+	"strings"
+
+	"github.com/ivangsm/blugo/internal/config"
+)
+
+// IO capabilities the agent can register with. BlueZ uses them to pick
+// the pairing method, so a capability the user cannot act on makes
+// pairing fail.
+const (
+	CapabilityKeyboardDisplay = "KeyboardDisplay" // PINs and passkeys typed or shown here
+	CapabilityDisplayYesNo    = "DisplayYesNo"    // Passkeys shown and confirmed here
+	CapabilityDisplayOnly     = "DisplayOnly"     // Passkeys shown here, typed on the device
+	CapabilityKeyboardOnly    = "KeyboardOnly"    // Passkeys typed here
+	CapabilityNoInputNoOutput = "NoInputNoOutput" // Just Works pairing only
+)
+
+// Capabilities lists the IO capabilities, most capable first.
+var Capabilities = []string{
+	CapabilityKeyboardDisplay,
+	CapabilityDisplayYesNo,
+	CapabilityDisplayOnly,
+	CapabilityKeyboardOnly,
+	CapabilityNoInputNoOutput,
+}
+
+// ParseCapability returns the IO capability named by name, ignoring case.
+func ParseCapability(name string) (string, bool) {
+	for _, capability := range Capabilities {
+		if strings.EqualFold(name, capability) {
+			return capability, true
+		}
+	}
+	return "", false
+}
+
+// configuredCapability returns the IO capability to register with and
+// whether to ask to become the default agent.
+func configuredCapability() (string, bool) {
+	if config.Global == nil {
+		return CapabilityKeyboardDisplay, true
+	}
+	if config.Global.AgentCapability == "" {
+		return CapabilityKeyboardDisplay, config.Global.AgentDefault
+	}
+	return config.Global.AgentCapability, config.Global.AgentDefault
+}
@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/ivangsm/blugo/internal/config"
+)
+
+func TestParseCapability(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		wantOk bool
+	}{
+		{"KeyboardDisplay", CapabilityKeyboardDisplay, true},
+		{"displayyesno", CapabilityDisplayYesNo, true},
+		{"DISPLAYONLY", CapabilityDisplayOnly, true},
+		{"KeyboardOnly", CapabilityKeyboardOnly, true},
+		{"NoInputNoOutput", CapabilityNoInputNoOutput, true},
+		{"External", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseCapability(tt.name)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("ParseCapability(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestConfiguredCapability(t *testing.T) {
+	saved := config.Global
+	defer func() { config.Global = saved }()
+
+	tests := []struct {
+		name        string
+		cfg         *config.Config
+		want        string
+		wantDefault bool
+	}{
+		{"no config", nil, CapabilityKeyboardDisplay, true},
+		{"configured", &config.Config{AgentCapability: "DisplayYesNo", AgentDefault: false}, "DisplayYesNo", false},
+		{"empty capability", &config.Config{AgentDefault: false}, CapabilityKeyboardDisplay, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Global = tt.cfg
+			got, isDefault := configuredCapability()
+			if got != tt.want || isDefault != tt.wantDefault {
+				t.Errorf("configuredCapability() = %q, %v, want %q, %v", got, isDefault, tt.want, tt.wantDefault)
+			}
+		})
+	}
+}
+
+func TestAgent_Release(t *testing.T) {
+	a := NewAgent(nil)
+	a.capability, a.isDefault = CapabilityKeyboardDisplay, true
+
+	_ = a.Release()
+	if capability, isDefault := a.Registration(); capability != "" || isDefault {
+		t.Errorf("Registration() = %q, %v after Release, want nothing", capability, isDefault)
+	}
+}
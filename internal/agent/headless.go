@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ivangsm/blugo/internal/config"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// HeadlessPolicy decides the requests the headless agent answers on its
+// own. Anything it does not accept is rejected.
+type HeadlessPolicy struct {
+	JustWorks      bool     // Accept pairings without a code
+	AllowedDevices []string // Addresses whose passkey comparisons and service requests are accepted
+	PinCode        string   // PIN given to legacy devices; empty rejects them
+}
+
+// HeadlessPolicyFromConfig returns the policy set in the configuration.
+func HeadlessPolicyFromConfig() HeadlessPolicy {
+	if config.Global == nil {
+		return HeadlessPolicy{}
+	}
+	return HeadlessPolicy{
+		JustWorks:      config.Global.HeadlessJustWorks,
+		AllowedDevices: config.Global.HeadlessAllowedDevices,
+		PinCode:        config.Global.HeadlessPinCode,
+	}
+}
+
+// allows reports whether dev is on the allow list.
+func (p HeadlessPolicy) allows(dev *models.Device) bool {
+	address := models.NormalizeMAC(dev.Address)
+	for _, allowed := range p.AllowedDevices {
+		if address != "" && models.NormalizeMAC(allowed) == address {
+			return true
+		}
+	}
+	return false
+}
+
+// NewHeadlessAgent creates an agent that answers requests according to
+// policy instead of asking a user, logging every decision. Pairings
+// without a code are decided by policy.JustWorks alone, whatever the
+// authorization policy says. The remote device starts the pairing, so the
+// configured PINs, which are tried one per pairing attempt, are replaced
+// by the policy's PIN.
+func NewHeadlessAgent(policy HeadlessPolicy, logger *slog.Logger) *Agent {
+	a := NewAgent(nil)
+	r := &responder{agent: a, policy: policy, logger: logger}
+	a.program = r
+	a.pinCodes = func(*models.Device) []string { return nil }
+	a.justWorks = func(dev *models.Device) bool {
+		return r.decide(dev, "just-works", policy.JustWorks)
+	}
+	return a
+}
+
+// responder stands in for the user interface of a headless agent. The
+// agent opens a session before sending a request, so answering from Send
+// reaches it.
+type responder struct {
+	agent  *Agent
+	policy HeadlessPolicy
+	logger *slog.Logger
+}
+
+// Send answers requests and logs notifications.
+func (r *responder) Send(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case AuthorizeRequestMsg:
+		// Pairings without a code never get here, see NewHeadlessAgent
+		accept := r.decide(msg.Device, "service", r.policy.allows(msg.Device),
+			slog.String("uuid", msg.UUID), slog.String("profile", models.ServiceName(msg.UUID)))
+		r.confirm(msg.Device, accept)
+
+	case ConfirmPasskeyMsg:
+		accept := r.decide(msg.Device, "numeric-comparison", r.policy.allows(msg.Device),
+			slog.String("passkey", formatPasskey(msg.Passkey)))
+		r.confirm(msg.Device, accept)
+
+	case PinCodeRequestMsg:
+		if r.decide(msg.Device, "pin-code", ValidPinCode(r.policy.PinCode)) {
+			r.agent.AnswerPinCode(msg.Device.Path, r.policy.PinCode)
+		} else {
+			r.agent.Reject(msg.Device.Path)
+		}
+
+	case PasskeyRequestMsg:
+		// Nobody can read the passkey shown by the device
+		r.decide(msg.Device, "passkey-entry", false)
+		r.agent.Reject(msg.Device.Path)
+
+	case DisplayPasskeyMsg:
+		r.log(slog.LevelInfo, "passkey to type on the device", msg.Device,
+			slog.String("passkey", formatPasskey(msg.Passkey)), slog.Int("entered", int(msg.Entered)))
+
+	case DisplayPinCodeMsg:
+		r.log(slog.LevelInfo, "PIN to type on the device", msg.Device, slog.String("pin", msg.PinCode))
+
+	case AuthorizationDeniedMsg:
+		r.decide(msg.Device, "authorization-policy", false, slog.String("uuid", msg.UUID))
+
+	case RequestCancelledMsg:
+		r.log(slog.LevelWarn, "request cancelled", msg.Device, slog.Bool("timed_out", msg.TimedOut))
+	}
+}
+
+// decide logs the decision taken on a request and returns it.
+func (r *responder) decide(dev *models.Device, method string, accept bool, attrs ...slog.Attr) bool {
+	decision := "reject"
+	if accept {
+		decision = "accept"
+	}
+	attrs = append([]slog.Attr{slog.String("method", method), slog.String("decision", decision)}, attrs...)
+	r.log(slog.LevelInfo, "pairing request", dev, attrs...)
+	return accept
+}
+
+// confirm answers a request that only needs accepting or rejecting.
+func (r *responder) confirm(dev *models.Device, accept bool) {
+	if accept {
+		r.agent.Confirm(dev.Path)
+	} else {
+		r.agent.Reject(dev.Path)
+	}
+}
+
+// log writes a record about dev.
+func (r *responder) log(level slog.Level, msg string, dev *models.Device, attrs ...slog.Attr) {
+	attrs = append([]slog.Attr{slog.String("device", dev.Address), slog.String("name", dev.Name)}, attrs...)
+	r.logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
@@ -0,0 +1,130 @@
+package agent
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/config"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// newTestHeadlessAgent returns a headless agent logging to the returned
+// buffer, with the authorization policy leaving every request to it.
+func newTestHeadlessAgent(t *testing.T, policy HeadlessPolicy) (*Agent, *bytes.Buffer) {
+	t.Helper()
+	saved := config.Global
+	t.Cleanup(func() { config.Global = saved })
+	config.Global = config.Default()
+	config.Global.Authorization = AuthorizePrompt
+
+	var logs bytes.Buffer
+	a := NewHeadlessAgent(policy, slog.New(slog.NewJSONHandler(&logs, nil)))
+	a.SetDeviceLookup(func(path dbus.ObjectPath) (*models.Device, error) {
+		return &models.Device{Path: path, Address: addressFromPath(path), Name: "Phone"}, nil
+	})
+	return a, &logs
+}
+
+func TestHeadlessAgent(t *testing.T) {
+	allowed := dbus.ObjectPath("/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF")
+	stranger := dbus.ObjectPath("/org/bluez/hci0/dev_11_22_33_44_55_66")
+	policy := HeadlessPolicy{
+		JustWorks:      true,
+		AllowedDevices: []string{"aa:bb:cc:dd:ee:ff"},
+		PinCode:        "2468",
+	}
+
+	tests := []struct {
+		name    string
+		policy  HeadlessPolicy
+		request func(a *Agent) *dbus.Error
+		wantErr bool
+		wantLog string
+	}{
+		{"just works accepted", policy, func(a *Agent) *dbus.Error { return a.RequestAuthorization(stranger) }, false, `"method":"just-works","decision":"accept"`},
+		{"just works rejected", HeadlessPolicy{}, func(a *Agent) *dbus.Error { return a.RequestAuthorization(stranger) }, true, `"decision":"reject"`},
+		{"comparison from an allowed device", policy, func(a *Agent) *dbus.Error { return a.RequestConfirmation(allowed, 123456) }, false, `"passkey":"123456"`},
+		{"comparison from another device", policy, func(a *Agent) *dbus.Error { return a.RequestConfirmation(stranger, 123456) }, true, `"method":"numeric-comparison","decision":"reject"`},
+		{"service for an allowed device", policy, func(a *Agent) *dbus.Error {
+			return a.AuthorizeService(allowed, "0000110b-0000-1000-8000-00805f9b34fb")
+		}, false, `"profile":"Audio Sink (A2DP)"`},
+		{"passkey entry", policy, func(a *Agent) *dbus.Error {
+			_, err := a.RequestPasskey(allowed)
+			return err
+		}, true, `"method":"passkey-entry"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, logs := newTestHeadlessAgent(t, tt.policy)
+
+			err := tt.request(a)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(logs.String(), tt.wantLog) {
+				t.Errorf("log should contain %s:\n%s", tt.wantLog, logs.String())
+			}
+		})
+	}
+}
+
+func TestHeadlessAgent_JustWorksBeforeAuthorization(t *testing.T) {
+	stranger := dbus.ObjectPath("/org/bluez/hci0/dev_11_22_33_44_55_66")
+
+	tests := []struct {
+		name          string
+		authorization string
+		justWorks     bool
+		wantErr       bool
+	}{
+		{"allow does not accept without just works", AuthorizeAllow, false, true},
+		{"trusted does not accept without just works", AuthorizeTrusted, false, true},
+		{"deny does not reject with just works", AuthorizeDeny, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, logs := newTestHeadlessAgent(t, HeadlessPolicy{JustWorks: tt.justWorks})
+			config.Global.Authorization = tt.authorization
+			a.SetDeviceLookup(func(path dbus.ObjectPath) (*models.Device, error) {
+				return &models.Device{Path: path, Address: addressFromPath(path), Name: "Phone", Trusted: true}, nil
+			})
+
+			err := a.RequestAuthorization(stranger)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(logs.String(), `"method":"just-works"`) {
+				t.Errorf("the decision should be logged:\n%s", logs.String())
+			}
+		})
+	}
+}
+
+func TestHeadlessAgent_PinCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		pin     string
+		want    string
+		wantErr bool
+	}{
+		{"fixed PIN", "2468", "2468", false},
+		{"no PIN configured", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := newTestHeadlessAgent(t, HeadlessPolicy{PinCode: tt.pin})
+
+			// The configured pin_codes are not tried
+			pin, err := a.RequestPinCode("/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF")
+			if pin != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("RequestPinCode() = %q, %v, want %q, wantErr %v", pin, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package agent
+
+import "github.com/ivangsm/blugo/internal/models"
+
+// PinCodeTryMsg is sent when the agent answers a PIN request with one of
+// the configured PINs.
+type PinCodeTryMsg struct {
+	Device  *models.Device
+	PinCode string
+}
+
+// PinCodeRequestMsg asks the user for the PIN of a legacy device once the
+// configured PINs are exhausted. Answer it with AnswerPinCode or Reject.
+type PinCodeRequestMsg struct {
+	Device *models.Device
+}
+
+// DisplayPinCodeMsg carries a PIN to type on the remote device.
+type DisplayPinCodeMsg struct {
+	Device  *models.Device
+	PinCode string
+}
+
+// PasskeyRequestMsg asks the user for the passkey shown on the remote
+// device. Answer it with AnswerPasskey or Reject.
+type PasskeyRequestMsg struct {
+	Device *models.Device
+}
+
+// DisplayPasskeyMsg carries a passkey to type on the remote device and
+// how many of its digits have been typed there so far.
+type DisplayPasskeyMsg struct {
+	Device  *models.Device
+	Passkey uint32
+	Entered uint16
+}
+
+// ConfirmPasskeyMsg asks the user to confirm that the device shows the
+// same passkey. Answer it with Confirm or Reject.
+type ConfirmPasskeyMsg struct {
+	Device  *models.Device
+	Passkey uint32
+}
+
+// AuthorizeRequestMsg asks the user whether the device may use the
+// service UUID, or pair when UUID is empty. Answer it with Confirm or
+// Reject.
+type AuthorizeRequestMsg struct {
+	Device *models.Device
+	UUID   string
+}
+
+// AuthorizationDeniedMsg is sent when the authorization policy refuses a
+// request without asking.
+type AuthorizationDeniedMsg struct {
+	Device *models.Device
+	UUID   string
+}
+
+// RequestCancelledMsg is sent when the agent stops waiting for the user
+// because the request's deadline passed or BlueZ cancelled it.
+type RequestCancelledMsg struct {
+	Device   *models.Device
+	TimedOut bool
+}
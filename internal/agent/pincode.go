@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"strings"
+
+	"github.com/ivangsm/blugo/internal/config"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// maxPinCodeLength is the longest PIN legacy pairing accepts.
+const maxPinCodeLength = 16
+
+// defaultPinCodes are tried when no configuration is loaded.
+var defaultPinCodes = []string{"0000", "1234", "1111"}
+
+// pinState tracks the PINs handed out to one device while pairing.
+type pinState struct {
+	next     int  // Index of the next automatic PIN to hand out
+	prompted bool // The user was asked, so the pairing is not retried
+}
+
+// ValidPinCode reports whether pin can be used for legacy pairing:
+// between 1 and 16 printable ASCII characters.
+func ValidPinCode(pin string) bool {
+	if pin == "" || len(pin) > maxPinCodeLength {
+		return false
+	}
+	for _, r := range pin {
+		if r < 0x20 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// PinCodes returns the PINs to try automatically with dev, most specific
+// first: the ones configured for its address, then for its device type or
+// major class, then the general list. Lists under several spellings of
+// the same key follow each other in sorted key order. Invalid and
+// repeated entries are skipped.
+func PinCodes(dev *models.Device) []string {
+	if config.Global == nil {
+		return defaultPinCodes
+	}
+
+	var lists [][]string
+	address := models.NormalizeMAC(dev.Address)
+	for _, key := range sortedKeys(config.Global.PinCodesByDevice) {
+		if models.NormalizeMAC(key) == address {
+			lists = append(lists, config.Global.PinCodesByDevice[key])
+		}
+	}
+	classes := []string{dev.Type().Kind.String(), dev.MajorClassName()}
+	for _, class := range classes {
+		for _, key := range sortedKeys(config.Global.PinCodesByClass) {
+			if strings.EqualFold(key, class) {
+				lists = append(lists, config.Global.PinCodesByClass[key])
+			}
+		}
+	}
+	lists = append(lists, config.Global.PinCodes)
+
+	var codes []string
+	seen := make(map[string]bool)
+	for _, pins := range lists {
+		for _, pin := range pins {
+			if ValidPinCode(pin) && !seen[pin] {
+				seen[pin] = true
+				codes = append(codes, pin)
+			}
+		}
+	}
+	return codes
+}
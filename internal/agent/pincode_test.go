@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/config"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+func TestValidPinCode(t *testing.T) {
+	tests := []struct {
+		pin  string
+		want bool
+	}{
+		{"0000", true},
+		{"A1b2", true},
+		{"1234567890123456", true},
+		{"", false},
+		{"12345678901234567", false},
+		{"12\n4", false},
+		{"ñ123", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pin, func(t *testing.T) {
+			if got := ValidPinCode(tt.pin); got != tt.want {
+				t.Errorf("ValidPinCode(%q) = %v, want %v", tt.pin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPinCodes(t *testing.T) {
+	saved := config.Global
+	defer func() { config.Global = saved }()
+
+	cfg := config.Default()
+	cfg.PinCodesByClass = map[string][]string{
+		"printer": {"4321"},
+		"Imaging": {"0000", "9999"},
+	}
+	cfg.PinCodesByDevice = map[string][]string{
+		"aa-bb-cc-dd-ee-ff": {"8888", ""},
+	}
+	config.Global = cfg
+
+	tests := []struct {
+		name   string
+		device *models.Device
+		want   []string
+	}{
+		{"general list", &models.Device{Address: "11:22:33:44:55:66"}, []string{"0000", "1234", "1111"}},
+		{"kind before major class", &models.Device{Address: "11:22:33:44:55:66", Class: 0x040680}, []string{"4321", "0000", "9999", "1234", "1111"}},
+		{"device first, invalid skipped", &models.Device{Address: "AA:BB:CC:DD:EE:FF"}, []string{"8888", "0000", "1234", "1111"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PinCodes(tt.device); !slices.Equal(got, tt.want) {
+				t.Errorf("PinCodes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	config.Global = nil
+	if got := PinCodes(&models.Device{}); !slices.Equal(got, defaultPinCodes) {
+		t.Errorf("PinCodes() without config = %v, want %v", got, defaultPinCodes)
+	}
+}
+
+func TestAgent_RequestPinCode_TriesConfiguredPins(t *testing.T) {
+	saved := config.Global
+	defer func() { config.Global = saved }()
+	config.Global = config.Default()
+	config.Global.PinCodes = []string{"0000", "1234"}
+
+	a := NewAgent(nil)
+	device := dbus.ObjectPath("/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF")
+
+	// A pairing the remote device started goes straight to the user, who
+	// cannot be asked without a program
+	if _, err := a.RequestPinCode(device); err == nil || err.Name != errRejected {
+		t.Errorf("RequestPinCode() for a remote pairing error = %v, want %s", err, errRejected)
+	}
+
+	a.TryPinCodes(device)
+	if a.RetryPinCode(device) {
+		t.Error("RetryPinCode() should be false before any PIN was requested")
+	}
+
+	for _, want := range []string{"0000", "1234"} {
+		pin, err := a.RequestPinCode(device)
+		if err != nil || pin != want {
+			t.Fatalf("RequestPinCode() = %q, %v; want %q", pin, err, want)
+		}
+		if !a.RetryPinCode(device) {
+			t.Fatalf("RetryPinCode() after %q should be true", want)
+		}
+	}
+
+	// Without a program nobody can be asked, so the request is rejected
+	if _, err := a.RequestPinCode(device); err == nil || err.Name != errRejected {
+		t.Errorf("RequestPinCode() error = %v, want %s", err, errRejected)
+	}
+	if a.RetryPinCode(device) {
+		t.Error("RetryPinCode() should be false once the user was asked")
+	}
+
+	a.ResetPinCode(device)
+	a.TryPinCodes(device)
+	if pin, _ := a.RequestPinCode(device); pin != "0000" {
+		t.Errorf("RequestPinCode() after reset = %q, want 0000", pin)
+	}
+}
+
+func TestAddressFromPath(t *testing.T) {
+	tests := []struct {
+		path dbus.ObjectPath
+		want string
+	}{
+		{"/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF", "AA:BB:CC:DD:EE:FF"},
+		{"/org/bluez/hci0", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.path), func(t *testing.T) {
+			if got := addressFromPath(tt.path); got != tt.want {
+				t.Errorf("addressFromPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
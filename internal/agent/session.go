@@ -0,0 +1,147 @@
+package agent
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// reply is the user's answer to a session.
+type reply struct {
+	value string // PIN or passkey; empty for confirmations
+	ok    bool   // False when the user rejected the request
+}
+
+// session is one BlueZ request waiting for the user. Sessions are keyed
+// by the device's object path, so the answer to a prompt can only reach
+// the request of the device it was shown for.
+type session struct {
+	device   *models.Device
+	deadline time.Time
+	replies  chan reply
+	done     chan struct{} // Closed when the session ends without a reply
+	notify   bool          // Tell the UI when done is closed by a cancellation
+}
+
+// Confirm accepts the passkey shown for device.
+func (a *Agent) Confirm(device dbus.ObjectPath) {
+	a.answer(device, reply{ok: true})
+}
+
+// AnswerPinCode hands the PIN typed by the user to device's pending
+// RequestPinCode.
+func (a *Agent) AnswerPinCode(device dbus.ObjectPath, pin string) {
+	a.answer(device, reply{value: pin, ok: true})
+}
+
+// AnswerPasskey hands the passkey typed by the user to device's pending
+// RequestPasskey.
+func (a *Agent) AnswerPasskey(device dbus.ObjectPath, passkey uint32) {
+	a.answer(device, reply{value: formatPasskey(passkey), ok: true})
+}
+
+// Reject turns down device's pending request.
+func (a *Agent) Reject(device dbus.ObjectPath) {
+	a.answer(device, reply{})
+}
+
+// Pending reports whether device has a request waiting for the user.
+func (a *Agent) Pending(device dbus.ObjectPath) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.sessions[device]
+	return ok
+}
+
+// Cancel is called by BlueZ when the request it is waiting for failed,
+// e.g. because the remote device gave up. BlueZ has at most one request
+// outstanding per agent and does not say which, so every session ends.
+func (a *Agent) Cancel() *dbus.Error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for path, s := range a.sessions {
+		s.notify = true
+		close(s.done)
+		delete(a.sessions, path)
+	}
+	return nil
+}
+
+// answer delivers r to device's session, if it has one.
+func (a *Agent) answer(device dbus.ObjectPath, r reply) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if s, ok := a.sessions[device]; ok {
+		select {
+		case s.replies <- r:
+		default:
+		}
+	}
+}
+
+// open starts a session for dev. A session still open for the same
+// device is superseded silently: the UI replaces its prompt with the one
+// for the new request.
+func (a *Agent) open(dev *models.Device) *session {
+	s := &session{
+		device:   dev,
+		deadline: time.Now().Add(timeout()),
+		replies:  make(chan reply, 1),
+		done:     make(chan struct{}),
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if old, ok := a.sessions[dev.Path]; ok {
+		close(old.done)
+	}
+	a.sessions[dev.Path] = s
+	return s
+}
+
+// close removes s unless it already ended.
+func (a *Agent) close(s *session) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.sessions[s.device.Path] == s {
+		delete(a.sessions, s.device.Path)
+	}
+}
+
+// ask opens a session for dev, sends request to the program and waits for
+// the user's answer. The wait ends early when BlueZ cancels the request or
+// the session's deadline passes; the UI is then told to close its prompt.
+func (a *Agent) ask(dev *models.Device, request tea.Msg) (reply, *dbus.Error) {
+	if a.program == nil {
+		return reply{}, dbus.NewError(errRejected, []interface{}{i18n.T.ErrorPairingCancelled})
+	}
+
+	s := a.open(dev)
+	defer a.close(s)
+	a.send(request)
+
+	timer := time.NewTimer(time.Until(s.deadline))
+	defer timer.Stop()
+
+	select {
+	case r := <-s.replies:
+		if !r.ok {
+			return r, dbus.NewError(errRejected, []interface{}{i18n.T.ErrorPairingCancelled})
+		}
+		return r, nil
+	case <-s.done:
+		a.mu.Lock()
+		notify := s.notify
+		a.mu.Unlock()
+		if notify {
+			a.send(RequestCancelledMsg{Device: dev})
+		}
+		return reply{}, dbus.NewError(errCanceled, []interface{}{i18n.T.ErrorPairingCancelled})
+	case <-timer.C:
+		a.send(RequestCancelledMsg{Device: dev, TimedOut: true})
+		return reply{}, dbus.NewError(errCanceled, []interface{}{i18n.T.ErrorRequestTimeout})
+	}
+}
@@ -1,16 +1,26 @@
 package bluetooth
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/godbus/dbus/v5"
 	"github.com/ivangsm/blugo/internal/i18n"
 )
 
-// StartDiscovery starts scanning for Bluetooth devices.
+// StartDiscovery starts scanning for Bluetooth devices, applying the
+// discovery filter first when one is set.
 func (m *Manager) StartDiscovery() error {
-	obj := m.conn.Object(bluezService, m.adapter)
-	err := obj.Call(bluezAdapterIface+".StartDiscovery", 0).Err
+	obj, err := m.adapterObject()
+	if err != nil {
+		return err
+	}
+	if filter := m.DiscoveryFilter(); !filter.IsDefault() {
+		if err := m.setDiscoveryFilter(obj, filter); err != nil {
+			return err
+		}
+	}
+	err = call(context.Background(), m.Timeouts().Other, obj, bluezAdapterIface+".StartDiscovery")
 	if err != nil {
 		return fmt.Errorf(i18n.T.ErrorStartDiscovery+": %w", err)
 	}
@@ -19,8 +29,11 @@ func (m *Manager) StartDiscovery() error {
 
 // StopDiscovery stops scanning for Bluetooth devices.
 func (m *Manager) StopDiscovery() error {
-	obj := m.conn.Object(bluezService, m.adapter)
-	err := obj.Call(bluezAdapterIface+".StopDiscovery", 0).Err
+	obj, err := m.adapterObject()
+	if err != nil {
+		return err
+	}
+	err = call(context.Background(), m.Timeouts().Other, obj, bluezAdapterIface+".StopDiscovery")
 	if err != nil {
 		return fmt.Errorf(i18n.T.ErrorStopDiscovery+": %w", err)
 	}
@@ -29,8 +42,16 @@ func (m *Manager) StopDiscovery() error {
 
 // RemoveDevice removes a device from the adapter.
 func (m *Manager) RemoveDevice(devicePath dbus.ObjectPath) error {
-	obj := m.conn.Object(bluezService, m.adapter)
-	err := obj.Call(bluezAdapterIface+".RemoveDevice", 0, devicePath).Err
+	return m.RemoveDeviceContext(context.Background(), devicePath)
+}
+
+// RemoveDeviceContext is RemoveDevice giving up when ctx ends.
+func (m *Manager) RemoveDeviceContext(ctx context.Context, devicePath dbus.ObjectPath) error {
+	obj, err := m.adapterObject()
+	if err != nil {
+		return err
+	}
+	err = call(ctx, m.Timeouts().Other, obj, bluezAdapterIface+".RemoveDevice", devicePath)
 	if err != nil {
 		return fmt.Errorf(i18n.T.ErrorRemoveDevice+": %w", err)
 	}
@@ -1,7 +1,10 @@
 package bluetooth
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/godbus/dbus/v5"
@@ -9,78 +12,47 @@ import (
 	"github.com/ivangsm/blugo/internal/models"
 )
 
-// getDevices gets all Bluetooth devices from the system.
-func getDevices(conn *dbus.Conn) (map[string]*models.Device, error) {
-	obj := conn.Object(bluezService, "/")
-	var paths map[string]map[string]map[string]dbus.Variant
-	err := obj.Call("org.freedesktop.DBus.ObjectManager.GetManagedObjects", 0).Store(&paths)
-	if err != nil {
-		return nil, err
-	}
-
+// parseDevices extracts the Device1 objects that belong to adapter.
+// Devices with properties that could not be decoded are still returned;
+// the joined error describes every mismatch.
+func parseDevices(objects objectMap, adapter dbus.ObjectPath) (map[string]*models.Device, error) {
 	devices := make(map[string]*models.Device)
-	for path, interfaces := range paths {
+	var errs []error
+	for path, interfaces := range objects {
+		if !belongsToAdapter(path, adapter) {
+			continue
+		}
 		if props, ok := interfaces[bluezDeviceIface]; ok {
-			dev := parseDevice(dbus.ObjectPath(path), interfaces, props)
+			dev, err := parseDevice(path, interfaces, props)
+			if err != nil {
+				errs = append(errs, err)
+			}
 			devices[dev.Address] = dev
 		}
 	}
 
-	return devices, nil
+	return devices, errors.Join(errs...)
+}
+
+// belongsToAdapter reports whether a device object lives under adapter.
+func belongsToAdapter(device, adapter dbus.ObjectPath) bool {
+	return adapter != "" && strings.HasPrefix(string(device), string(adapter)+"/")
+}
+
+// batteryProps holds the org.bluez.Battery1 properties blugo uses.
+type batteryProps struct {
+	Percentage *uint8 `dbus:"Percentage"`
 }
 
 // parseDevice converts DBus properties into a Device model.
-func parseDevice(path dbus.ObjectPath, interfaces map[string]map[string]dbus.Variant, props map[string]dbus.Variant) *models.Device {
+// The device is always returned; err reports properties of an unexpected type.
+func parseDevice(path dbus.ObjectPath, interfaces map[string]map[string]dbus.Variant, props map[string]dbus.Variant) (*models.Device, error) {
 	dev := &models.Device{
 		Path:     path,
 		LastSeen: time.Now(),
 	}
 
-	if variant, ok := props["Address"]; ok {
-		if v, ok := variant.Value().(string); ok {
-			dev.Address = v
-		}
-	}
-	if variant, ok := props["Name"]; ok {
-		if v, ok := variant.Value().(string); ok {
-			dev.Name = v
-		}
-	}
-	if variant, ok := props["Alias"]; ok {
-		if v, ok := variant.Value().(string); ok {
-			dev.Alias = v
-		}
-	}
-	if variant, ok := props["Paired"]; ok {
-		if v, ok := variant.Value().(bool); ok {
-			dev.Paired = v
-		}
-	}
-	if variant, ok := props["Trusted"]; ok {
-		if v, ok := variant.Value().(bool); ok {
-			dev.Trusted = v
-		}
-	}
-	if variant, ok := props["Connected"]; ok {
-		if v, ok := variant.Value().(bool); ok {
-			dev.Connected = v
-		}
-	}
-	if variant, ok := props["RSSI"]; ok {
-		if v, ok := variant.Value().(int16); ok {
-			dev.RSSI = v
-		}
-	}
-	if variant, ok := props["Icon"]; ok {
-		if v, ok := variant.Value().(string); ok {
-			dev.Icon = v
-		}
-	}
-	if variant, ok := props["Class"]; ok {
-		if v, ok := variant.Value().(uint32); ok {
-			dev.Class = v
-		}
-	}
+	errs := []error{decodeProperties(props, dev)}
 
 	// Use Alias as Name if no Name is set and Alias is not the MAC address
 	// BlueZ sets Alias to the MAC address (with - instead of :) when there's no real name
@@ -93,21 +65,29 @@ func parseDevice(path dbus.ObjectPath, interfaces map[string]map[string]dbus.Var
 	}
 
 	// Get battery information if available
-	if batteryProps, ok := interfaces[bluezBatteryIface]; ok {
-		if variant, ok := batteryProps["Percentage"]; ok {
-			if percentage, ok := variant.Value().(byte); ok {
-				dev.Battery = &percentage
-			}
-		}
+	if props, ok := interfaces[bluezBatteryIface]; ok {
+		var battery batteryProps
+		errs = append(errs, decodeProperties(props, &battery))
+		dev.Battery = battery.Percentage
 	}
 
-	return dev
+	if err := errors.Join(errs...); err != nil {
+		return dev, fmt.Errorf("%s: %w", path, err)
+	}
+	return dev, nil
 }
 
 // PairDevice pairs a device.
 func (m *Manager) PairDevice(devicePath dbus.ObjectPath) error {
+	return m.PairDeviceContext(context.Background(), devicePath)
+}
+
+// PairDeviceContext is PairDevice giving up when ctx ends. BlueZ goes on
+// pairing after the call is abandoned; use CancelPairing to stop it.
+func (m *Manager) PairDeviceContext(ctx context.Context, devicePath dbus.ObjectPath) error {
+	defer m.beginTransition(devicePath, models.StatePairing)()
 	obj := m.conn.Object(bluezService, devicePath)
-	err := obj.Call(bluezDeviceIface+".Pair", 0).Err
+	err := call(ctx, m.Timeouts().Pair, obj, bluezDeviceIface+".Pair")
 	if err != nil {
 		return fmt.Errorf(i18n.T.ErrorPairDevice+": %w", err)
 	}
@@ -117,8 +97,8 @@ func (m *Manager) PairDevice(devicePath dbus.ObjectPath) error {
 // TrustDevice marks a device as trusted.
 func (m *Manager) TrustDevice(devicePath dbus.ObjectPath) error {
 	obj := m.conn.Object(bluezService, devicePath)
-	err := obj.Call("org.freedesktop.DBus.Properties.Set", 0,
-		bluezDeviceIface, "Trusted", dbus.MakeVariant(true)).Err
+	err := call(context.Background(), m.Timeouts().Other, obj, propertiesIface+".Set",
+		bluezDeviceIface, "Trusted", dbus.MakeVariant(true))
 	if err != nil {
 		return fmt.Errorf(i18n.T.ErrorTrustDevice+": %w", err)
 	}
@@ -127,8 +107,14 @@ func (m *Manager) TrustDevice(devicePath dbus.ObjectPath) error {
 
 // ConnectDevice connects to a device.
 func (m *Manager) ConnectDevice(devicePath dbus.ObjectPath) error {
+	return m.ConnectDeviceContext(context.Background(), devicePath)
+}
+
+// ConnectDeviceContext is ConnectDevice giving up when ctx ends.
+func (m *Manager) ConnectDeviceContext(ctx context.Context, devicePath dbus.ObjectPath) error {
+	defer m.beginTransition(devicePath, models.StateConnecting)()
 	obj := m.conn.Object(bluezService, devicePath)
-	err := obj.Call(bluezDeviceIface+".Connect", 0).Err
+	err := call(ctx, m.Timeouts().Connect, obj, bluezDeviceIface+".Connect")
 	if err != nil {
 		return fmt.Errorf(i18n.T.ErrorConnectDevice+": %w", err)
 	}
@@ -137,10 +123,61 @@ func (m *Manager) ConnectDevice(devicePath dbus.ObjectPath) error {
 
 // DisconnectDevice disconnects a device.
 func (m *Manager) DisconnectDevice(devicePath dbus.ObjectPath) error {
+	return m.DisconnectDeviceContext(context.Background(), devicePath)
+}
+
+// DisconnectDeviceContext is DisconnectDevice giving up when ctx ends.
+func (m *Manager) DisconnectDeviceContext(ctx context.Context, devicePath dbus.ObjectPath) error {
+	defer m.beginTransition(devicePath, models.StateDisconnecting)()
 	obj := m.conn.Object(bluezService, devicePath)
-	err := obj.Call(bluezDeviceIface+".Disconnect", 0).Err
+	err := call(ctx, m.Timeouts().Disconnect, obj, bluezDeviceIface+".Disconnect")
 	if err != nil {
 		return fmt.Errorf(i18n.T.ErrorDisconnectDevice+": %w", err)
 	}
 	return nil
 }
+
+// ConnectProfile connects a single profile of a device, identified by the
+// UUID of the remote service, e.g. the audio sink of a headset.
+func (m *Manager) ConnectProfile(devicePath dbus.ObjectPath, uuid string) error {
+	return m.ConnectProfileContext(context.Background(), devicePath, uuid)
+}
+
+// ConnectProfileContext is ConnectProfile giving up when ctx ends.
+func (m *Manager) ConnectProfileContext(ctx context.Context, devicePath dbus.ObjectPath, uuid string) error {
+	defer m.beginTransition(devicePath, models.StateConnecting)()
+	obj := m.conn.Object(bluezService, devicePath)
+	err := call(ctx, m.Timeouts().Connect, obj, bluezDeviceIface+".ConnectProfile", uuid)
+	if err != nil {
+		return fmt.Errorf(i18n.T.ErrorConnectProfile+" %s: %w", models.ProfileLabel(uuid), err)
+	}
+	return nil
+}
+
+// DisconnectProfile disconnects a single profile of a device, leaving the
+// others connected.
+func (m *Manager) DisconnectProfile(devicePath dbus.ObjectPath, uuid string) error {
+	return m.DisconnectProfileContext(context.Background(), devicePath, uuid)
+}
+
+// DisconnectProfileContext is DisconnectProfile giving up when ctx ends.
+// The device stays connected through its other profiles, so no
+// transition is recorded.
+func (m *Manager) DisconnectProfileContext(ctx context.Context, devicePath dbus.ObjectPath, uuid string) error {
+	obj := m.conn.Object(bluezService, devicePath)
+	err := call(ctx, m.Timeouts().Disconnect, obj, bluezDeviceIface+".DisconnectProfile", uuid)
+	if err != nil {
+		return fmt.Errorf(i18n.T.ErrorDisconnectProfile+" %s: %w", models.ProfileLabel(uuid), err)
+	}
+	return nil
+}
+
+// CancelPairing stops a pairing in progress with a device.
+func (m *Manager) CancelPairing(devicePath dbus.ObjectPath) error {
+	obj := m.conn.Object(bluezService, devicePath)
+	err := call(context.Background(), m.Timeouts().Other, obj, bluezDeviceIface+".CancelPairing")
+	if err != nil {
+		return fmt.Errorf(i18n.T.ErrorCancelPairing+": %w", err)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package bluetooth
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -322,7 +324,10 @@ func TestParseDevice(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			dev := parseDevice(tt.path, tt.interfaces, tt.props)
+			dev, err := parseDevice(tt.path, tt.interfaces, tt.props)
+			if err != nil {
+				t.Fatalf("parseDevice() error = %v", err)
+			}
 			if dev == nil {
 				t.Fatal("parseDevice returned nil")
 			}
@@ -338,11 +343,14 @@ func TestParseDevice_EdgeCases(t *testing.T) {
 	}
 
 	t.Run("handles empty props map", func(t *testing.T) {
-		dev := parseDevice(
+		dev, err := parseDevice(
 			"/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF",
 			map[string]map[string]dbus.Variant{},
 			map[string]dbus.Variant{},
 		)
+		if err != nil {
+			t.Fatalf("parseDevice() error = %v", err)
+		}
 		if dev == nil {
 			t.Fatal("parseDevice should not return nil even with empty props")
 		}
@@ -352,7 +360,7 @@ func TestParseDevice_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("handles Name without Alias", func(t *testing.T) {
-		dev := parseDevice(
+		dev, err := parseDevice(
 			"/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF",
 			map[string]map[string]dbus.Variant{
 				bluezDeviceIface: {
@@ -363,6 +371,9 @@ func TestParseDevice_EdgeCases(t *testing.T) {
 				"Name": makeVariant("Device Name"),
 			},
 		)
+		if err != nil {
+			t.Fatalf("parseDevice() error = %v", err)
+		}
 		if dev.Name != "Device Name" {
 			t.Errorf("Name = %v, want Device Name", dev.Name)
 		}
@@ -372,7 +383,7 @@ func TestParseDevice_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("preserves Name when both Name and Alias exist", func(t *testing.T) {
-		dev := parseDevice(
+		dev, err := parseDevice(
 			"/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF",
 			map[string]map[string]dbus.Variant{
 				bluezDeviceIface: {
@@ -385,6 +396,9 @@ func TestParseDevice_EdgeCases(t *testing.T) {
 				"Alias": makeVariant("Device Alias"),
 			},
 		)
+		if err != nil {
+			t.Fatalf("parseDevice() error = %v", err)
+		}
 		if dev.Name != "Original Name" {
 			t.Errorf("Name = %v, want Original Name (should not be overwritten by Alias)", dev.Name)
 		}
@@ -421,7 +435,7 @@ func TestParseDevice_DoesNotUseAliasWhenItIsMAC(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			dev := parseDevice(
+			dev, err := parseDevice(
 				"/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF",
 				map[string]map[string]dbus.Variant{
 					bluezDeviceIface: {
@@ -436,6 +450,9 @@ func TestParseDevice_DoesNotUseAliasWhenItIsMAC(t *testing.T) {
 					"Alias":   makeVariant(tt.alias),
 				},
 			)
+			if err != nil {
+				t.Fatalf("parseDevice() error = %v", err)
+			}
 
 			if dev.Name != "" {
 				t.Errorf("Name should be empty when Alias is MAC address, got %q", dev.Name)
@@ -446,3 +463,26 @@ func TestParseDevice_DoesNotUseAliasWhenItIsMAC(t *testing.T) {
 		})
 	}
 }
+
+func TestIsAuthenticationFailed(t *testing.T) {
+	failed := dbus.Error{Name: "org.bluez.Error.AuthenticationFailed"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"direct", failed, true},
+		{"wrapped", fmt.Errorf("pair: %w", failed), true},
+		{"other BlueZ error", dbus.Error{Name: "org.bluez.Error.AuthenticationCanceled"}, false},
+		{"plain error", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAuthenticationFailed(tt.err); got != tt.want {
+				t.Errorf("IsAuthenticationFailed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package bluetooth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/i18n"
+)
+
+// Discovery transports accepted by Adapter1.SetDiscoveryFilter.
+const (
+	TransportAuto  = "auto"
+	TransportBREDR = "bredr"
+	TransportLE    = "le"
+)
+
+// DiscoveryFilter narrows down the devices reported by the controller while
+// scanning. DefaultDiscoveryFilter is an unfiltered dual-mode scan.
+type DiscoveryFilter struct {
+	Transport     string   // auto, bredr or le
+	RSSI          int      // Minimum RSSI in dBm (0 = no threshold)
+	Pathloss      int      // Maximum pathloss in dB (0 = no threshold)
+	UUIDs         []string // Only report devices advertising one of these services
+	Pattern       string   // Only report devices whose name or address starts with this
+	DuplicateData bool     // Report every advertisement instead of only changes
+}
+
+// DefaultDiscoveryFilter returns the filter BlueZ uses when none is set.
+func DefaultDiscoveryFilter() DiscoveryFilter {
+	return DiscoveryFilter{Transport: TransportAuto, DuplicateData: true}
+}
+
+// IsDefault reports whether the filter leaves discovery unfiltered.
+func (f DiscoveryFilter) IsDefault() bool {
+	return !f.IsActive() && f.DuplicateData
+}
+
+// IsActive reports whether the filter hides any device from discovery.
+func (f DiscoveryFilter) IsActive() bool {
+	return (f.Transport != "" && f.Transport != TransportAuto) ||
+		f.RSSI != 0 || f.Pathloss != 0 || len(f.UUIDs) > 0 || f.Pattern != ""
+}
+
+// Validate checks the filter against the constraints documented by BlueZ.
+func (f DiscoveryFilter) Validate() error {
+	switch f.Transport {
+	case "", TransportAuto, TransportBREDR, TransportLE:
+	default:
+		return fmt.Errorf(i18n.T.ErrorFilterTransport, f.Transport)
+	}
+	if f.RSSI != 0 && f.Pathloss != 0 {
+		return errors.New(i18n.T.ErrorFilterRSSIPathloss)
+	}
+	if f.RSSI < -127 || f.RSSI > 20 {
+		return fmt.Errorf(i18n.T.ErrorFilterRSSI, f.RSSI)
+	}
+	if f.Pathloss < 0 || f.Pathloss > 255 {
+		return fmt.Errorf(i18n.T.ErrorFilterPathloss, f.Pathloss)
+	}
+	return nil
+}
+
+// properties builds the dictionary passed to SetDiscoveryFilter.
+// Keys left at their BlueZ default are omitted, so the default filter
+// yields an empty dictionary, which clears any previous filter.
+func (f DiscoveryFilter) properties() map[string]dbus.Variant {
+	props := make(map[string]dbus.Variant)
+
+	if f.Transport != "" && f.Transport != TransportAuto {
+		props["Transport"] = dbus.MakeVariant(f.Transport)
+	}
+	if f.RSSI != 0 {
+		props["RSSI"] = dbus.MakeVariant(int16(f.RSSI))
+	}
+	if f.Pathloss != 0 {
+		props["Pathloss"] = dbus.MakeVariant(uint16(f.Pathloss))
+	}
+	if len(f.UUIDs) > 0 {
+		uuids := make([]string, 0, len(f.UUIDs))
+		for _, uuid := range f.UUIDs {
+			if uuid = strings.ToLower(strings.TrimSpace(uuid)); uuid != "" {
+				uuids = append(uuids, uuid)
+			}
+		}
+		props["UUIDs"] = dbus.MakeVariant(uuids)
+	}
+	if f.Pattern != "" {
+		props["Pattern"] = dbus.MakeVariant(f.Pattern)
+	}
+	if !f.DuplicateData {
+		props["DuplicateData"] = dbus.MakeVariant(false)
+	}
+
+	return props
+}
+
+// DiscoveryFilter returns the filter applied to discovery sessions.
+func (m *Manager) DiscoveryFilter() DiscoveryFilter {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.filter
+}
+
+// UseDiscoveryFilter validates and stores the filter for the next
+// StartDiscovery without calling BlueZ, which rejects filters while the
+// adapter is powered off.
+func (m *Manager) UseDiscoveryFilter(filter DiscoveryFilter) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.filter = filter
+	m.mu.Unlock()
+	return nil
+}
+
+// SetDiscoveryFilter validates and stores the filter, and applies it to the
+// active adapter right away. BlueZ restarts a running scan with the new
+// filter. The filter is applied again on every StartDiscovery, since BlueZ
+// drops it when discovery stops or the adapter changes.
+func (m *Manager) SetDiscoveryFilter(filter DiscoveryFilter) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	obj, err := m.adapterObject()
+	switch {
+	case err == nil:
+		if err := m.setDiscoveryFilter(obj, filter); err != nil {
+			return err
+		}
+	case !errors.Is(err, ErrNoAdapter):
+		return err
+	}
+
+	m.mu.Lock()
+	m.filter = filter
+	m.mu.Unlock()
+	return nil
+}
+
+// setDiscoveryFilter calls Adapter1.SetDiscoveryFilter on obj.
+func (m *Manager) setDiscoveryFilter(obj dbus.BusObject, filter DiscoveryFilter) error {
+	err := call(context.Background(), m.Timeouts().Other, obj, bluezAdapterIface+".SetDiscoveryFilter", filter.properties())
+	if err != nil {
+		return fmt.Errorf(i18n.T.ErrorSetDiscoveryFilter+": %w", err)
+	}
+	return nil
+}
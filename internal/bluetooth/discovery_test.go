@@ -0,0 +1,139 @@
+package bluetooth
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestDiscoveryFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  DiscoveryFilter
+		wantErr bool
+	}{
+		{"default", DefaultDiscoveryFilter(), false},
+		{"zero value", DiscoveryFilter{}, false},
+		{"le with rssi", DiscoveryFilter{Transport: TransportLE, RSSI: -70}, false},
+		{"bredr with pathloss", DiscoveryFilter{Transport: TransportBREDR, Pathloss: 60}, false},
+		{"unknown transport", DiscoveryFilter{Transport: "usb"}, true},
+		{"rssi too low", DiscoveryFilter{RSSI: -128}, true},
+		{"rssi too high", DiscoveryFilter{RSSI: 21}, true},
+		{"negative pathloss", DiscoveryFilter{Pathloss: -1}, true},
+		{"rssi and pathloss", DiscoveryFilter{RSSI: -70, Pathloss: 60}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiscoveryFilter_Properties(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter DiscoveryFilter
+		want   map[string]dbus.Variant
+	}{
+		{
+			name:   "default clears the filter",
+			filter: DefaultDiscoveryFilter(),
+			want:   map[string]dbus.Variant{},
+		},
+		{
+			name: "every option",
+			filter: DiscoveryFilter{
+				Transport: TransportLE,
+				RSSI:      -70,
+				UUIDs:     []string{" 180D ", "", "0000110b-0000-1000-8000-00805f9b34fb"},
+				Pattern:   "Polar",
+			},
+			want: map[string]dbus.Variant{
+				"Transport":     dbus.MakeVariant("le"),
+				"RSSI":          dbus.MakeVariant(int16(-70)),
+				"UUIDs":         dbus.MakeVariant([]string{"180d", "0000110b-0000-1000-8000-00805f9b34fb"}),
+				"Pattern":       dbus.MakeVariant("Polar"),
+				"DuplicateData": dbus.MakeVariant(false),
+			},
+		},
+		{
+			name:   "pathloss",
+			filter: DiscoveryFilter{Transport: TransportAuto, Pathloss: 60, DuplicateData: true},
+			want:   map[string]dbus.Variant{"Pathloss": dbus.MakeVariant(uint16(60))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.filter.properties()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("properties() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoveryFilter_IsDefault(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     DiscoveryFilter
+		wantDef    bool
+		wantActive bool
+	}{
+		{"default", DefaultDiscoveryFilter(), true, false},
+		{"empty transport", DiscoveryFilter{DuplicateData: true}, true, false},
+		{"no duplicates", DiscoveryFilter{Transport: TransportAuto}, false, false},
+		{"le only", DiscoveryFilter{Transport: TransportLE, DuplicateData: true}, false, true},
+		{"pattern", DiscoveryFilter{Pattern: "AA:BB", DuplicateData: true}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.IsDefault(); got != tt.wantDef {
+				t.Errorf("IsDefault() = %v, want %v", got, tt.wantDef)
+			}
+			if got := tt.filter.IsActive(); got != tt.wantActive {
+				t.Errorf("IsActive() = %v, want %v", got, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestManager_SetDiscoveryFilterWithoutAdapter(t *testing.T) {
+	m := &Manager{filter: DefaultDiscoveryFilter()}
+
+	filter := DiscoveryFilter{Transport: TransportLE, RSSI: -60, DuplicateData: true}
+	if err := m.SetDiscoveryFilter(filter); err != nil {
+		t.Fatalf("SetDiscoveryFilter() error = %v", err)
+	}
+	if got := m.DiscoveryFilter(); !reflect.DeepEqual(got, filter) {
+		t.Errorf("DiscoveryFilter() = %+v, want %+v", got, filter)
+	}
+
+	if err := m.SetDiscoveryFilter(DiscoveryFilter{Transport: "usb"}); err == nil {
+		t.Errorf("SetDiscoveryFilter() should reject an invalid filter")
+	}
+	if got := m.DiscoveryFilter(); !reflect.DeepEqual(got, filter) {
+		t.Errorf("invalid filter should not replace the stored one, got %+v", got)
+	}
+}
+
+func TestManager_UseDiscoveryFilter(t *testing.T) {
+	m := &Manager{filter: DefaultDiscoveryFilter()}
+
+	filter := DiscoveryFilter{Transport: TransportBREDR, Pattern: "JBL", DuplicateData: true}
+	if err := m.UseDiscoveryFilter(filter); err != nil {
+		t.Fatalf("UseDiscoveryFilter() error = %v", err)
+	}
+	if got := m.DiscoveryFilter(); !reflect.DeepEqual(got, filter) {
+		t.Errorf("DiscoveryFilter() = %+v, want %+v", got, filter)
+	}
+	if err := m.UseDiscoveryFilter(DiscoveryFilter{RSSI: 50}); err == nil {
+		t.Errorf("UseDiscoveryFilter() should reject an invalid filter")
+	}
+}
@@ -0,0 +1,158 @@
+package bluetooth
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/i18n"
+)
+
+// Failures BlueZ reports, recognized from the D-Bus error name or, for the
+// generic org.bluez.Error.Failed, the reason attached to it. Errors
+// returned by the manager match them with errors.Is.
+var (
+	ErrAuthenticationFailed = errors.New("authentication failed")
+	ErrAlreadyConnected     = errors.New("already connected")
+	ErrAlreadyPaired        = errors.New("already paired")
+	ErrInProgress           = errors.New("operation already in progress")
+	ErrNotReady             = errors.New("adapter not ready")
+	ErrPageTimeout          = errors.New("device did not answer")
+	ErrProfileUnavailable   = errors.New("no usable profile")
+	ErrKeyMissing           = errors.New("pairing key missing")
+	ErrNotAuthorized        = errors.New("not authorized")
+	ErrDeviceGone           = errors.New("device no longer exists")
+)
+
+// Error is a failure reported by BlueZ, classified into one of the Err
+// sentinels when it is a known one.
+type Error struct {
+	Name   string // D-Bus error name, e.g. org.bluez.Error.Failed
+	Reason string // Text BlueZ attached, e.g. br-connection-page-timeout
+	kind   error  // Sentinel the error was recognized as; nil when unknown
+	err    dbus.Error
+}
+
+// errorNames maps D-Bus error names to the sentinels they stand for.
+var errorNames = map[string]error{
+	"org.bluez.Error.AuthenticationFailed":           ErrAuthenticationFailed,
+	"org.bluez.Error.AlreadyConnected":               ErrAlreadyConnected,
+	"org.bluez.Error.AlreadyExists":                  ErrAlreadyPaired,
+	"org.bluez.Error.InProgress":                     ErrInProgress,
+	"org.bluez.Error.NotReady":                       ErrNotReady,
+	"org.bluez.Error.NotAvailable":                   ErrProfileUnavailable,
+	"org.bluez.Error.NotAuthorized":                  ErrNotAuthorized,
+	"org.bluez.Error.DoesNotExist":                   ErrDeviceGone,
+	"org.freedesktop.DBus.Error.AccessDenied":        ErrNotAuthorized,
+	"org.freedesktop.DBus.Error.UnknownObject":       ErrDeviceGone,
+	"org.freedesktop.PolicyKit1.Error.NotAuthorized": ErrNotAuthorized,
+}
+
+// errorReasons maps the reasons BlueZ attaches to org.bluez.Error.Failed
+// and ConnectionAttemptFailed to sentinels. Recent releases send
+// "br-connection-..." and "le-connection-..." codes, older ones the
+// kernel's error strings; both are matched as substrings.
+var errorReasons = []struct {
+	reason string
+	kind   error
+}{
+	{"page-timeout", ErrPageTimeout},
+	{"Page Timeout", ErrPageTimeout},
+	{"Host is down", ErrPageTimeout},
+	{"profile-unavailable", ErrProfileUnavailable},
+	{"Protocol not available", ErrProfileUnavailable},
+	{"already-connected", ErrAlreadyConnected},
+	{"connection-busy", ErrInProgress},
+	{"adapter-not-powered", ErrNotReady},
+	{"key-missing", ErrKeyMissing},
+}
+
+// Classify returns the BlueZ error in err's chain as an *Error, or err
+// unchanged when it holds none.
+func Classify(err error) error {
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified
+	}
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) {
+		return err
+	}
+
+	e := &Error{Name: dbusErr.Name, err: dbusErr}
+	if len(dbusErr.Body) > 0 {
+		e.Reason, _ = dbusErr.Body[0].(string)
+	}
+	e.kind = errorNames[e.Name]
+	for _, r := range errorReasons {
+		if e.kind == nil && strings.Contains(e.Reason, r.reason) {
+			e.kind = r.kind
+		}
+	}
+	return e
+}
+
+// Error explains the failure in the current language when it is a known
+// one, keeping what BlueZ said for reference.
+func (e *Error) Error() string {
+	detail := e.Reason
+	if detail == "" {
+		detail = e.Name
+	}
+	if text, ok := errorTexts()[e.kind]; ok {
+		return text.explanation + " (" + detail + ")"
+	}
+	if e.Reason == "" {
+		return e.Name
+	}
+	return e.Name + ": " + e.Reason
+}
+
+// Is reports whether e was recognized as target.
+func (e *Error) Is(target error) bool {
+	return e.kind != nil && e.kind == target
+}
+
+// Unwrap returns the D-Bus error e was built from.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Hint suggests how to fix the BlueZ failure in err's chain, in the
+// current language, or returns "" when there is no advice for it.
+func Hint(err error) string {
+	for kind, text := range errorTexts() {
+		if errors.Is(err, kind) {
+			return text.hint
+		}
+	}
+	return ""
+}
+
+// IsAuthenticationFailed reports whether err comes from a pairing attempt
+// that failed because the remote device did not accept our credentials.
+func IsAuthenticationFailed(err error) bool {
+	return errors.Is(Classify(err), ErrAuthenticationFailed)
+}
+
+// errorText is the explanation of a sentinel and the fix to suggest.
+type errorText struct {
+	explanation string
+	hint        string
+}
+
+// errorTexts returns the texts of the sentinels in the current language.
+func errorTexts() map[error]errorText {
+	return map[error]errorText{
+		ErrAuthenticationFailed: {i18n.T.BluezAuthenticationFailed, i18n.T.HintAuthenticationFailed},
+		ErrAlreadyConnected:     {i18n.T.BluezAlreadyConnected, i18n.T.HintAlreadyConnected},
+		ErrAlreadyPaired:        {i18n.T.BluezAlreadyPaired, i18n.T.HintAlreadyPaired},
+		ErrInProgress:           {i18n.T.BluezInProgress, i18n.T.HintInProgress},
+		ErrNotReady:             {i18n.T.BluezNotReady, i18n.T.HintNotReady},
+		ErrPageTimeout:          {i18n.T.BluezPageTimeout, i18n.T.HintPageTimeout},
+		ErrProfileUnavailable:   {i18n.T.BluezProfileUnavailable, i18n.T.HintProfileUnavailable},
+		ErrKeyMissing:           {i18n.T.BluezKeyMissing, i18n.T.HintKeyMissing},
+		ErrNotAuthorized:        {i18n.T.BluezNotAuthorized, i18n.T.HintNotAuthorized},
+		ErrDeviceGone:           {i18n.T.BluezDeviceGone, i18n.T.HintDeviceGone},
+	}
+}
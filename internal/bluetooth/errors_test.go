@@ -0,0 +1,79 @@
+package bluetooth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/i18n"
+)
+
+func TestClassify(t *testing.T) {
+	failed := func(reason string) dbus.Error {
+		return dbus.Error{Name: "org.bluez.Error.Failed", Body: []interface{}{reason}}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"authentication failed", dbus.Error{Name: "org.bluez.Error.AuthenticationFailed"}, ErrAuthenticationFailed},
+		{"already connected", dbus.Error{Name: "org.bluez.Error.AlreadyConnected"}, ErrAlreadyConnected},
+		{"in progress", dbus.Error{Name: "org.bluez.Error.InProgress", Body: []interface{}{"In Progress"}}, ErrInProgress},
+		{"not ready", dbus.Error{Name: "org.bluez.Error.NotReady"}, ErrNotReady},
+		{"polkit", dbus.Error{Name: "org.freedesktop.DBus.Error.AccessDenied"}, ErrNotAuthorized},
+		{"page timeout", failed("br-connection-page-timeout"), ErrPageTimeout},
+		{"LE page timeout", failed("le-connection-page-timeout"), ErrPageTimeout},
+		{"old page timeout", dbus.Error{Name: "org.bluez.Error.ConnectionAttemptFailed", Body: []interface{}{"Page Timeout"}}, ErrPageTimeout},
+		{"profile unavailable", failed("br-connection-profile-unavailable"), ErrProfileUnavailable},
+		{"key missing", failed("br-connection-key-missing"), ErrKeyMissing},
+		{"wrapped", fmt.Errorf("connect: %w", failed("br-connection-page-timeout")), ErrPageTimeout},
+		{"unknown reason", failed("br-connection-unknown"), nil},
+		{"plain error", errors.New("boom"), nil},
+	}
+
+	sentinels := []error{
+		ErrAuthenticationFailed, ErrAlreadyConnected, ErrAlreadyPaired, ErrInProgress, ErrNotReady,
+		ErrPageTimeout, ErrProfileUnavailable, ErrKeyMissing, ErrNotAuthorized, ErrDeviceGone,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Classify(tt.err)
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v", err, sentinel, got)
+				}
+			}
+			if hint := Hint(err); (hint != "") != (tt.want != nil) {
+				t.Errorf("Hint() = %q, want a hint only for known failures", hint)
+			}
+		})
+	}
+}
+
+func TestError_Error(t *testing.T) {
+	i18n.SetLanguage(i18n.English)
+
+	err := fmt.Errorf("%s: %w", i18n.T.ErrorConnectDevice,
+		Classify(dbus.Error{Name: "org.bluez.Error.Failed", Body: []interface{}{"br-connection-profile-unavailable"}}))
+	msg := err.Error()
+	if !strings.Contains(msg, i18n.T.BluezProfileUnavailable) || !strings.Contains(msg, "br-connection-profile-unavailable") {
+		t.Errorf("Error() = %q, want the explanation and the BlueZ reason", msg)
+	}
+
+	// Unknown failures are shown as BlueZ reported them
+	unknown := Classify(dbus.Error{Name: "org.bluez.Error.Failed", Body: []interface{}{"br-connection-unknown"}})
+	if got := unknown.Error(); got != "org.bluez.Error.Failed: br-connection-unknown" {
+		t.Errorf("Error() = %q, want the raw D-Bus error", got)
+	}
+
+	// The D-Bus error stays reachable
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) || dbusErr.Name != "org.bluez.Error.Failed" {
+		t.Errorf("errors.As() should find the D-Bus error")
+	}
+}
@@ -0,0 +1,463 @@
+package bluetooth
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+const (
+	objectManagerIface = "org.freedesktop.DBus.ObjectManager"
+	propertiesIface    = "org.freedesktop.DBus.Properties"
+	dbusService        = "org.freedesktop.DBus"
+)
+
+// EventKind identifies the kind of change reported by BlueZ.
+type EventKind int
+
+const (
+	// DeviceChanged is sent when a device appears or one of its properties changes.
+	DeviceChanged EventKind = iota
+	// DeviceRemoved is sent when BlueZ drops a device object.
+	DeviceRemoved
+	// AdapterChanged is sent when an adapter appears or one of its properties changes.
+	AdapterChanged
+	// AdapterRemoved is sent when an adapter disappears (e.g. a dongle is unplugged).
+	AdapterRemoved
+	// AdapterSelected is sent when the active adapter changes on its own after
+	// a hotplug. Adapter is nil when no adapter is left.
+	AdapterSelected
+	// ServiceLost is sent when bluetoothd leaves the bus (crash, restart).
+	ServiceLost
+	// ServiceRestored is sent once bluetoothd is back and the cache has been
+	// rebuilt. Adapter is the newly active adapter, or nil if there is none.
+	ServiceRestored
+	// DeviceDisconnected is sent when BlueZ says why a device was
+	// disconnected. Only recent BlueZ releases send it, and the Connected
+	// property may change before or after it.
+	DeviceDisconnected
+)
+
+// DisconnectedLocally is the DeviceDisconnected reason for a disconnect
+// asked for on this host, by blugo or any other client.
+const DisconnectedLocally = "org.bluez.Reason.Local"
+
+// Event describes an incremental change in the BlueZ object tree.
+type Event struct {
+	Kind    EventKind
+	Address string          // Device address (DeviceChanged, DeviceRemoved)
+	Device  *models.Device  // Updated device (DeviceChanged)
+	Adapter *models.Adapter // Affected adapter (AdapterChanged, AdapterRemoved, AdapterSelected)
+	Reason  string          // Why the device was disconnected (DeviceDisconnected)
+}
+
+// Watch subscribes to InterfacesAdded, InterfacesRemoved, PropertiesChanged
+// and Device1.Disconnected from BlueZ and seeds the object cache. It also watches the owner of the
+// org.bluez name so a bluetoothd restart is detected. After Watch returns,
+// GetDevices and GetAdapterInfo are served from the cache and deltas are
+// delivered on Events.
+func (m *Manager) Watch() error {
+	matches := [][]dbus.MatchOption{
+		{
+			dbus.WithMatchSender(dbusService),
+			dbus.WithMatchInterface(dbusService),
+			dbus.WithMatchMember("NameOwnerChanged"),
+			dbus.WithMatchArg(0, bluezService),
+		},
+		{
+			dbus.WithMatchSender(bluezService),
+			dbus.WithMatchInterface(objectManagerIface),
+			dbus.WithMatchMember("InterfacesAdded"),
+		},
+		{
+			dbus.WithMatchSender(bluezService),
+			dbus.WithMatchInterface(objectManagerIface),
+			dbus.WithMatchMember("InterfacesRemoved"),
+		},
+		{
+			dbus.WithMatchSender(bluezService),
+			dbus.WithMatchInterface(propertiesIface),
+			dbus.WithMatchMember("PropertiesChanged"),
+			dbus.WithMatchPathNamespace("/org/bluez"),
+		},
+		{
+			dbus.WithMatchSender(bluezService),
+			dbus.WithMatchInterface(bluezDeviceIface),
+			dbus.WithMatchMember("Disconnected"),
+			dbus.WithMatchPathNamespace("/org/bluez"),
+		},
+	}
+	for _, opts := range matches {
+		if err := m.conn.AddMatchSignal(opts...); err != nil {
+			return fmt.Errorf(i18n.T.ErrorWatchSignals+": %w", err)
+		}
+	}
+
+	// Subscribe before seeding so no change between the two is lost
+	m.signals = make(chan *dbus.Signal, 64)
+	m.conn.Signal(m.signals)
+
+	objects, err := getManagedObjects(m.conn)
+	if err != nil {
+		m.conn.RemoveSignal(m.signals)
+		return fmt.Errorf(i18n.T.ErrorWatchSignals+": %w", err)
+	}
+
+	m.mu.Lock()
+	m.objects = objects
+	m.changes = make(chan dbus.ObjectPath, 16)
+	m.mu.Unlock()
+
+	m.events = make(chan Event, 64)
+	if m.done == nil {
+		m.done = make(chan struct{})
+	}
+	go m.dispatch()
+
+	return nil
+}
+
+// Events returns the channel on which cache deltas are delivered.
+// It is nil until Watch succeeds and is closed when the connection closes.
+func (m *Manager) Events() <-chan Event {
+	return m.events
+}
+
+// IsAvailable reports whether bluetoothd currently owns its bus name.
+func (m *Manager) IsAvailable() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return !m.unavailable
+}
+
+// IsWatching reports whether the manager is driven by BlueZ signals.
+func (m *Manager) IsWatching() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.objects != nil
+}
+
+// dispatch applies incoming signals to the cache and forwards the
+// resulting events, along with the transitions beginning and ending.
+func (m *Manager) dispatch() {
+	defer close(m.events)
+
+	m.mu.RLock()
+	changes := m.changes
+	m.mu.RUnlock()
+
+	for {
+		var events []Event
+		select {
+		case sig, ok := <-m.signals:
+			if !ok {
+				return
+			}
+			events = m.applySignal(sig)
+			if owner, ok := bluezOwnerChange(sig); ok && owner != "" {
+				events = m.reload()
+			}
+		case path := <-changes:
+			events = m.transitionChanged(path)
+		}
+
+		for _, ev := range events {
+			select {
+			case m.events <- ev:
+			case <-m.done:
+				return
+			}
+		}
+	}
+}
+
+// applySignal updates the object cache with a single signal and returns
+// the events it produces. Unknown or malformed signals are ignored.
+func (m *Manager) applySignal(sig *dbus.Signal) []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.objects == nil {
+		return nil
+	}
+
+	switch sig.Name {
+	case dbusService + ".NameOwnerChanged":
+		return m.applyOwnerChanged(sig)
+	case objectManagerIface + ".InterfacesAdded":
+		return m.applyInterfacesAdded(sig)
+	case objectManagerIface + ".InterfacesRemoved":
+		return m.applyInterfacesRemoved(sig)
+	case propertiesIface + ".PropertiesChanged":
+		return m.applyPropertiesChanged(sig)
+	case bluezDeviceIface + ".Disconnected":
+		return m.applyDisconnected(sig)
+	}
+	return nil
+}
+
+// bluezOwnerChange extracts the new owner of org.bluez from a
+// NameOwnerChanged signal. An empty owner means bluetoothd went away.
+func bluezOwnerChange(sig *dbus.Signal) (string, bool) {
+	if sig.Name != dbusService+".NameOwnerChanged" || len(sig.Body) < 3 {
+		return "", false
+	}
+	name, ok := sig.Body[0].(string)
+	if !ok || name != bluezService {
+		return "", false
+	}
+	owner, ok := sig.Body[2].(string)
+	return owner, ok
+}
+
+// applyOwnerChanged empties the cache when bluetoothd leaves the bus.
+// The active adapter is remembered so reload can pick it again.
+func (m *Manager) applyOwnerChanged(sig *dbus.Signal) []Event {
+	owner, ok := bluezOwnerChange(sig)
+	if !ok || owner != "" {
+		return nil
+	}
+
+	m.objects = make(objectMap)
+	if m.adapter != "" {
+		m.lastAdapter = m.adapter
+	}
+	m.adapter = ""
+	m.unavailable = true
+
+	return []Event{{Kind: ServiceLost}}
+}
+
+// reload rebuilds the cache after bluetoothd came back and selects the
+// previously active adapter, the preferred one, or the first available.
+func (m *Manager) reload() []Event {
+	objects, err := getManagedObjects(m.conn)
+	if err != nil {
+		// Objects will still arrive through InterfacesAdded
+		objects = make(objectMap)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.objects = objects
+	m.unavailable = false
+
+	adapters := parseAdapters(objects)
+	adapter := findAdapter(adapters, string(m.lastAdapter))
+	if adapter == nil && m.preferred != "" {
+		adapter = findAdapter(adapters, m.preferred)
+	}
+	if adapter == nil {
+		adapter = findAdapter(adapters, "")
+	}
+
+	m.adapter = ""
+	if adapter != nil {
+		m.adapter = adapter.Path
+	}
+
+	return []Event{{Kind: ServiceRestored, Adapter: adapter}}
+}
+
+// applyInterfacesAdded handles ObjectManager.InterfacesAdded.
+func (m *Manager) applyInterfacesAdded(sig *dbus.Signal) []Event {
+	if len(sig.Body) < 2 {
+		return nil
+	}
+	path, ok := sig.Body[0].(dbus.ObjectPath)
+	if !ok {
+		return nil
+	}
+	added, ok := sig.Body[1].(map[string]map[string]dbus.Variant)
+	if !ok {
+		return nil
+	}
+
+	interfaces, exists := m.objects[path]
+	if !exists {
+		interfaces = make(map[string]map[string]dbus.Variant)
+		m.objects[path] = interfaces
+	}
+	for iface, props := range added {
+		interfaces[iface] = props
+	}
+
+	events := m.eventsFor(path)
+
+	// Adopt a new adapter when we have none, or when the preferred one returns
+	if props, ok := added[bluezAdapterIface]; ok && path != m.adapter {
+		adapter, _ := parseAdapter(path, props)
+		if m.adapter == "" || (m.preferred != "" && findAdapter([]*models.Adapter{adapter}, m.preferred) != nil) {
+			m.adapter = path
+			events = append(events, Event{Kind: AdapterSelected, Adapter: adapter})
+		}
+	}
+
+	return events
+}
+
+// applyInterfacesRemoved handles ObjectManager.InterfacesRemoved.
+func (m *Manager) applyInterfacesRemoved(sig *dbus.Signal) []Event {
+	if len(sig.Body) < 2 {
+		return nil
+	}
+	path, ok := sig.Body[0].(dbus.ObjectPath)
+	if !ok {
+		return nil
+	}
+	removed, ok := sig.Body[1].([]string)
+	if !ok {
+		return nil
+	}
+
+	interfaces, exists := m.objects[path]
+	if !exists {
+		return nil
+	}
+
+	var events []Event
+	for _, iface := range removed {
+		switch iface {
+		case bluezDeviceIface:
+			if props, ok := interfaces[bluezDeviceIface]; ok && belongsToAdapter(path, m.adapter) {
+				dev, _ := parseDevice(path, interfaces, props)
+				events = append(events, Event{Kind: DeviceRemoved, Address: dev.Address})
+			}
+		case bluezAdapterIface:
+			if props, ok := interfaces[bluezAdapterIface]; ok {
+				events = append(events, m.removeAdapter(path, props)...)
+			}
+		}
+		delete(interfaces, iface)
+	}
+
+	if len(interfaces) == 0 {
+		delete(m.objects, path)
+	}
+
+	// A device that lost a secondary interface (e.g. Battery1) still changed
+	if len(events) == 0 {
+		events = m.eventsFor(path)
+	}
+	return events
+}
+
+// applyPropertiesChanged handles Properties.PropertiesChanged.
+func (m *Manager) applyPropertiesChanged(sig *dbus.Signal) []Event {
+	if len(sig.Body) < 2 {
+		return nil
+	}
+	iface, ok := sig.Body[0].(string)
+	if !ok {
+		return nil
+	}
+	changed, ok := sig.Body[1].(map[string]dbus.Variant)
+	if !ok {
+		return nil
+	}
+
+	interfaces, exists := m.objects[sig.Path]
+	if !exists {
+		return nil
+	}
+	props, exists := interfaces[iface]
+	if !exists {
+		props = make(map[string]dbus.Variant)
+		interfaces[iface] = props
+	}
+	for name, value := range changed {
+		props[name] = value
+	}
+	if len(sig.Body) >= 3 {
+		if invalidated, ok := sig.Body[2].([]string); ok {
+			for _, name := range invalidated {
+				delete(props, name)
+			}
+		}
+	}
+
+	return m.eventsFor(sig.Path)
+}
+
+// applyDisconnected handles Device1.Disconnected. The cache is left alone;
+// the Connected property arrives through PropertiesChanged.
+func (m *Manager) applyDisconnected(sig *dbus.Signal) []Event {
+	if len(sig.Body) < 1 {
+		return nil
+	}
+	reason, ok := sig.Body[0].(string)
+	if !ok {
+		return nil
+	}
+	interfaces := m.objects[sig.Path]
+	props, ok := interfaces[bluezDeviceIface]
+	if !ok || !belongsToAdapter(sig.Path, m.adapter) {
+		return nil
+	}
+	dev, _ := parseDevice(sig.Path, interfaces, props)
+	return []Event{{Kind: DeviceDisconnected, Address: dev.Address, Reason: reason}}
+}
+
+// removeAdapter drops an adapter and its devices from the cache and falls
+// back to another adapter when it was the active one.
+// The caller must hold m.mu.
+func (m *Manager) removeAdapter(path dbus.ObjectPath, props map[string]dbus.Variant) []Event {
+	adapter, _ := parseAdapter(path, props)
+	events := []Event{{Kind: AdapterRemoved, Adapter: adapter}}
+
+	// BlueZ normally removes devices first; make sure none are left behind
+	for objPath := range m.objects {
+		if belongsToAdapter(objPath, path) {
+			delete(m.objects, objPath)
+		}
+	}
+
+	if path != m.adapter {
+		return events
+	}
+
+	var next *models.Adapter
+	for _, adapter := range parseAdapters(m.objects) {
+		if adapter.Path != path {
+			next = adapter
+			break
+		}
+	}
+
+	m.adapter = ""
+	if next != nil {
+		m.adapter = next.Path
+	}
+	return append(events, Event{Kind: AdapterSelected, Adapter: next})
+}
+
+// eventsFor builds the event describing the current cached state of path.
+// Devices of adapters other than the active one are skipped. Events carry
+// partially decoded objects; decode errors are reported by GetDevices and
+// GetAdapterInfo.
+// The caller must hold m.mu.
+func (m *Manager) eventsFor(path dbus.ObjectPath) []Event {
+	interfaces, ok := m.objects[path]
+	if !ok {
+		return nil
+	}
+
+	if props, ok := interfaces[bluezDeviceIface]; ok {
+		if !belongsToAdapter(path, m.adapter) {
+			return nil
+		}
+		dev, _ := parseDevice(path, interfaces, props)
+		dev.Transition = m.transitions[path]
+		return []Event{{Kind: DeviceChanged, Address: dev.Address, Device: dev}}
+	}
+
+	if props, ok := interfaces[bluezAdapterIface]; ok {
+		adapter, _ := parseAdapter(path, props)
+		return []Event{{Kind: AdapterChanged, Adapter: adapter}}
+	}
+
+	return nil
+}
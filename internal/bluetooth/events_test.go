@@ -0,0 +1,477 @@
+package bluetooth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// newWatchingManager returns a Manager with a seeded cache and no connection
+func newWatchingManager() *Manager {
+	return &Manager{
+		adapter: "/org/bluez/hci0",
+		objects: objectMap{
+			"/org/bluez/hci0": {
+				bluezAdapterIface: {
+					"Address": dbus.MakeVariant("00:11:22:33:44:55"),
+					"Powered": dbus.MakeVariant(true),
+				},
+			},
+			"/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF": {
+				bluezDeviceIface: {
+					"Address":   dbus.MakeVariant("AA:BB:CC:DD:EE:FF"),
+					"Name":      dbus.MakeVariant("Headphones"),
+					"Connected": dbus.MakeVariant(false),
+				},
+			},
+		},
+	}
+}
+
+func TestApplySignal_InterfacesAdded(t *testing.T) {
+	m := newWatchingManager()
+
+	events := m.applySignal(&dbus.Signal{
+		Path: "/",
+		Name: objectManagerIface + ".InterfacesAdded",
+		Body: []interface{}{
+			dbus.ObjectPath("/org/bluez/hci0/dev_11_22_33_44_55_66"),
+			map[string]map[string]dbus.Variant{
+				bluezDeviceIface: {
+					"Address": dbus.MakeVariant("11:22:33:44:55:66"),
+					"Name":    dbus.MakeVariant("Mouse"),
+				},
+			},
+		},
+	})
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Kind != DeviceChanged {
+		t.Errorf("Kind = %v, want DeviceChanged", events[0].Kind)
+	}
+	if events[0].Address != "11:22:33:44:55:66" {
+		t.Errorf("Address = %v, want 11:22:33:44:55:66", events[0].Address)
+	}
+	if events[0].Device == nil || events[0].Device.Name != "Mouse" {
+		t.Errorf("Device should be parsed from the added properties")
+	}
+
+	devices, err := m.GetDevices()
+	if err != nil {
+		t.Fatalf("GetDevices() returned error: %v", err)
+	}
+	if len(devices) != 2 {
+		t.Errorf("cache should contain 2 devices, got %d", len(devices))
+	}
+}
+
+func TestApplySignal_InterfacesRemoved(t *testing.T) {
+	m := newWatchingManager()
+
+	events := m.applySignal(&dbus.Signal{
+		Path: "/",
+		Name: objectManagerIface + ".InterfacesRemoved",
+		Body: []interface{}{
+			dbus.ObjectPath("/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF"),
+			[]string{bluezDeviceIface},
+		},
+	})
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Kind != DeviceRemoved {
+		t.Errorf("Kind = %v, want DeviceRemoved", events[0].Kind)
+	}
+	if events[0].Address != "AA:BB:CC:DD:EE:FF" {
+		t.Errorf("Address = %v, want AA:BB:CC:DD:EE:FF", events[0].Address)
+	}
+	if _, ok := m.objects["/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF"]; ok {
+		t.Errorf("object without interfaces should be dropped from the cache")
+	}
+}
+
+func TestApplySignal_PropertiesChanged(t *testing.T) {
+	t.Run("updates device properties", func(t *testing.T) {
+		m := newWatchingManager()
+
+		events := m.applySignal(&dbus.Signal{
+			Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF",
+			Name: propertiesIface + ".PropertiesChanged",
+			Body: []interface{}{
+				bluezDeviceIface,
+				map[string]dbus.Variant{
+					"Connected": dbus.MakeVariant(true),
+					"RSSI":      dbus.MakeVariant(int16(-42)),
+				},
+				[]string{},
+			},
+		})
+
+		if len(events) != 1 {
+			t.Fatalf("expected 1 event, got %d", len(events))
+		}
+		dev := events[0].Device
+		if dev == nil {
+			t.Fatal("Device should not be nil")
+		}
+		if !dev.Connected {
+			t.Errorf("Connected = false, want true")
+		}
+		if dev.RSSI != -42 {
+			t.Errorf("RSSI = %v, want -42", dev.RSSI)
+		}
+		if dev.Name != "Headphones" {
+			t.Errorf("unchanged properties should be kept, Name = %v", dev.Name)
+		}
+	})
+
+	t.Run("drops invalidated properties", func(t *testing.T) {
+		m := newWatchingManager()
+
+		events := m.applySignal(&dbus.Signal{
+			Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF",
+			Name: propertiesIface + ".PropertiesChanged",
+			Body: []interface{}{
+				bluezDeviceIface,
+				map[string]dbus.Variant{},
+				[]string{"Name"},
+			},
+		})
+
+		if len(events) != 1 || events[0].Device.Name != "" {
+			t.Errorf("invalidated Name should be removed from the cache")
+		}
+	})
+
+	t.Run("battery change produces a device event", func(t *testing.T) {
+		m := newWatchingManager()
+		m.objects["/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF"][bluezBatteryIface] = map[string]dbus.Variant{
+			"Percentage": dbus.MakeVariant(byte(80)),
+		}
+
+		events := m.applySignal(&dbus.Signal{
+			Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF",
+			Name: propertiesIface + ".PropertiesChanged",
+			Body: []interface{}{
+				bluezBatteryIface,
+				map[string]dbus.Variant{"Percentage": dbus.MakeVariant(byte(55))},
+				[]string{},
+			},
+		})
+
+		if len(events) != 1 {
+			t.Fatalf("expected 1 event, got %d", len(events))
+		}
+		if events[0].Device.Battery == nil || *events[0].Device.Battery != 55 {
+			t.Errorf("Battery should be updated to 55")
+		}
+	})
+
+	t.Run("adapter change produces an adapter event", func(t *testing.T) {
+		m := newWatchingManager()
+
+		events := m.applySignal(&dbus.Signal{
+			Path: "/org/bluez/hci0",
+			Name: propertiesIface + ".PropertiesChanged",
+			Body: []interface{}{
+				bluezAdapterIface,
+				map[string]dbus.Variant{"Discovering": dbus.MakeVariant(true)},
+				[]string{},
+			},
+		})
+
+		if len(events) != 1 {
+			t.Fatalf("expected 1 event, got %d", len(events))
+		}
+		if events[0].Kind != AdapterChanged {
+			t.Errorf("Kind = %v, want AdapterChanged", events[0].Kind)
+		}
+		if !events[0].Adapter.Discovering || !events[0].Adapter.Powered {
+			t.Errorf("Adapter should reflect cached and changed properties")
+		}
+	})
+
+	t.Run("ignores unknown objects", func(t *testing.T) {
+		m := newWatchingManager()
+
+		events := m.applySignal(&dbus.Signal{
+			Path: "/org/bluez/hci0/dev_00_00_00_00_00_00",
+			Name: propertiesIface + ".PropertiesChanged",
+			Body: []interface{}{
+				bluezDeviceIface,
+				map[string]dbus.Variant{"RSSI": dbus.MakeVariant(int16(-70))},
+				[]string{},
+			},
+		})
+
+		if len(events) != 0 {
+			t.Errorf("expected no events for unknown object, got %d", len(events))
+		}
+	})
+}
+
+func TestApplySignal_Disconnected(t *testing.T) {
+	m := newWatchingManager()
+
+	events := m.applySignal(&dbus.Signal{
+		Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF",
+		Name: bluezDeviceIface + ".Disconnected",
+		Body: []interface{}{DisconnectedLocally, "Connection terminated by local host"},
+	})
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Kind != DeviceDisconnected || events[0].Address != "AA:BB:CC:DD:EE:FF" || events[0].Reason != DisconnectedLocally {
+		t.Errorf("event = %+v, want DeviceDisconnected for AA:BB:CC:DD:EE:FF", events[0])
+	}
+
+	events = m.applySignal(&dbus.Signal{
+		Path: "/org/bluez/hci0/dev_00_00_00_00_00_00",
+		Name: bluezDeviceIface + ".Disconnected",
+		Body: []interface{}{DisconnectedLocally, ""},
+	})
+	if len(events) != 0 {
+		t.Errorf("expected no events for an unknown device, got %d", len(events))
+	}
+}
+
+func TestApplySignal_IgnoredWhenNotWatching(t *testing.T) {
+	m := &Manager{adapter: "/org/bluez/hci0"}
+
+	events := m.applySignal(&dbus.Signal{
+		Path: "/org/bluez/hci0",
+		Name: propertiesIface + ".PropertiesChanged",
+		Body: []interface{}{bluezAdapterIface, map[string]dbus.Variant{}, []string{}},
+	})
+
+	if events != nil {
+		t.Errorf("expected nil events without a cache, got %v", events)
+	}
+	if m.IsWatching() {
+		t.Errorf("IsWatching() should be false without a cache")
+	}
+}
+
+func TestManager_GetAdapterInfo_FromCache(t *testing.T) {
+	m := newWatchingManager()
+
+	adapter, err := m.GetAdapterInfo()
+	if err != nil {
+		t.Fatalf("GetAdapterInfo() returned error: %v", err)
+	}
+	if adapter.Address != "00:11:22:33:44:55" {
+		t.Errorf("Address = %v, want 00:11:22:33:44:55", adapter.Address)
+	}
+	if !adapter.Powered {
+		t.Errorf("Powered = false, want true")
+	}
+}
+
+func TestApplySignal_AdapterHotplug(t *testing.T) {
+	adapterRemoved := func(path dbus.ObjectPath) *dbus.Signal {
+		return &dbus.Signal{
+			Path: "/",
+			Name: objectManagerIface + ".InterfacesRemoved",
+			Body: []interface{}{path, []string{bluezAdapterIface}},
+		}
+	}
+	adapterAdded := func(path dbus.ObjectPath) *dbus.Signal {
+		return &dbus.Signal{
+			Path: "/",
+			Name: objectManagerIface + ".InterfacesAdded",
+			Body: []interface{}{
+				path,
+				map[string]map[string]dbus.Variant{
+					bluezAdapterIface: {"Powered": dbus.MakeVariant(true)},
+				},
+			},
+		}
+	}
+
+	t.Run("falls back to another adapter", func(t *testing.T) {
+		m := newWatchingManager()
+		m.objects["/org/bluez/hci1"] = map[string]map[string]dbus.Variant{
+			bluezAdapterIface: {"Address": dbus.MakeVariant("11:11:11:11:11:11")},
+		}
+
+		events := m.applySignal(adapterRemoved("/org/bluez/hci0"))
+
+		if len(events) != 2 {
+			t.Fatalf("expected 2 events, got %d", len(events))
+		}
+		if events[0].Kind != AdapterRemoved || events[0].Adapter.Path != "/org/bluez/hci0" {
+			t.Errorf("first event should be AdapterRemoved for hci0, got %+v", events[0])
+		}
+		if events[1].Kind != AdapterSelected || events[1].Adapter == nil || events[1].Adapter.Path != "/org/bluez/hci1" {
+			t.Errorf("second event should select hci1, got %+v", events[1])
+		}
+		if m.GetAdapter() != "/org/bluez/hci1" {
+			t.Errorf("active adapter = %v, want /org/bluez/hci1", m.GetAdapter())
+		}
+		if _, ok := m.objects["/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF"]; ok {
+			t.Errorf("devices of the removed adapter should be dropped from the cache")
+		}
+	})
+
+	t.Run("enters no adapter state when the last one is removed", func(t *testing.T) {
+		m := newWatchingManager()
+
+		events := m.applySignal(adapterRemoved("/org/bluez/hci0"))
+
+		if len(events) != 2 || events[1].Kind != AdapterSelected || events[1].Adapter != nil {
+			t.Fatalf("expected AdapterSelected with nil adapter, got %+v", events)
+		}
+		if m.HasAdapter() {
+			t.Errorf("HasAdapter() should be false after removing the last adapter")
+		}
+		if err := m.StartDiscovery(); !errors.Is(err, ErrNoAdapter) {
+			t.Errorf("StartDiscovery() error = %v, want ErrNoAdapter", err)
+		}
+		if _, err := m.GetAdapterInfo(); !errors.Is(err, ErrNoAdapter) {
+			t.Errorf("GetAdapterInfo() error = %v, want ErrNoAdapter", err)
+		}
+		devices, err := m.GetDevices()
+		if err != nil || len(devices) != 0 {
+			t.Errorf("GetDevices() = %v, %v, want no devices", devices, err)
+		}
+	})
+
+	t.Run("resumes when an adapter comes back", func(t *testing.T) {
+		m := newWatchingManager()
+		m.applySignal(adapterRemoved("/org/bluez/hci0"))
+
+		events := m.applySignal(adapterAdded("/org/bluez/hci0"))
+
+		if len(events) != 2 {
+			t.Fatalf("expected 2 events, got %d", len(events))
+		}
+		if events[0].Kind != AdapterChanged {
+			t.Errorf("first event should be AdapterChanged, got %v", events[0].Kind)
+		}
+		if events[1].Kind != AdapterSelected || events[1].Adapter.Path != "/org/bluez/hci0" {
+			t.Errorf("second event should select hci0, got %+v", events[1])
+		}
+		if m.GetAdapter() != "/org/bluez/hci0" {
+			t.Errorf("active adapter = %v, want /org/bluez/hci0", m.GetAdapter())
+		}
+	})
+
+	t.Run("switches back to the preferred adapter", func(t *testing.T) {
+		m := newWatchingManager()
+		m.preferred = "hci1"
+
+		events := m.applySignal(adapterAdded("/org/bluez/hci1"))
+
+		if len(events) != 2 || events[1].Kind != AdapterSelected {
+			t.Fatalf("expected the preferred adapter to be selected, got %+v", events)
+		}
+		if m.GetAdapter() != "/org/bluez/hci1" {
+			t.Errorf("active adapter = %v, want /org/bluez/hci1", m.GetAdapter())
+		}
+	})
+
+	t.Run("keeps the active adapter when another one is plugged in", func(t *testing.T) {
+		m := newWatchingManager()
+
+		events := m.applySignal(adapterAdded("/org/bluez/hci1"))
+
+		if len(events) != 1 || events[0].Kind != AdapterChanged {
+			t.Fatalf("expected only AdapterChanged, got %+v", events)
+		}
+		if m.GetAdapter() != "/org/bluez/hci0" {
+			t.Errorf("active adapter = %v, want /org/bluez/hci0", m.GetAdapter())
+		}
+	})
+}
+
+func TestApplySignal_NameOwnerChanged(t *testing.T) {
+	ownerChanged := func(name, oldOwner, newOwner string) *dbus.Signal {
+		return &dbus.Signal{
+			Path: "/org/freedesktop/DBus",
+			Name: dbusService + ".NameOwnerChanged",
+			Body: []interface{}{name, oldOwner, newOwner},
+		}
+	}
+
+	t.Run("empties the cache when bluetoothd goes away", func(t *testing.T) {
+		m := newWatchingManager()
+
+		events := m.applySignal(ownerChanged(bluezService, ":1.42", ""))
+
+		if len(events) != 1 || events[0].Kind != ServiceLost {
+			t.Fatalf("expected ServiceLost, got %+v", events)
+		}
+		if m.IsAvailable() {
+			t.Errorf("IsAvailable() should be false after bluetoothd left")
+		}
+		if m.HasAdapter() {
+			t.Errorf("HasAdapter() should be false after bluetoothd left")
+		}
+		if m.lastAdapter != "/org/bluez/hci0" {
+			t.Errorf("lastAdapter = %v, want /org/bluez/hci0", m.lastAdapter)
+		}
+		if !m.IsWatching() {
+			t.Errorf("manager should keep watching while bluetoothd is gone")
+		}
+		devices, _ := m.GetDevices()
+		if len(devices) != 0 {
+			t.Errorf("GetDevices() should be empty, got %d devices", len(devices))
+		}
+	})
+
+	t.Run("ignores other names", func(t *testing.T) {
+		m := newWatchingManager()
+
+		events := m.applySignal(ownerChanged("org.freedesktop.NetworkManager", ":1.7", ""))
+
+		if len(events) != 0 {
+			t.Errorf("expected no events, got %+v", events)
+		}
+		if !m.HasAdapter() {
+			t.Errorf("active adapter should be kept")
+		}
+	})
+}
+
+func TestBluezOwnerChange(t *testing.T) {
+	tests := []struct {
+		name      string
+		sig       *dbus.Signal
+		wantOwner string
+		wantOK    bool
+	}{
+		{
+			name:      "bluetoothd appeared",
+			sig:       &dbus.Signal{Name: dbusService + ".NameOwnerChanged", Body: []interface{}{bluezService, "", ":1.99"}},
+			wantOwner: ":1.99",
+			wantOK:    true,
+		},
+		{
+			name:   "bluetoothd vanished",
+			sig:    &dbus.Signal{Name: dbusService + ".NameOwnerChanged", Body: []interface{}{bluezService, ":1.42", ""}},
+			wantOK: true,
+		},
+		{
+			name: "other signal",
+			sig:  &dbus.Signal{Name: propertiesIface + ".PropertiesChanged", Body: []interface{}{bluezService, "", ":1.99"}},
+		},
+		{
+			name: "malformed body",
+			sig:  &dbus.Signal{Name: dbusService + ".NameOwnerChanged", Body: []interface{}{bluezService}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, ok := bluezOwnerChange(tt.sig)
+			if owner != tt.wantOwner || ok != tt.wantOK {
+				t.Errorf("bluezOwnerChange() = (%q, %v), want (%q, %v)", owner, ok, tt.wantOwner, tt.wantOK)
+			}
+		})
+	}
+}
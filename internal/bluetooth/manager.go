@@ -1,7 +1,11 @@
 package bluetooth
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/godbus/dbus/v5"
 	"github.com/ivangsm/blugo/internal/i18n"
@@ -15,39 +19,91 @@ const (
 	bluezBatteryIface = "org.bluez.Battery1"
 )
 
+// ErrNoAdapter is returned by adapter operations while no adapter is available.
+var ErrNoAdapter error = noAdapterError{}
+
+// noAdapterError renders ErrNoAdapter in the current language.
+type noAdapterError struct{}
+
+func (noAdapterError) Error() string {
+	return i18n.T.ErrorAdapterNotFound
+}
+
+// objectMap mirrors the result of ObjectManager.GetManagedObjects:
+// object path -> interface name -> property name -> value.
+type objectMap map[dbus.ObjectPath]map[string]map[string]dbus.Variant
+
 // Manager manages the connection to BlueZ through DBus.
 type Manager struct {
-	conn    *dbus.Conn
-	adapter dbus.ObjectPath
+	conn      *dbus.Conn
+	adapter   dbus.ObjectPath // Empty when no adapter is available
+	preferred string          // Adapter requested by the user, if any
+
+	mu          sync.RWMutex
+	objects     objectMap         // Incremental cache, nil until Watch succeeds
+	lastAdapter dbus.ObjectPath   // Active adapter before bluetoothd went away
+	unavailable bool              // bluetoothd is not on the bus
+	filter      DiscoveryFilter   // Applied before every StartDiscovery
+	timeouts    Timeouts          // Bounds on BlueZ calls
+	signals     chan *dbus.Signal // Raw signals delivered by godbus
+	events      chan Event        // Deltas pushed to consumers
+	done        chan struct{}     // Closed by Close to stop the dispatcher
+
+	// Pairings, connects and disconnects in flight, also guarded by mu
+	transitions map[dbus.ObjectPath]models.ConnectionState
+	changes     chan dbus.ObjectPath // Devices whose transition began or ended, read by the dispatcher
+
+	ops OperationQueue // Serializes operations per device and adapter
 }
 
 // NewManager creates a new Bluetooth manager instance.
-func NewManager() (*Manager, error) {
+// preferred selects the adapter by name (e.g. "hci1"), object path or
+// address; when empty the first adapter in path order is used.
+// A system without adapters is not an error: the manager starts with no
+// active adapter and picks one up when it is plugged in.
+func NewManager(preferred string) (*Manager, error) {
 	conn, err := dbus.ConnectSystemBus()
 	if err != nil {
 		return nil, fmt.Errorf(i18n.T.ErrorDBusConnection+": %w", err)
 	}
 
-	adapter, err := getAdapter(conn)
+	adapter, err := getAdapter(conn, preferred)
 	if err != nil {
 		conn.Close()
 		return nil, fmt.Errorf(i18n.T.ErrorAdapterNotFound+": %w", err)
 	}
 
 	return &Manager{
-		conn:    conn,
-		adapter: adapter,
+		conn:      conn,
+		adapter:   adapter,
+		preferred: preferred,
+		filter:    DefaultDiscoveryFilter(),
+		timeouts:  DefaultTimeouts(),
+		done:      make(chan struct{}),
 	}, nil
 }
 
 // Close closes the DBus connection.
 func (m *Manager) Close() error {
+	if m.done != nil {
+		select {
+		case <-m.done:
+		default:
+			close(m.done)
+		}
+	}
 	if m.conn != nil {
 		return m.conn.Close()
 	}
 	return nil
 }
 
+// Operations returns the queue that serializes operations on the same
+// device or adapter.
+func (m *Manager) Operations() *OperationQueue {
+	return &m.ops
+}
+
 // GetConnection returns the DBus connection.
 func (m *Manager) GetConnection() *dbus.Conn {
 	return m.conn
@@ -55,80 +111,201 @@ func (m *Manager) GetConnection() *dbus.Conn {
 
 // GetAdapter returns the Bluetooth adapter path.
 func (m *Manager) GetAdapter() dbus.ObjectPath {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.adapter
 }
 
-// GetDevices gets all known Bluetooth devices.
+// GetAdapters lists every Bluetooth adapter known to BlueZ, sorted by path.
+func (m *Manager) GetAdapters() ([]*models.Adapter, error) {
+	objects, release, err := m.managedObjects()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return parseAdapters(objects), nil
+}
+
+// SelectAdapter makes another adapter the active one.
+// The adapter is matched by name (e.g. "hci1"), object path or address.
+func (m *Manager) SelectAdapter(name string) (*models.Adapter, error) {
+	adapters, err := m.GetAdapters()
+	if err != nil {
+		return nil, err
+	}
+
+	adapter := findAdapter(adapters, name)
+	if adapter == nil {
+		return nil, fmt.Errorf(i18n.T.ErrorAdapterUnknown, name)
+	}
+
+	m.mu.Lock()
+	m.adapter = adapter.Path
+	m.mu.Unlock()
+
+	return adapter, nil
+}
+
+// HasAdapter reports whether an adapter is currently active.
+func (m *Manager) HasAdapter() bool {
+	return m.GetAdapter() != ""
+}
+
+// adapterObject returns the DBus object of the active adapter.
+func (m *Manager) adapterObject() (dbus.BusObject, error) {
+	path := m.GetAdapter()
+	if path == "" {
+		return nil, ErrNoAdapter
+	}
+	return m.conn.Object(bluezService, path), nil
+}
+
+// GetDevices gets the Bluetooth devices known to the active adapter.
+// When the manager is watching signals the object cache is used and no
+// DBus round trip is made. Devices with properties that could not be
+// decoded are still returned, together with an error describing them.
 func (m *Manager) GetDevices() (map[string]*models.Device, error) {
-	return getDevices(m.conn)
+	adapter := m.GetAdapter()
+	if adapter == "" {
+		return make(map[string]*models.Device), nil
+	}
+
+	objects, release, err := m.managedObjects()
+	if err != nil {
+		return nil, err
+	}
+	devices, err := parseDevices(objects, adapter)
+	release()
+
+	for _, dev := range devices {
+		m.withTransitions(dev)
+	}
+	return devices, err
+}
+
+// GetDevice returns the device at path, or nil when BlueZ does not know it.
+// Like GetDevices, a device whose properties could not all be decoded is
+// returned together with the error.
+func (m *Manager) GetDevice(path dbus.ObjectPath) (*models.Device, error) {
+	objects, release, err := m.managedObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces, ok := objects[path]
+	props, isDevice := interfaces[bluezDeviceIface]
+	if !ok || !isDevice {
+		release()
+		return nil, nil
+	}
+	dev, err := parseDevice(path, interfaces, props)
+	release()
+
+	m.withTransitions(dev)
+	return dev, err
 }
 
 // GetAdapterInfo gets the Bluetooth adapter information.
 func (m *Manager) GetAdapterInfo() (*models.Adapter, error) {
-	obj := m.conn.Object(bluezService, m.adapter)
-
-	adapter := &models.Adapter{
-		Path: m.adapter,
+	path := m.GetAdapter()
+	if path == "" {
+		return nil, ErrNoAdapter
 	}
 
-	// Get all adapter properties
-	props, err := obj.GetProperty("org.bluez.Adapter1.Address")
-	if err == nil {
-		if address, ok := props.Value().(string); ok {
-			adapter.Address = address
+	m.mu.RLock()
+	if m.objects != nil {
+		if props, ok := m.objects[path][bluezAdapterIface]; ok {
+			adapter, err := parseAdapter(path, props)
+			m.mu.RUnlock()
+			return adapter, err
 		}
 	}
+	m.mu.RUnlock()
 
-	props, err = obj.GetProperty("org.bluez.Adapter1.Name")
-	if err == nil {
-		if name, ok := props.Value().(string); ok {
-			adapter.Name = name
-		}
+	// Not cached: fetch all adapter properties in a single call
+	obj := m.conn.Object(bluezService, path)
+	var props map[string]dbus.Variant
+	err := obj.Call(propertiesIface+".GetAll", 0, bluezAdapterIface).Store(&props)
+	if err != nil {
+		return nil, err
 	}
 
-	props, err = obj.GetProperty("org.bluez.Adapter1.Alias")
-	if err == nil {
-		if alias, ok := props.Value().(string); ok {
-			adapter.Alias = alias
-		}
+	return parseAdapter(path, props)
+}
+
+// managedObjects returns the cached object tree, or fetches it when the
+// manager is not watching signals. release must be called when done.
+func (m *Manager) managedObjects() (objectMap, func(), error) {
+	m.mu.RLock()
+	if m.objects != nil {
+		return m.objects, m.mu.RUnlock, nil
 	}
+	m.mu.RUnlock()
 
-	props, err = obj.GetProperty("org.bluez.Adapter1.Powered")
-	if err == nil {
-		if powered, ok := props.Value().(bool); ok {
-			adapter.Powered = powered
-		}
+	objects, err := getManagedObjects(m.conn)
+	if err != nil {
+		return nil, nil, err
 	}
+	return objects, func() {}, nil
+}
 
-	props, err = obj.GetProperty("org.bluez.Adapter1.Discoverable")
-	if err == nil {
-		if discoverable, ok := props.Value().(bool); ok {
-			adapter.Discoverable = discoverable
+// parseAdapters extracts every Adapter1 object, sorted by path.
+// Decode errors are left to GetAdapterInfo, which reports them for the
+// active adapter.
+func parseAdapters(objects objectMap) []*models.Adapter {
+	adapters := make([]*models.Adapter, 0)
+	for path, interfaces := range objects {
+		if props, ok := interfaces[bluezAdapterIface]; ok {
+			adapter, _ := parseAdapter(path, props)
+			adapters = append(adapters, adapter)
 		}
 	}
+	sort.Slice(adapters, func(i, j int) bool {
+		return adapters[i].Path < adapters[j].Path
+	})
+	return adapters
+}
 
-	props, err = obj.GetProperty("org.bluez.Adapter1.Pairable")
-	if err == nil {
-		if pairable, ok := props.Value().(bool); ok {
-			adapter.Pairable = pairable
+// findAdapter matches an adapter by name (hciX), object path or address.
+// An empty name matches the first adapter.
+func findAdapter(adapters []*models.Adapter, name string) *models.Adapter {
+	if len(adapters) == 0 {
+		return nil
+	}
+	if name == "" {
+		return adapters[0]
+	}
+	for _, adapter := range adapters {
+		if adapter.ID() == name || string(adapter.Path) == name ||
+			strings.EqualFold(adapter.Address, name) {
+			return adapter
 		}
 	}
+	return nil
+}
 
-	props, err = obj.GetProperty("org.bluez.Adapter1.Discovering")
-	if err == nil {
-		if discovering, ok := props.Value().(bool); ok {
-			adapter.Discovering = discovering
-		}
+// parseAdapter converts DBus properties into an Adapter model.
+// The adapter is always returned; err reports properties of an unexpected type.
+func parseAdapter(path dbus.ObjectPath, props map[string]dbus.Variant) (*models.Adapter, error) {
+	adapter := &models.Adapter{
+		Path: path,
 	}
 
+	if err := decodeProperties(props, adapter); err != nil {
+		return adapter, fmt.Errorf("%s: %w", path, err)
+	}
 	return adapter, nil
 }
 
 // SetAdapterPowered turns the Bluetooth adapter on or off.
 func (m *Manager) SetAdapterPowered(powered bool) error {
-	obj := m.conn.Object(bluezService, m.adapter)
-	err := obj.Call("org.freedesktop.DBus.Properties.Set", 0,
-		bluezAdapterIface, "Powered", dbus.MakeVariant(powered)).Err
+	obj, err := m.adapterObject()
+	if err != nil {
+		return err
+	}
+	err = call(context.Background(), m.Timeouts().Other, obj, propertiesIface+".Set",
+		bluezAdapterIface, "Powered", dbus.MakeVariant(powered))
 	if err != nil {
 		return fmt.Errorf(i18n.T.ErrorSetAdapterPowered+": %w", err)
 	}
@@ -137,9 +314,12 @@ func (m *Manager) SetAdapterPowered(powered bool) error {
 
 // SetAdapterDiscoverable enables or disables discoverable mode.
 func (m *Manager) SetAdapterDiscoverable(discoverable bool) error {
-	obj := m.conn.Object(bluezService, m.adapter)
-	err := obj.Call("org.freedesktop.DBus.Properties.Set", 0,
-		bluezAdapterIface, "Discoverable", dbus.MakeVariant(discoverable)).Err
+	obj, err := m.adapterObject()
+	if err != nil {
+		return err
+	}
+	err = call(context.Background(), m.Timeouts().Other, obj, propertiesIface+".Set",
+		bluezAdapterIface, "Discoverable", dbus.MakeVariant(discoverable))
 	if err != nil {
 		return fmt.Errorf(i18n.T.ErrorSetAdapterDiscoverable+": %w", err)
 	}
@@ -148,9 +328,12 @@ func (m *Manager) SetAdapterDiscoverable(discoverable bool) error {
 
 // SetAdapterPairable enables or disables pairable mode.
 func (m *Manager) SetAdapterPairable(pairable bool) error {
-	obj := m.conn.Object(bluezService, m.adapter)
-	err := obj.Call("org.freedesktop.DBus.Properties.Set", 0,
-		bluezAdapterIface, "Pairable", dbus.MakeVariant(pairable)).Err
+	obj, err := m.adapterObject()
+	if err != nil {
+		return err
+	}
+	err = call(context.Background(), m.Timeouts().Other, obj, propertiesIface+".Set",
+		bluezAdapterIface, "Pairable", dbus.MakeVariant(pairable))
 	if err != nil {
 		return fmt.Errorf(i18n.T.ErrorSetAdapterPairable+": %w", err)
 	}
@@ -159,28 +342,45 @@ func (m *Manager) SetAdapterPairable(pairable bool) error {
 
 // SetAdapterAlias changes the adapter alias (display name).
 func (m *Manager) SetAdapterAlias(alias string) error {
-	obj := m.conn.Object(bluezService, m.adapter)
-	err := obj.Call("org.freedesktop.DBus.Properties.Set", 0,
-		bluezAdapterIface, "Alias", dbus.MakeVariant(alias)).Err
+	obj, err := m.adapterObject()
+	if err != nil {
+		return err
+	}
+	err = call(context.Background(), m.Timeouts().Other, obj, propertiesIface+".Set",
+		bluezAdapterIface, "Alias", dbus.MakeVariant(alias))
 	if err != nil {
 		return fmt.Errorf(i18n.T.ErrorSetAdapterAlias+": %w", err)
 	}
 	return nil
 }
 
-// getAdapter finds the first available Bluetooth adapter.
-func getAdapter(conn *dbus.Conn) (dbus.ObjectPath, error) {
+// getManagedObjects fetches the full BlueZ object tree.
+func getManagedObjects(conn *dbus.Conn) (objectMap, error) {
 	obj := conn.Object(bluezService, "/")
-	var paths map[string]map[string]map[string]dbus.Variant
-	err := obj.Call("org.freedesktop.DBus.ObjectManager.GetManagedObjects", 0).Store(&paths)
+	var objects objectMap
+	err := obj.Call(objectManagerIface+".GetManagedObjects", 0).Store(&objects)
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// getAdapter finds the preferred adapter, or the first one in path order.
+// An empty path is returned when the system has no adapters at all.
+func getAdapter(conn *dbus.Conn, preferred string) (dbus.ObjectPath, error) {
+	objects, err := getManagedObjects(conn)
 	if err != nil {
 		return "", err
 	}
 
-	for path, interfaces := range paths {
-		if _, ok := interfaces[bluezAdapterIface]; ok {
-			return dbus.ObjectPath(path), nil
-		}
+	adapters := parseAdapters(objects)
+	if len(adapters) == 0 {
+		return "", nil
+	}
+
+	adapter := findAdapter(adapters, preferred)
+	if adapter == nil {
+		return "", fmt.Errorf(i18n.T.ErrorAdapterUnknown, preferred)
 	}
-	return "", fmt.Errorf("%s", i18n.T.ErrorAdapterNotFound)
+	return adapter.Path, nil
 }
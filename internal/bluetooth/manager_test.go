@@ -82,3 +82,107 @@ func TestManager_Struct(t *testing.T) {
 		t.Errorf("adapter = %v, want /org/bluez/hci0", m.adapter)
 	}
 }
+
+// TestParseAdapters verifies adapters are listed in path order
+func TestParseAdapters(t *testing.T) {
+	objects := objectMap{
+		"/org/bluez/hci1": {
+			bluezAdapterIface: {"Address": dbus.MakeVariant("11:11:11:11:11:11")},
+		},
+		"/org/bluez/hci0": {
+			bluezAdapterIface: {"Address": dbus.MakeVariant("00:00:00:00:00:00")},
+		},
+		"/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF": {
+			bluezDeviceIface: {"Address": dbus.MakeVariant("AA:BB:CC:DD:EE:FF")},
+		},
+	}
+
+	adapters := parseAdapters(objects)
+	if len(adapters) != 2 {
+		t.Fatalf("parseAdapters() returned %d adapters, want 2", len(adapters))
+	}
+	if adapters[0].Path != "/org/bluez/hci0" || adapters[1].Path != "/org/bluez/hci1" {
+		t.Errorf("parseAdapters() should sort by path, got %v, %v", adapters[0].Path, adapters[1].Path)
+	}
+}
+
+// TestFindAdapter tests adapter lookup by name, path and address
+func TestFindAdapter(t *testing.T) {
+	adapters := parseAdapters(objectMap{
+		"/org/bluez/hci0": {bluezAdapterIface: {"Address": dbus.MakeVariant("00:00:00:00:00:00")}},
+		"/org/bluez/hci1": {bluezAdapterIface: {"Address": dbus.MakeVariant("11:11:11:11:11:11")}},
+	})
+
+	tests := []struct {
+		name     string
+		query    string
+		expected dbus.ObjectPath
+	}{
+		{name: "empty query returns first adapter", query: "", expected: "/org/bluez/hci0"},
+		{name: "matches by name", query: "hci1", expected: "/org/bluez/hci1"},
+		{name: "matches by object path", query: "/org/bluez/hci1", expected: "/org/bluez/hci1"},
+		{name: "matches by address case-insensitively", query: "11:11:11:11:11:11", expected: "/org/bluez/hci1"},
+		{name: "returns nil for unknown adapter", query: "hci7", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findAdapter(adapters, tt.query)
+			if tt.expected == "" {
+				if got != nil {
+					t.Errorf("findAdapter(%q) = %v, want nil", tt.query, got.Path)
+				}
+				return
+			}
+			if got == nil || got.Path != tt.expected {
+				t.Errorf("findAdapter(%q) = %v, want %v", tt.query, got, tt.expected)
+			}
+		})
+	}
+
+	if findAdapter(nil, "") != nil {
+		t.Errorf("findAdapter() with no adapters should return nil")
+	}
+}
+
+// TestManager_SelectAdapter tests switching adapters and device scoping
+func TestManager_SelectAdapter(t *testing.T) {
+	m := &Manager{
+		adapter: "/org/bluez/hci0",
+		objects: objectMap{
+			"/org/bluez/hci0": {bluezAdapterIface: {"Address": dbus.MakeVariant("00:00:00:00:00:00")}},
+			"/org/bluez/hci1": {bluezAdapterIface: {"Address": dbus.MakeVariant("11:11:11:11:11:11")}},
+			"/org/bluez/hci0/dev_AA_AA_AA_AA_AA_AA": {
+				bluezDeviceIface: {"Address": dbus.MakeVariant("AA:AA:AA:AA:AA:AA")},
+			},
+			"/org/bluez/hci1/dev_BB_BB_BB_BB_BB_BB": {
+				bluezDeviceIface: {"Address": dbus.MakeVariant("BB:BB:BB:BB:BB:BB")},
+			},
+		},
+	}
+
+	devices, _ := m.GetDevices()
+	if _, ok := devices["AA:AA:AA:AA:AA:AA"]; !ok || len(devices) != 1 {
+		t.Errorf("GetDevices() should only return devices of hci0, got %v", devices)
+	}
+
+	adapter, err := m.SelectAdapter("hci1")
+	if err != nil {
+		t.Fatalf("SelectAdapter() returned error: %v", err)
+	}
+	if adapter.Path != "/org/bluez/hci1" || m.GetAdapter() != "/org/bluez/hci1" {
+		t.Errorf("SelectAdapter() did not switch to hci1")
+	}
+
+	devices, _ = m.GetDevices()
+	if _, ok := devices["BB:BB:BB:BB:BB:BB"]; !ok || len(devices) != 1 {
+		t.Errorf("GetDevices() should only return devices of hci1, got %v", devices)
+	}
+
+	if _, err := m.SelectAdapter("hci9"); err == nil {
+		t.Errorf("SelectAdapter() should fail for unknown adapter")
+	}
+	if m.GetAdapter() != "/org/bluez/hci1" {
+		t.Errorf("failed SelectAdapter() should keep the current adapter")
+	}
+}
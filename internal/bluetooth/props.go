@@ -0,0 +1,122 @@
+package bluetooth
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/i18n"
+)
+
+// PropertyTypeError reports a D-Bus property whose value does not fit the
+// field it is decoded into.
+type PropertyTypeError struct {
+	Property  string
+	Signature string       // D-Bus signature of the received value
+	Want      reflect.Type // Go type of the destination field
+}
+
+func (e *PropertyTypeError) Error() string {
+	return fmt.Sprintf(i18n.T.ErrorDecodeProperty, e.Property, e.Signature, e.Want)
+}
+
+// decodeProperties copies D-Bus properties onto the fields of the struct
+// pointed to by out, matching each field by its `dbus` tag. Properties
+// without a tagged field are ignored. Nested variants, maps and slices are
+// unwrapped, so a{qv} decodes into map[uint16][]byte, and pointer fields
+// are allocated only when the property is present. A property that does
+// not fit its field leaves the field untouched and is reported as a
+// *PropertyTypeError; every mismatch is returned joined into one error.
+func decodeProperties(props map[string]dbus.Variant, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		panic("decodeProperties: out must be a pointer to a struct")
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	var errs []error
+	for i := 0; i < rt.NumField(); i++ {
+		name := rt.Field(i).Tag.Get("dbus")
+		if name == "" || name == "-" {
+			continue
+		}
+		variant, ok := props[name]
+		if !ok {
+			continue
+		}
+
+		field := rv.Field(i)
+		value, ok := convertValue(variant.Value(), field.Type())
+		if !ok {
+			errs = append(errs, &PropertyTypeError{
+				Property:  name,
+				Signature: variant.Signature().String(),
+				Want:      field.Type(),
+			})
+			continue
+		}
+		field.Set(value)
+	}
+
+	return errors.Join(errs...)
+}
+
+// convertValue converts a value received from D-Bus into want.
+// Only lossless conversions are made: variants are unwrapped and maps and
+// slices are converted element by element; numeric types must match exactly.
+func convertValue(src interface{}, want reflect.Type) (reflect.Value, bool) {
+	if variant, ok := src.(dbus.Variant); ok {
+		src = variant.Value()
+	}
+	if src == nil {
+		return reflect.Value{}, false
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(want) {
+		return sv, true
+	}
+
+	switch {
+	case want.Kind() == reflect.Pointer:
+		// Optional values: decode into a newly allocated element
+		elem, ok := convertValue(src, want.Elem())
+		if !ok {
+			return reflect.Value{}, false
+		}
+		ptr := reflect.New(want.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, true
+
+	case sv.Kind() == reflect.Map && want.Kind() == reflect.Map:
+		out := reflect.MakeMapWithSize(want, sv.Len())
+		iter := sv.MapRange()
+		for iter.Next() {
+			key, ok := convertValue(iter.Key().Interface(), want.Key())
+			if !ok {
+				return reflect.Value{}, false
+			}
+			elem, ok := convertValue(iter.Value().Interface(), want.Elem())
+			if !ok {
+				return reflect.Value{}, false
+			}
+			out.SetMapIndex(key, elem)
+		}
+		return out, true
+
+	case sv.Kind() == reflect.Slice && want.Kind() == reflect.Slice:
+		out := reflect.MakeSlice(want, sv.Len(), sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			elem, ok := convertValue(sv.Index(i).Interface(), want.Elem())
+			if !ok {
+				return reflect.Value{}, false
+			}
+			out.Index(i).Set(elem)
+		}
+		return out, true
+	}
+
+	return reflect.Value{}, false
+}
@@ -0,0 +1,233 @@
+package bluetooth
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+func TestDecodeProperties(t *testing.T) {
+	type target struct {
+		Path     dbus.ObjectPath   `dbus:"Path"`
+		Name     string            `dbus:"Name"`
+		Enabled  bool              `dbus:"Enabled"`
+		Level    int16             `dbus:"Level"`
+		Flags    []byte            `dbus:"Flags"`
+		UUIDs    []string          `dbus:"UUIDs"`
+		Data     map[uint16][]byte `dbus:"Data"`
+		Services map[string][]byte `dbus:"Services"`
+		Optional *uint8            `dbus:"Optional"`
+		Skipped  string            `dbus:"-"`
+		Untagged string
+	}
+
+	t.Run("decodes every supported shape", func(t *testing.T) {
+		props := map[string]dbus.Variant{
+			"Path":     dbus.MakeVariant(dbus.ObjectPath("/org/bluez/hci0")),
+			"Name":     dbus.MakeVariant("Keyboard"),
+			"Enabled":  dbus.MakeVariant(true),
+			"Level":    dbus.MakeVariant(int16(-42)),
+			"Flags":    dbus.MakeVariant([]byte{0x06}),
+			"UUIDs":    dbus.MakeVariant([]string{"0000180f-0000-1000-8000-00805f9b34fb"}),
+			"Data":     dbus.MakeVariant(map[uint16]dbus.Variant{0x004c: dbus.MakeVariant([]byte{0x02, 0x15})}),
+			"Services": dbus.MakeVariant(map[string]dbus.Variant{"180f": dbus.MakeVariant([]byte{0x64})}),
+			"Optional": dbus.MakeVariant(uint8(80)),
+			"Skipped":  dbus.MakeVariant("ignored"),
+			"Untagged": dbus.MakeVariant("ignored"),
+			"Unknown":  dbus.MakeVariant(uint32(1)),
+		}
+
+		var got target
+		if err := decodeProperties(props, &got); err != nil {
+			t.Fatalf("decodeProperties() error = %v", err)
+		}
+
+		optional := uint8(80)
+		want := target{
+			Path:     "/org/bluez/hci0",
+			Name:     "Keyboard",
+			Enabled:  true,
+			Level:    -42,
+			Flags:    []byte{0x06},
+			UUIDs:    []string{"0000180f-0000-1000-8000-00805f9b34fb"},
+			Data:     map[uint16][]byte{0x004c: {0x02, 0x15}},
+			Services: map[string][]byte{"180f": {0x64}},
+			Optional: &optional,
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("decodeProperties() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("leaves missing optional fields nil", func(t *testing.T) {
+		var got target
+		if err := decodeProperties(map[string]dbus.Variant{}, &got); err != nil {
+			t.Fatalf("decodeProperties() error = %v", err)
+		}
+		if got.Optional != nil || got.Data != nil {
+			t.Errorf("missing properties should leave fields at their zero value, got %+v", got)
+		}
+	})
+
+	t.Run("reports type mismatches", func(t *testing.T) {
+		props := map[string]dbus.Variant{
+			"Name":  dbus.MakeVariant(int32(7)),
+			"Level": dbus.MakeVariant(int32(-42)), // int16 expected, no lossy conversion
+			"Data":  dbus.MakeVariant(map[uint16]dbus.Variant{1: dbus.MakeVariant("text")}),
+			"Flags": dbus.MakeVariant([]byte{0x02}),
+		}
+
+		got := target{Name: "keep"}
+		err := decodeProperties(props, &got)
+		if err == nil {
+			t.Fatal("decodeProperties() should report mismatches")
+		}
+
+		var typeErr *PropertyTypeError
+		if !errors.As(err, &typeErr) {
+			t.Fatalf("error should wrap *PropertyTypeError, got %T", err)
+		}
+		for _, name := range []string{"Name", "Level", "Data"} {
+			if !containsMismatch(err, name) {
+				t.Errorf("error should mention %s: %v", name, err)
+			}
+		}
+		if got.Name != "keep" {
+			t.Errorf("mismatched field should be left untouched, got %q", got.Name)
+		}
+		if !reflect.DeepEqual(got.Flags, []byte{0x02}) {
+			t.Errorf("valid properties should still be decoded, got %v", got.Flags)
+		}
+	})
+}
+
+// containsMismatch reports whether err joins a PropertyTypeError for property.
+func containsMismatch(err error, property string) bool {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return false
+	}
+	for _, e := range joined.Unwrap() {
+		var typeErr *PropertyTypeError
+		if errors.As(e, &typeErr) && typeErr.Property == property {
+			return true
+		}
+	}
+	return false
+}
+
+func TestParseDevice_FullPropertySet(t *testing.T) {
+	props := map[string]dbus.Variant{
+		"Adapter":          dbus.MakeVariant(dbus.ObjectPath("/org/bluez/hci0")),
+		"Address":          dbus.MakeVariant("AA:BB:CC:DD:EE:FF"),
+		"AddressType":      dbus.MakeVariant("random"),
+		"Name":             dbus.MakeVariant("Heart Rate"),
+		"Bonded":           dbus.MakeVariant(true),
+		"Blocked":          dbus.MakeVariant(false),
+		"ServicesResolved": dbus.MakeVariant(true),
+		"LegacyPairing":    dbus.MakeVariant(false),
+		"WakeAllowed":      dbus.MakeVariant(true),
+		"TxPower":          dbus.MakeVariant(int16(4)),
+		"Appearance":       dbus.MakeVariant(uint16(0x0341)),
+		"Modalias":         dbus.MakeVariant("bluetooth:v004Cp0001d0001"),
+		"UUIDs":            dbus.MakeVariant([]string{"0000180d-0000-1000-8000-00805f9b34fb"}),
+		"ManufacturerData": dbus.MakeVariant(map[uint16]dbus.Variant{0x0059: dbus.MakeVariant([]byte{0x01, 0x02})}),
+		"ServiceData":      dbus.MakeVariant(map[string]dbus.Variant{"0000180d-0000-1000-8000-00805f9b34fb": dbus.MakeVariant([]byte{0x48})}),
+		"AdvertisingData":  dbus.MakeVariant(map[byte]dbus.Variant{0x16: dbus.MakeVariant([]byte{0x0d, 0x18})}),
+		"AdvertisingFlags": dbus.MakeVariant([]byte{0x06}),
+	}
+	interfaces := map[string]map[string]dbus.Variant{bluezDeviceIface: props}
+
+	dev, err := parseDevice("/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF", interfaces, props)
+	if err != nil {
+		t.Fatalf("parseDevice() error = %v", err)
+	}
+
+	resolved := true
+	want := &models.Device{
+		Path:             "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF",
+		Adapter:          "/org/bluez/hci0",
+		Address:          "AA:BB:CC:DD:EE:FF",
+		AddressType:      "random",
+		Name:             "Heart Rate",
+		Bonded:           true,
+		ServicesResolved: &resolved,
+		WakeAllowed:      true,
+		TxPower:          4,
+		Appearance:       0x0341,
+		Modalias:         "bluetooth:v004Cp0001d0001",
+		UUIDs:            []string{"0000180d-0000-1000-8000-00805f9b34fb"},
+		ManufacturerData: map[uint16][]byte{0x0059: {0x01, 0x02}},
+		ServiceData:      map[string][]byte{"0000180d-0000-1000-8000-00805f9b34fb": {0x48}},
+		AdvertisingData:  map[byte][]byte{0x16: {0x0d, 0x18}},
+		AdvertisingFlags: []byte{0x06},
+		LastSeen:         dev.LastSeen,
+	}
+	if !reflect.DeepEqual(dev, want) {
+		t.Errorf("parseDevice() = %+v, want %+v", dev, want)
+	}
+}
+
+func TestParseDevice_ReportsMismatches(t *testing.T) {
+	props := map[string]dbus.Variant{
+		"Address": dbus.MakeVariant("AA:BB:CC:DD:EE:FF"),
+		"RSSI":    dbus.MakeVariant(int32(-60)),
+	}
+	interfaces := map[string]map[string]dbus.Variant{
+		bluezDeviceIface:  props,
+		bluezBatteryIface: {"Percentage": dbus.MakeVariant("full")},
+	}
+
+	dev, err := parseDevice("/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF", interfaces, props)
+	if err == nil {
+		t.Fatal("parseDevice() should report the mismatched RSSI and Percentage")
+	}
+	if dev == nil || dev.Address != "AA:BB:CC:DD:EE:FF" {
+		t.Fatalf("parseDevice() should still return the decodable properties, got %+v", dev)
+	}
+	if dev.Battery != nil {
+		t.Errorf("Battery should stay nil when Percentage has the wrong type")
+	}
+
+	devices, err := parseDevices(objectMap{dev.Path: interfaces}, "/org/bluez/hci0")
+	if err == nil || len(devices) != 1 {
+		t.Errorf("parseDevices() = %d devices, %v; want 1 device and an error", len(devices), err)
+	}
+}
+
+func TestParseAdapter(t *testing.T) {
+	props := map[string]dbus.Variant{
+		"Address":      dbus.MakeVariant("00:11:22:33:44:55"),
+		"Name":         dbus.MakeVariant("laptop"),
+		"Alias":        dbus.MakeVariant("Work laptop"),
+		"Powered":      dbus.MakeVariant(true),
+		"Discoverable": dbus.MakeVariant(false),
+		"Pairable":     dbus.MakeVariant(true),
+		"Discovering":  dbus.MakeVariant(true),
+	}
+
+	adapter, err := parseAdapter("/org/bluez/hci0", props)
+	if err != nil {
+		t.Fatalf("parseAdapter() error = %v", err)
+	}
+	want := &models.Adapter{
+		Path:        "/org/bluez/hci0",
+		Address:     "00:11:22:33:44:55",
+		Name:        "laptop",
+		Alias:       "Work laptop",
+		Powered:     true,
+		Pairable:    true,
+		Discovering: true,
+	}
+	if !reflect.DeepEqual(adapter, want) {
+		t.Errorf("parseAdapter() = %+v, want %+v", adapter, want)
+	}
+
+	props["Powered"] = dbus.MakeVariant("yes")
+	if _, err := parseAdapter("/org/bluez/hci0", props); err == nil {
+		t.Errorf("parseAdapter() should report a mismatched Powered")
+	}
+}
@@ -0,0 +1,55 @@
+package bluetooth
+
+import "sync"
+
+// OperationQueue serializes the operations run on the same key, such as a
+// device address, while operations on different keys run in parallel. The
+// zero value is ready to use.
+type OperationQueue struct {
+	mu    sync.Mutex
+	lanes map[string]*lane
+}
+
+// lane holds the operations of one key.
+type lane struct {
+	lock    chan struct{} // Holds a token while an operation runs
+	pending int           // Operations queued or running
+}
+
+// Run runs op once every operation queued earlier on key has finished,
+// and returns when op does.
+func (q *OperationQueue) Run(key string, op func()) {
+	q.mu.Lock()
+	if q.lanes == nil {
+		q.lanes = make(map[string]*lane)
+	}
+	l, ok := q.lanes[key]
+	if !ok {
+		l = &lane{lock: make(chan struct{}, 1)}
+		q.lanes[key] = l
+	}
+	l.pending++
+	q.mu.Unlock()
+
+	l.lock <- struct{}{}
+	defer func() {
+		<-l.lock
+		q.mu.Lock()
+		l.pending--
+		if l.pending == 0 {
+			delete(q.lanes, key)
+		}
+		q.mu.Unlock()
+	}()
+	op()
+}
+
+// Pending returns the number of operations queued or running on key.
+func (q *OperationQueue) Pending(key string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if l, ok := q.lanes[key]; ok {
+		return l.pending
+	}
+	return 0
+}
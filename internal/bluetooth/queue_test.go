@@ -0,0 +1,67 @@
+package bluetooth
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOperationQueue_SameKey(t *testing.T) {
+	var q OperationQueue
+	var mu sync.Mutex
+	running, overlaps := 0, 0
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.Run("AA:BB:CC:DD:EE:FF", func() {
+				mu.Lock()
+				running++
+				if running > 1 {
+					overlaps++
+				}
+				mu.Unlock()
+				time.Sleep(5 * time.Millisecond)
+				mu.Lock()
+				running--
+				mu.Unlock()
+			})
+		}()
+	}
+	wg.Wait()
+
+	if overlaps != 0 {
+		t.Errorf("operations on one device overlapped %d times", overlaps)
+	}
+	if q.Pending("AA:BB:CC:DD:EE:FF") != 0 || len(q.lanes) != 0 {
+		t.Errorf("finished lanes should be dropped")
+	}
+}
+
+func TestOperationQueue_DifferentKeys(t *testing.T) {
+	var q OperationQueue
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	go q.Run("AA:BB:CC:DD:EE:FF", func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	// A slow operation on one device must not hold up another device
+	done := make(chan struct{})
+	go q.Run("11:22:33:44:55:66", func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("an operation on another device was blocked")
+	}
+
+	if got := q.Pending("AA:BB:CC:DD:EE:FF"); got != 1 {
+		t.Errorf("Pending() = %d, want 1", got)
+	}
+	close(release)
+}
@@ -0,0 +1,194 @@
+package bluetooth
+
+import (
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// ReconnectPolicy says how often a device marked for auto-reconnect is
+// tried. Delays double after every failure, up to MaxDelay.
+type ReconnectPolicy struct {
+	BaseDelay   time.Duration // Wait after an unexpected disconnect, and after the first failure
+	MaxDelay    time.Duration // Longest wait between attempts
+	Jitter      float64       // Fraction of each delay that is randomized, from 0 to 1
+	MaxFailures int           // Failures in a row before giving up; 0 = never
+}
+
+// DefaultReconnectPolicy returns the policy used when none is configured.
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		BaseDelay:   5 * time.Second,
+		MaxDelay:    5 * time.Minute,
+		Jitter:      0.2,
+		MaxFailures: 5,
+	}
+}
+
+// reconnectState tracks one device marked for auto-reconnect.
+type reconnectState struct {
+	address  string // As it was marked, which is how it is persisted
+	failures int    // Failed attempts in a row
+	pending  bool   // An attempt is scheduled or running
+	gaveUp   bool   // MaxFailures was reached
+	userOff  bool   // The user disconnected the device, so it stays disconnected
+}
+
+// Reconnector decides when trusted devices marked for auto-reconnect are
+// connected again: when they come back into range or drop their
+// connection without the user asking. It only decides; the caller makes
+// the attempts and reports how they went. A Reconnector is not safe for
+// concurrent use. A nil Reconnector reconnects nothing.
+type Reconnector struct {
+	policy  ReconnectPolicy
+	devices map[string]*reconnectState // Keyed by normalized address
+}
+
+// NewReconnector returns a reconnector for the devices at addresses.
+func NewReconnector(policy ReconnectPolicy, addresses []string) *Reconnector {
+	r := &Reconnector{policy: policy, devices: make(map[string]*reconnectState)}
+	for _, address := range addresses {
+		r.SetEnabled(address, true)
+	}
+	return r
+}
+
+// Enabled reports whether the device at address is marked for
+// auto-reconnect.
+func (r *Reconnector) Enabled(address string) bool {
+	return r.state(address) != nil
+}
+
+// SetEnabled marks or unmarks the device at address. Marking it again
+// starts over after it was given up.
+func (r *Reconnector) SetEnabled(address string, enabled bool) {
+	if enabled {
+		r.devices[models.NormalizeMAC(address)] = &reconnectState{address: address}
+	} else {
+		delete(r.devices, models.NormalizeMAC(address))
+	}
+}
+
+// Addresses returns the marked devices, sorted, as they are persisted.
+func (r *Reconnector) Addresses() []string {
+	if r == nil {
+		return nil
+	}
+	addresses := make([]string, 0, len(r.devices))
+	for _, s := range r.devices {
+		addresses = append(addresses, s.address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// UserDisconnected keeps the device at address disconnected until it is
+// connected again, by the user or on its own.
+func (r *Reconnector) UserDisconnected(address string) {
+	if s := r.state(address); s != nil {
+		s.userOff = true
+		s.pending = false
+	}
+}
+
+// UserConnected lets the device at address be reconnected again, with
+// its failures forgotten.
+func (r *Reconnector) UserConnected(address string) {
+	if s := r.state(address); s != nil {
+		*s = reconnectState{address: s.address}
+	}
+}
+
+// Changed is told that a device went from old, nil when it just appeared,
+// to dev. It returns whether to attempt a reconnect, and after how long.
+func (r *Reconnector) Changed(old, dev *models.Device) (time.Duration, bool) {
+	s := r.state(dev.Address)
+	if s == nil {
+		return 0, false
+	}
+	if dev.Connected {
+		// However it happened, the device is back
+		s.failures, s.pending, s.gaveUp = 0, false, false
+		if old == nil || !old.Connected {
+			// Connected again, so the user's disconnect is over. Updates
+			// while the link is still going down must not clear it.
+			s.userOff = false
+		}
+		return 0, false
+	}
+	if !dev.Paired || !dev.Trusted || s.pending || s.gaveUp || s.userOff {
+		return 0, false
+	}
+
+	switch {
+	case old != nil && old.Connected:
+		// Dropped out: give the device a moment to come back by itself
+		s.pending = true
+		return r.policy.BaseDelay, true
+	case (old == nil || old.RSSI == 0) && dev.RSSI != 0:
+		// Seen again while scanning
+		s.pending = true
+		return 0, true
+	}
+	return 0, false
+}
+
+// Pending reports whether an attempt on the device at address is
+// scheduled or running.
+func (r *Reconnector) Pending(address string) bool {
+	s := r.state(address)
+	return s != nil && s.pending
+}
+
+// Succeeded records that the device at address was reconnected.
+func (r *Reconnector) Succeeded(address string) {
+	if s := r.state(address); s != nil {
+		s.failures, s.pending, s.gaveUp = 0, false, false
+	}
+}
+
+// Failed records a failed attempt on the device at address. It returns
+// the wait before the next attempt, or false once the device is given up.
+func (r *Reconnector) Failed(address string) (time.Duration, bool) {
+	s := r.state(address)
+	if s == nil || !s.pending {
+		return 0, false
+	}
+
+	s.failures++
+	if r.policy.MaxFailures > 0 && s.failures >= r.policy.MaxFailures {
+		s.pending = false
+		s.gaveUp = true
+		return 0, false
+	}
+	return r.delay(s.failures, rand.Float64()), true
+}
+
+// Skip drops the attempt scheduled for the device at address without
+// counting it as a failure, e.g. because the user is busy with it.
+func (r *Reconnector) Skip(address string) {
+	if s := r.state(address); s != nil {
+		s.pending = false
+	}
+}
+
+// state returns the state of the device at address, or nil when it is
+// not marked.
+func (r *Reconnector) state(address string) *reconnectState {
+	if r == nil {
+		return nil
+	}
+	return r.devices[models.NormalizeMAC(address)]
+}
+
+// delay returns the wait after the given number of failures.
+func (r *Reconnector) delay(failures int, jitter float64) time.Duration {
+	backoff := RetryPolicy{BaseDelay: r.policy.BaseDelay, Jitter: r.policy.Jitter}
+	delay := backoff.Delay(failures, jitter)
+	if r.policy.MaxDelay > 0 && delay > r.policy.MaxDelay {
+		return r.policy.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,124 @@
+package bluetooth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+func TestReconnector_Changed(t *testing.T) {
+	policy := ReconnectPolicy{BaseDelay: 5 * time.Second, MaxDelay: time.Minute, MaxFailures: 3}
+	headphones := func(connected bool, rssi int16) *models.Device {
+		return &models.Device{Address: "AA:BB:CC:DD:EE:FF", Paired: true, Trusted: true, Connected: connected, RSSI: rssi}
+	}
+
+	tests := []struct {
+		name      string
+		old, dev  *models.Device
+		userOff   bool
+		wantDelay time.Duration
+		want      bool
+	}{
+		{"dropped out", headphones(true, 0), headphones(false, 0), false, 5 * time.Second, true},
+		{"seen again", headphones(false, 0), headphones(false, -60), false, 0, true},
+		{"appeared in range", nil, headphones(false, -60), false, 0, true},
+		{"cached at startup", nil, headphones(false, 0), false, 0, false},
+		{"signal changed", headphones(false, -70), headphones(false, -60), false, 0, false},
+		{"connected", headphones(false, 0), headphones(true, 0), false, 0, false},
+		{"disconnected by the user", headphones(true, 0), headphones(false, 0), true, 0, false},
+		{"not trusted", nil, &models.Device{Address: "AA:BB:CC:DD:EE:FF", Paired: true, RSSI: -60}, false, 0, false},
+		{"not marked", nil, &models.Device{Address: "11:22:33:44:55:66", Paired: true, Trusted: true, RSSI: -60}, false, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReconnector(policy, []string{"aa:bb:cc:dd:ee:ff"})
+			if tt.userOff {
+				r.UserDisconnected(tt.dev.Address)
+			}
+			delay, ok := r.Changed(tt.old, tt.dev)
+			if ok != tt.want || delay != tt.wantDelay {
+				t.Errorf("Changed() = %v, %v, want %v, %v", delay, ok, tt.wantDelay, tt.want)
+			}
+			if r.Pending(tt.dev.Address) != tt.want {
+				t.Errorf("Pending() = %v, want %v", r.Pending(tt.dev.Address), tt.want)
+			}
+		})
+	}
+}
+
+func TestReconnector_ConnectedAfterUserDisconnect(t *testing.T) {
+	r := NewReconnector(ReconnectPolicy{BaseDelay: 5 * time.Second}, []string{"AA:BB:CC:DD:EE:FF"})
+	resolved, unresolved := true, false
+	up := &models.Device{Address: "AA:BB:CC:DD:EE:FF", Paired: true, Trusted: true, Connected: true, ServicesResolved: &resolved}
+	going := *up
+	going.ServicesResolved = &unresolved
+	down := going
+	down.Connected = false
+
+	r.UserDisconnected(up.Address)
+	r.Changed(up, &going)
+	if _, ok := r.Changed(&going, &down); ok {
+		t.Fatalf("the user's disconnect should not be reconnected")
+	}
+
+	// The device connects on its own, then drops out
+	r.Changed(&down, up)
+	if delay, ok := r.Changed(up, &down); !ok || delay != 5*time.Second {
+		t.Errorf("Changed() = %v, %v, want a reconnect once the device was back", delay, ok)
+	}
+}
+
+func TestReconnector_Failed(t *testing.T) {
+	r := NewReconnector(ReconnectPolicy{BaseDelay: 5 * time.Second, MaxDelay: 8 * time.Second, MaxFailures: 3}, []string{"AA:BB:CC:DD:EE:FF"})
+	dev := &models.Device{Address: "AA:BB:CC:DD:EE:FF", Paired: true, Trusted: true, RSSI: -60}
+
+	if _, ok := r.Changed(nil, dev); !ok {
+		t.Fatalf("Changed() should schedule a reconnect")
+	}
+	if _, ok := r.Changed(dev, dev); ok {
+		t.Errorf("Changed() should not schedule a second reconnect while one is pending")
+	}
+
+	wantDelays := []time.Duration{5 * time.Second, 8 * time.Second}
+	for i, want := range wantDelays {
+		delay, ok := r.Failed(dev.Address)
+		if !ok || delay != want {
+			t.Errorf("failure %d: Failed() = %v, %v, want %v, true", i+1, delay, ok, want)
+		}
+	}
+	if _, ok := r.Failed(dev.Address); ok {
+		t.Errorf("Failed() should give up after MaxFailures")
+	}
+
+	// Given up until the user connects the device again
+	gone := *dev
+	gone.RSSI = 0
+	if _, ok := r.Changed(&gone, dev); ok {
+		t.Errorf("Changed() should not reconnect a device given up on")
+	}
+	r.UserConnected(dev.Address)
+	if _, ok := r.Changed(&gone, dev); !ok {
+		t.Errorf("Changed() should reconnect again once the user connected the device")
+	}
+}
+
+func TestReconnector_SetEnabled(t *testing.T) {
+	r := NewReconnector(DefaultReconnectPolicy(), nil)
+	r.SetEnabled("AA:BB:CC:DD:EE:FF", true)
+	r.SetEnabled("11:22:33:44:55:66", true)
+	r.SetEnabled("aa-bb-cc-dd-ee-ff", false)
+
+	if r.Enabled("AA:BB:CC:DD:EE:FF") {
+		t.Errorf("the device should be unmarked whatever the address format")
+	}
+	if got := r.Addresses(); len(got) != 1 || got[0] != "11:22:33:44:55:66" {
+		t.Errorf("Addresses() = %v, want [11:22:33:44:55:66]", got)
+	}
+
+	var none *Reconnector
+	if none.Enabled("11:22:33:44:55:66") || none.Addresses() != nil {
+		t.Errorf("a nil Reconnector should have no devices")
+	}
+}
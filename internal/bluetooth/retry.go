@@ -0,0 +1,75 @@
+package bluetooth
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy says how an operation failing with a transient BlueZ error
+// is attempted again. Delays grow exponentially from BaseDelay, each
+// spread randomly by Jitter so devices retried together do not collide.
+type RetryPolicy struct {
+	Attempts  int           // Attempts in total; less than 1 means one
+	BaseDelay time.Duration // Wait before the second attempt, doubled for each one after it
+	Jitter    float64       // Fraction of each delay that is randomized, from 0 to 1
+	Deadline  time.Duration // Limit on all the attempts together; 0 = none
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Attempts:  3,
+		BaseDelay: 500 * time.Millisecond,
+		Jitter:    0.2,
+		Deadline:  60 * time.Second,
+	}
+}
+
+// IsTransient reports whether err is a failure that may not happen again
+// shortly: the device did not answer, or was busy with another operation.
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrPageTimeout) || errors.Is(err, ErrInProgress)
+}
+
+// Do runs op until it succeeds or fails with an error that is not
+// transient, the attempts run out, the deadline passes or ctx is
+// cancelled. op gets the context bounded by the deadline and the number
+// of the attempt, starting at 1. The last failure is returned, except
+// when ctx was cancelled while waiting for the next attempt.
+func (p RetryPolicy) Do(ctx context.Context, op func(ctx context.Context, attempt int) error) error {
+	if p.Deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Deadline)
+		defer cancel()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := op(ctx, attempt)
+		if err == nil || !IsTransient(err) || attempt >= p.Attempts {
+			return err
+		}
+
+		timer := time.NewTimer(p.Delay(attempt, rand.Float64()))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return ctx.Err()
+			}
+			// Past the deadline, the failure says more than the timeout
+			return err
+		}
+	}
+}
+
+// Delay returns the wait after the given attempt failed. r, from 0 to 1,
+// picks the point within the jitter range.
+func (p RetryPolicy) Delay(attempt int, r float64) time.Duration {
+	// Doubling stops long before a Duration overflows
+	delay := p.BaseDelay << min(max(attempt-1, 0), 16)
+	jitter := min(max(p.Jitter, 0), 1)
+	return time.Duration(float64(delay) * (1 - jitter + 2*jitter*r))
+}
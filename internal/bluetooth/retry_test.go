@@ -0,0 +1,87 @@
+package bluetooth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, Jitter: 0.5}
+
+	tests := []struct {
+		attempt int
+		r       float64
+		want    time.Duration
+	}{
+		{1, 0.5, 100 * time.Millisecond},
+		{2, 0.5, 200 * time.Millisecond},
+		{3, 0.5, 400 * time.Millisecond},
+		{1, 0, 50 * time.Millisecond},
+		{1, 1, 150 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		if got := p.Delay(tt.attempt, tt.r); got != tt.want {
+			t.Errorf("Delay(%d, %v) = %v, want %v", tt.attempt, tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	busy := Classify(dbus.Error{Name: "org.bluez.Error.InProgress"})
+	refused := Classify(dbus.Error{Name: "org.bluez.Error.NotReady"})
+
+	tests := []struct {
+		name         string
+		policy       RetryPolicy
+		failures     []error // Returned by the attempts in turn, then success
+		wantErr      error
+		wantAttempts int
+	}{
+		{"succeeds at once", RetryPolicy{Attempts: 3}, nil, nil, 1},
+		{"transient then success", RetryPolicy{Attempts: 3}, []error{busy, busy}, nil, 3},
+		{"attempts run out", RetryPolicy{Attempts: 2}, []error{busy, busy, busy}, ErrInProgress, 2},
+		{"not transient", RetryPolicy{Attempts: 3}, []error{refused}, ErrNotReady, 1},
+		{"no attempts configured", RetryPolicy{}, []error{busy}, ErrInProgress, 1},
+		{"deadline", RetryPolicy{Attempts: 5, BaseDelay: time.Second, Deadline: 20 * time.Millisecond}, []error{busy, busy}, ErrInProgress, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := tt.policy.Do(context.Background(), func(ctx context.Context, attempt int) error {
+				attempts++
+				if attempt != attempts {
+					t.Errorf("attempt = %d, want %d", attempt, attempts)
+				}
+				if attempt <= len(tt.failures) {
+					return tt.failures[attempt-1]
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Do_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := RetryPolicy{Attempts: 3, BaseDelay: time.Minute}
+
+	err := p.Do(ctx, func(context.Context, int) error {
+		cancel()
+		return Classify(dbus.Error{Name: "org.bluez.Error.InProgress"})
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() error = %v, want the cancellation", err)
+	}
+}
@@ -0,0 +1,79 @@
+package bluetooth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/config"
+	"github.com/ivangsm/blugo/internal/i18n"
+)
+
+// Timeouts bounds how long each kind of BlueZ call may take. A zero
+// duration leaves the call to the D-Bus default timeout.
+type Timeouts struct {
+	Pair       time.Duration // Device1.Pair, which waits for the user on both ends
+	Connect    time.Duration // Device1.Connect
+	Disconnect time.Duration // Device1.Disconnect
+	Other      time.Duration // Every other call, e.g. removing a device or changing an adapter setting
+}
+
+// DefaultTimeouts returns the timeouts used when none are configured.
+// Pairing outlasts the agent's default prompt deadline, so an unanswered
+// prompt is cancelled before the call itself gives up.
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Pair:       90 * time.Second,
+		Connect:    30 * time.Second,
+		Disconnect: 10 * time.Second,
+		Other:      10 * time.Second,
+	}
+}
+
+// TimeoutsFromConfig returns the timeouts set in the configuration.
+func TimeoutsFromConfig() Timeouts {
+	if config.Global == nil {
+		return DefaultTimeouts()
+	}
+	seconds := func(n int) time.Duration { return time.Duration(n) * time.Second }
+	return Timeouts{
+		Pair:       seconds(config.Global.PairTimeout),
+		Connect:    seconds(config.Global.ConnectTimeout),
+		Disconnect: seconds(config.Global.DisconnectTimeout),
+		Other:      seconds(config.Global.CallTimeout),
+	}
+}
+
+// Timeouts returns the timeouts applied to BlueZ calls.
+func (m *Manager) Timeouts() Timeouts {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.timeouts
+}
+
+// SetTimeouts changes the timeouts applied to BlueZ calls started from
+// now on.
+func (m *Manager) SetTimeouts(timeouts Timeouts) {
+	m.mu.Lock()
+	m.timeouts = timeouts
+	m.mu.Unlock()
+}
+
+// call invokes method on obj and returns its error, classified when BlueZ
+// reported it. The call gives up when ctx ends or, if timeout is not zero,
+// once timeout has passed.
+func call(ctx context.Context, timeout time.Duration, obj dbus.BusObject, method string, args ...interface{}) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := obj.CallWithContext(ctx, method, 0, args...).Err
+	if timeout > 0 && errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf(i18n.T.ErrorCallTimeout+": %w", timeout, err)
+	}
+	return Classify(err)
+}
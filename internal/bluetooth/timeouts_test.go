@@ -0,0 +1,89 @@
+package bluetooth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/config"
+)
+
+// silentObject is a BlueZ object that never answers: its calls end only
+// when their context does.
+type silentObject struct {
+	dbus.BusObject
+	method string
+}
+
+func (o *silentObject) CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	o.method = method
+	<-ctx.Done()
+	return &dbus.Call{Err: ctx.Err()}
+}
+
+func TestCall(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		timeout time.Duration
+		want    error
+		wantMsg string
+	}{
+		{"times out", context.Background(), 10 * time.Millisecond, context.DeadlineExceeded, "10ms"},
+		{"cancelled", cancelled, time.Minute, context.Canceled, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &silentObject{}
+			err := call(tt.ctx, tt.timeout, obj, bluezDeviceIface+".Pair")
+			if !errors.Is(err, tt.want) {
+				t.Errorf("call() error = %v, want %v", err, tt.want)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("call() error = %q, want it to mention %q", err, tt.wantMsg)
+			}
+			if obj.method != bluezDeviceIface+".Pair" {
+				t.Errorf("called %q, want Device1.Pair", obj.method)
+			}
+		})
+	}
+}
+
+func TestManager_SetTimeouts(t *testing.T) {
+	m := &Manager{}
+	if m.Timeouts() != (Timeouts{}) {
+		t.Errorf("a bare manager should leave calls to the D-Bus default timeout")
+	}
+
+	timeouts := DefaultTimeouts()
+	timeouts.Connect = 5 * time.Second
+	m.SetTimeouts(timeouts)
+	if got := m.Timeouts(); got != timeouts {
+		t.Errorf("Timeouts() = %+v, want %+v", got, timeouts)
+	}
+}
+
+func TestTimeoutsFromConfig(t *testing.T) {
+	saved := config.Global
+	defer func() { config.Global = saved }()
+
+	config.Global = nil
+	if got := TimeoutsFromConfig(); got != DefaultTimeouts() {
+		t.Errorf("TimeoutsFromConfig() without config = %+v, want the defaults", got)
+	}
+
+	config.Global = config.Default()
+	config.Global.ConnectTimeout = 45
+	config.Global.CallTimeout = 0
+	got := TimeoutsFromConfig()
+	if got.Connect != 45*time.Second || got.Other != 0 || got.Pair != DefaultTimeouts().Pair {
+		t.Errorf("TimeoutsFromConfig() = %+v, want the configured timeouts", got)
+	}
+}
@@ -0,0 +1,63 @@
+package bluetooth
+
+import (
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// beginTransition records that an operation moving the device at path to
+// state is in flight, until the returned function is called. Devices
+// reported meanwhile carry the transition, and watchers are told about
+// both ends of it.
+func (m *Manager) beginTransition(path dbus.ObjectPath, state models.ConnectionState) func() {
+	m.setTransition(path, state)
+	return func() { m.setTransition(path, models.StateDisconnected) }
+}
+
+// setTransition records the transition of the device at path, removing it
+// for StateDisconnected, and tells the dispatcher about the change.
+func (m *Manager) setTransition(path dbus.ObjectPath, state models.ConnectionState) {
+	m.mu.Lock()
+	if state == models.StateDisconnected {
+		delete(m.transitions, path)
+	} else {
+		if m.transitions == nil {
+			m.transitions = make(map[dbus.ObjectPath]models.ConnectionState)
+		}
+		m.transitions[path] = state
+	}
+	changes := m.changes
+	m.mu.Unlock()
+
+	if changes == nil {
+		return
+	}
+	// The dispatcher falls behind only while events pile up unread, and
+	// the next BlueZ signal about the device carries the transition too
+	select {
+	case changes <- path:
+	default:
+	}
+}
+
+// withTransitions stamps the operations in flight on devices.
+func (m *Manager) withTransitions(devices ...*models.Device) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, dev := range devices {
+		if dev != nil {
+			dev.Transition = m.transitions[dev.Path]
+		}
+	}
+}
+
+// transitionChanged returns the event describing the device at path after
+// its transition began or ended.
+func (m *Manager) transitionChanged(path dbus.ObjectPath) []Event {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.objects == nil {
+		return nil
+	}
+	return m.eventsFor(path)
+}
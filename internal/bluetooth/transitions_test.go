@@ -0,0 +1,49 @@
+package bluetooth
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+func TestManager_BeginTransition(t *testing.T) {
+	const path = dbus.ObjectPath("/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF")
+	m := newWatchingManager()
+	m.changes = make(chan dbus.ObjectPath, 4)
+
+	end := m.beginTransition(path, models.StateConnecting)
+	if got := <-m.changes; got != path {
+		t.Errorf("beginning told the dispatcher about %q, want %q", got, path)
+	}
+
+	dev, err := m.GetDevice(path)
+	if err != nil || dev.State() != models.StateConnecting {
+		t.Errorf("GetDevice() state = %v (error %v), want connecting", dev.State(), err)
+	}
+	devices, _ := m.GetDevices()
+	if state := devices["AA:BB:CC:DD:EE:FF"].State(); state != models.StateConnecting {
+		t.Errorf("GetDevices() state = %v, want connecting", state)
+	}
+	events := m.transitionChanged(path)
+	if len(events) != 1 || events[0].Device.State() != models.StateConnecting {
+		t.Errorf("transitionChanged() = %+v, want the connecting device", events)
+	}
+
+	end()
+	if got := <-m.changes; got != path {
+		t.Errorf("ending told the dispatcher about %q, want %q", got, path)
+	}
+	if dev, _ := m.GetDevice(path); dev.State() != models.StateDisconnected {
+		t.Errorf("state after the connect = %v, want disconnected", dev.State())
+	}
+}
+
+func TestManager_BeginTransition_NotWatching(t *testing.T) {
+	m := &Manager{}
+	end := m.beginTransition("/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF", models.StatePairing)
+	end()
+	if len(m.transitions) != 0 {
+		t.Errorf("transitions = %v, want none left", m.transitions)
+	}
+}
@@ -18,9 +18,22 @@ type Config struct {
 	ThemeMode string `toml:"theme_mode"` // Color theme mode: "ansi" or "truecolor"
 
 	// Performance & Timing (in seconds)
-	RefreshInterval int `toml:"refresh_interval"` // Device list refresh interval (1-10 seconds)
-	PairingDelay    int `toml:"pairing_delay"`    // Wait time after pairing in milliseconds (0-5000)
-	DisconnectDelay int `toml:"disconnect_delay"` // Wait time before forgetting device in milliseconds (0-2000)
+	RefreshInterval int `toml:"refresh_interval"` // Polling fallback interval when signals are unavailable (1-10 seconds)
+	PairingDelay    int `toml:"pairing_delay"`    // Deprecated: ignored, connections are retried instead
+	DisconnectDelay int `toml:"disconnect_delay"` // Deprecated: ignored, removals are retried instead
+
+	// BlueZ call timeouts (in seconds, 0 = D-Bus default)
+	PairTimeout       int `toml:"pair_timeout"`       // Limit on pairing with a device
+	ConnectTimeout    int `toml:"connect_timeout"`    // Limit on connecting to a device
+	DisconnectTimeout int `toml:"disconnect_timeout"` // Limit on disconnecting from a device
+	CallTimeout       int `toml:"call_timeout"`       // Limit on every other call, e.g. forgetting a device
+
+	// Retries of connects and removals failing with a transient error
+	RetryAttempts  int                      `toml:"retry_attempts"`   // Attempts in total
+	RetryBaseDelay int                      `toml:"retry_base_delay"` // Milliseconds before the second attempt, doubled for each one after it
+	RetryJitter    float64                  `toml:"retry_jitter"`     // Fraction of each delay that is randomized (0-1)
+	RetryDeadline  int                      `toml:"retry_deadline"`   // Seconds all the attempts may take together (0 = no limit)
+	RetryByDevice  map[string]RetrySettings `toml:"retry_by_device"`  // Settings for one device, keyed by address
 
 	// Display & UI
 	MaxTerminalWidth  int  `toml:"max_terminal_width"`  // Maximum UI width in characters (80-200)
@@ -34,6 +47,42 @@ type Config struct {
 	AutoStartScanning bool `toml:"auto_start_scanning"` // Start scanning on app launch
 	RememberLanguage  bool `toml:"remember_language"`   // Save language changes to config
 
+	// Automatic reconnect of trusted devices
+	AutoReconnectDevices     []string `toml:"auto_reconnect_devices"`      // Addresses reconnected when they come back or drop out
+	AutoReconnectDelay       int      `toml:"auto_reconnect_delay"`        // Seconds before the first attempt after a drop, doubled after each failure
+	AutoReconnectMaxFailures int      `toml:"auto_reconnect_max_failures"` // Failures in a row before giving up (0 = never)
+
+	// Pairing
+	PairingTimeout   int                 `toml:"pairing_timeout"`     // Seconds to wait for an answer to a pairing prompt
+	PinCodes         []string            `toml:"pin_codes"`           // PINs tried automatically with legacy devices
+	PinCodesByClass  map[string][]string `toml:"pin_codes_by_class"`  // PINs tried first for a device type or major class
+	PinCodesByDevice map[string][]string `toml:"pin_codes_by_device"` // PINs tried first for one device, keyed by address
+
+	// Pairing agent
+	AgentCapability string `toml:"agent_capability"` // IO capability registered with BlueZ
+	AgentDefault    bool   `toml:"agent_default"`    // Become the default agent, taking pairing requests from desktop agents
+
+	// Headless agent (blugo agent)
+	HeadlessJustWorks      bool     `toml:"headless_just_works"`      // Accept pairings without a code
+	HeadlessAllowedDevices []string `toml:"headless_allowed_devices"` // Addresses whose passkey comparisons are accepted
+	HeadlessPinCode        string   `toml:"headless_pin_code"`        // PIN given to legacy devices; empty rejects them
+
+	// Authorization of incoming pairing and service requests
+	Authorization          string            `toml:"authorization"`            // Default mode: "allow", "trusted", "prompt" or "deny"
+	AuthorizationByService map[string]string `toml:"authorization_by_service"` // Mode for a service, keyed by UUID
+	AuthorizationByDevice  map[string]string `toml:"authorization_by_device"`  // Mode for one device, keyed by address
+
+	// Adapter selection
+	Adapter string `toml:"adapter"` // Adapter to use (e.g. "hci1"); empty = first available
+
+	// Discovery filter (applied by the controller while scanning)
+	DiscoveryTransport     string   `toml:"discovery_transport"`      // Transport to scan: "auto", "bredr" or "le"
+	DiscoveryRSSI          int      `toml:"discovery_rssi"`           // Minimum RSSI in dBm (0 = off)
+	DiscoveryPathloss      int      `toml:"discovery_pathloss"`       // Maximum pathloss in dB (0 = off)
+	DiscoveryUUIDs         []string `toml:"discovery_uuids"`          // Only report devices advertising one of these services
+	DiscoveryPattern       string   `toml:"discovery_pattern"`        // Only report devices whose name or address starts with this
+	DiscoveryDuplicateData bool     `toml:"discovery_duplicate_data"` // Report every advertisement, not only changes
+
 	// Battery Thresholds (percentage 0-100)
 	BatteryHighThreshold int `toml:"battery_high_threshold"` // Level above which battery is "high"
 	BatteryLowThreshold  int `toml:"battery_low_threshold"`  // Level below which battery is "low"
@@ -44,6 +93,15 @@ type Config struct {
 	DeviceTimeout      int  `toml:"device_timeout"`       // Remove devices not seen for X seconds (0 = never)
 }
 
+// RetrySettings overrides the retry settings for one device. Fields left
+// at zero keep the global value.
+type RetrySettings struct {
+	Attempts  int     `toml:"attempts"`
+	BaseDelay int     `toml:"base_delay"`
+	Jitter    float64 `toml:"jitter"`
+	Deadline  int     `toml:"deadline"`
+}
+
 var (
 	// Global config instance
 	Global *Config
@@ -64,6 +122,18 @@ func Default() *Config {
 		PairingDelay:    1000, // 1 second
 		DisconnectDelay: 500,  // 500ms
 
+		// BlueZ call timeouts
+		PairTimeout:       90, // Outlasts pairing_timeout, so prompts expire first
+		ConnectTimeout:    30,
+		DisconnectTimeout: 10,
+		CallTimeout:       10,
+
+		// Retries
+		RetryAttempts:  3,
+		RetryBaseDelay: 500, // Half a second, then one, two...
+		RetryJitter:    0.2,
+		RetryDeadline:  60,
+
 		// Display & UI
 		MaxTerminalWidth:  140, // Good for most terminals
 		ShowRSSI:          true,
@@ -76,6 +146,27 @@ func Default() *Config {
 		AutoStartScanning: true, // Most users want this
 		RememberLanguage:  true, // Persist language preference
 
+		// Automatic reconnect
+		AutoReconnectDelay:       5,
+		AutoReconnectMaxFailures: 5,
+
+		// Pairing
+		PairingTimeout: 60,                               // One minute to answer a prompt
+		PinCodes:       []string{"0000", "1234", "1111"}, // The usual factory PINs
+
+		// Pairing agent
+		AgentCapability: "KeyboardDisplay", // Allows every pairing method
+		AgentDefault:    true,              // Handle pairings started from the device too
+
+		// Authorization
+		Authorization: "prompt", // Ask before an untrusted device uses a service
+
+		// Discovery filter
+		DiscoveryTransport:     "auto", // Dual-mode scan
+		DiscoveryRSSI:          0,      // No threshold
+		DiscoveryPathloss:      0,      // No threshold
+		DiscoveryDuplicateData: true,   // Keep RSSI updates flowing
+
 		// Battery Thresholds
 		BatteryHighThreshold: 60, // 60% and above is "high"
 		BatteryLowThreshold:  30, // Below 30% is "low"
@@ -118,10 +209,68 @@ func Load() (*Config, error) {
 
 	// Load existing config
 	cfg := &Config{}
-	if _, err := toml.DecodeFile(configPath, cfg); err != nil {
+	meta, err := toml.DecodeFile(configPath, cfg)
+	if err != nil {
 		return nil, fmt.Errorf("failed to decode config file: %w", err)
 	}
 
+	// Files written before the discovery filter existed lack this key;
+	// its zero value would silently drop duplicate advertisements
+	if !meta.IsDefined("discovery_duplicate_data") {
+		cfg.DiscoveryDuplicateData = true
+	}
+
+	// Same for the pairing settings; an explicitly empty pin_codes list
+	// is kept so users can turn the automatic attempts off
+	if !meta.IsDefined("pairing_timeout") {
+		cfg.PairingTimeout = Default().PairingTimeout
+	}
+	if !meta.IsDefined("pin_codes") {
+		cfg.PinCodes = Default().PinCodes
+	}
+
+	if !meta.IsDefined("agent_capability") {
+		cfg.AgentCapability = Default().AgentCapability
+	}
+	if !meta.IsDefined("agent_default") {
+		cfg.AgentDefault = Default().AgentDefault
+	}
+	if !meta.IsDefined("authorization") {
+		cfg.Authorization = Default().Authorization
+	}
+
+	// A zero timeout means no limit, so missing ones get the defaults
+	if !meta.IsDefined("pair_timeout") {
+		cfg.PairTimeout = Default().PairTimeout
+	}
+	if !meta.IsDefined("connect_timeout") {
+		cfg.ConnectTimeout = Default().ConnectTimeout
+	}
+	if !meta.IsDefined("disconnect_timeout") {
+		cfg.DisconnectTimeout = Default().DisconnectTimeout
+	}
+	if !meta.IsDefined("call_timeout") {
+		cfg.CallTimeout = Default().CallTimeout
+	}
+	if !meta.IsDefined("retry_attempts") {
+		cfg.RetryAttempts = Default().RetryAttempts
+	}
+	if !meta.IsDefined("retry_base_delay") {
+		cfg.RetryBaseDelay = Default().RetryBaseDelay
+	}
+	if !meta.IsDefined("retry_jitter") {
+		cfg.RetryJitter = Default().RetryJitter
+	}
+	if !meta.IsDefined("retry_deadline") {
+		cfg.RetryDeadline = Default().RetryDeadline
+	}
+	if !meta.IsDefined("auto_reconnect_delay") {
+		cfg.AutoReconnectDelay = Default().AutoReconnectDelay
+	}
+	if !meta.IsDefined("auto_reconnect_max_failures") {
+		cfg.AutoReconnectMaxFailures = Default().AutoReconnectMaxFailures
+	}
+
 	return cfg, nil
 }
 
@@ -161,10 +310,27 @@ func (c *Config) Save() error {
 
 # PERFORMANCE & TIMING
 # refresh_interval: Device list refresh interval in seconds (1-10)
+#   - Only used as a fallback when BlueZ signals cannot be subscribed to
 #   - Lower = more responsive, higher CPU usage
 #   - Higher = less responsive, better battery life
-# pairing_delay: Wait time after pairing in milliseconds (0-5000)
-# disconnect_delay: Wait time before forgetting device in milliseconds (0-2000)
+# pairing_delay, disconnect_delay: No longer used; see CONNECTION RETRIES
+
+# BLUEZ CALL TIMEOUTS (in seconds, 0 = D-Bus default of about 25 seconds)
+# A device that never answers fails the operation once its timeout passes.
+# pair_timeout: Limit on pairing, which includes answering the prompts (keep above pairing_timeout)
+# connect_timeout: Limit on connecting to a device
+# disconnect_timeout: Limit on disconnecting from a device
+# call_timeout: Limit on every other call, e.g. forgetting a device or changing an adapter setting
+
+# CONNECTION RETRIES
+# Connecting to or forgetting a device is attempted again when the device
+# did not answer or was busy, e.g. right after pairing or disconnecting.
+# retry_attempts: Attempts in total (1 = no retries)
+# retry_base_delay: Milliseconds before the second attempt, doubled for each one after it
+# retry_jitter: Fraction of each delay that is randomized (0-1)
+# retry_deadline: Seconds all the attempts may take together (0 = no limit)
+# retry_by_device: Settings for one device, keyed by address; missing or zero fields keep the values above
+#   - Example: [retry_by_device."00:11:22:33:44:55"] with attempts = 6
 
 # DISPLAY & UI
 # max_terminal_width: Maximum UI width in characters (80-200)
@@ -178,6 +344,73 @@ func (c *Config) Save() error {
 # auto_start_scanning: Start scanning on app launch (true/false)
 # remember_language: Save language changes to config (true/false)
 
+# AUTOMATIC RECONNECT
+# Trusted devices listed here are connected again when they come back into
+# range or lose their connection, but not after you disconnect them.
+# Toggle a paired device with "o" in the device list.
+# auto_reconnect_devices: Addresses of the devices to reconnect
+# auto_reconnect_delay: Seconds before the first attempt after a drop, doubled after each failure
+# auto_reconnect_max_failures: Failed attempts in a row before giving up (0 = never)
+
+# PAIRING
+# pairing_timeout: Seconds to wait for an answer to a pairing prompt before cancelling it
+# pin_codes: PINs tried automatically with legacy devices before asking for one
+#   - Set to [] to always ask
+#   - Only tried when pairing from blugo; pairings a device starts always ask
+# pin_codes_by_class: PINs tried first for a device type or major class
+#   - Keys are names shown in the Type or Major Class rows, e.g. printer = ["0000"]
+# pin_codes_by_device: PINs tried first for one device, keyed by address
+#   - Example: "00:11:22:33:44:55" = ["8888"]
+
+# PAIRING AGENT
+# agent_capability: IO capability registered with BlueZ, which picks the pairing method from it
+#   - KeyboardDisplay, DisplayYesNo, DisplayOnly, KeyboardOnly or NoInputNoOutput
+#   - NoInputNoOutput is used instead when BlueZ refuses the configured one
+# agent_default: Become the default agent (true/false)
+#   - The default agent also handles pairings started from the device
+#   - Set to false to leave them to the GNOME or KDE agent
+
+# HEADLESS AGENT
+# Used by "blugo agent", which answers pairing requests without the TUI.
+# Requests not accepted by these settings are rejected.
+# headless_just_works: Accept pairings without a code (true/false)
+#   - Decides them on its own; the authorization settings are not used
+# headless_allowed_devices: Addresses whose passkey comparisons, and service
+#   requests left to "prompt" by the authorization settings, are accepted
+#   - Example: ["00:11:22:33:44:55"]
+# headless_pin_code: PIN given to legacy devices asking for one (empty = reject)
+#   - pin_codes are not tried, since the device starts the pairing
+
+# AUTHORIZATION
+# Applies when a device pairs without a code (Just Works) or an untrusted
+# device wants to use a service such as audio or file transfer.
+# authorization: Default mode for these requests
+#   - "allow": Always allow
+#   - "trusted": Allow trusted devices only
+#   - "prompt": Ask, naming the device and the profile
+#   - "deny": Always refuse
+# authorization_by_service: Mode for a service, keyed by UUID
+#   - Example: "110b" = "allow" (audio sink), "1105" = "deny" (file transfer)
+# authorization_by_device: Mode for one device, keyed by address
+#   - Example: "00:11:22:33:44:55" = "allow"
+#   - Device rules win over service rules, which win over the default;
+#     unknown modes deny
+
+# ADAPTER SELECTION
+# adapter: Bluetooth adapter to use by name, object path or address (e.g. "hci1")
+#   - Empty uses the first adapter; can be overridden with --adapter
+
+# DISCOVERY FILTER
+# Applied by the controller while scanning, so filtered devices never reach blugo.
+# Each value can be overridden with the matching command line flag.
+# discovery_transport: Transport to scan (auto, bredr, le) - --transport
+# discovery_rssi: Minimum RSSI in dBm, e.g. -70 (0 = off) - --rssi
+# discovery_pathloss: Maximum pathloss in dB (0 = off, cannot be combined with RSSI) - --pathloss
+# discovery_uuids: Only report devices advertising one of these service UUIDs - --uuids
+#   - Example: ["180d", "0000110b-0000-1000-8000-00805f9b34fb"]
+# discovery_pattern: Only report devices whose name or address starts with this - --pattern
+# discovery_duplicate_data: Report every advertisement, not only changes (true/false) - --duplicate-data
+
 # BATTERY THRESHOLDS (percentage 0-100)
 # battery_high_threshold: Level above which battery is "high"
 # battery_low_threshold: Level below which battery is "low"
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/BurntSushi/toml"
@@ -57,6 +58,29 @@ func TestDefault(t *testing.T) {
 	if cfg.RememberLanguage != true {
 		t.Errorf("Default RememberLanguage = %v, want true", cfg.RememberLanguage)
 	}
+	if cfg.Adapter != "" {
+		t.Errorf("Default Adapter = %v, want empty", cfg.Adapter)
+	}
+
+	// Test discovery filter
+	if cfg.DiscoveryTransport != "auto" {
+		t.Errorf("Default DiscoveryTransport = %v, want auto", cfg.DiscoveryTransport)
+	}
+	if cfg.DiscoveryRSSI != 0 {
+		t.Errorf("Default DiscoveryRSSI = %v, want 0", cfg.DiscoveryRSSI)
+	}
+	if cfg.DiscoveryPathloss != 0 {
+		t.Errorf("Default DiscoveryPathloss = %v, want 0", cfg.DiscoveryPathloss)
+	}
+	if len(cfg.DiscoveryUUIDs) != 0 {
+		t.Errorf("Default DiscoveryUUIDs = %v, want empty", cfg.DiscoveryUUIDs)
+	}
+	if cfg.DiscoveryPattern != "" {
+		t.Errorf("Default DiscoveryPattern = %v, want empty", cfg.DiscoveryPattern)
+	}
+	if cfg.DiscoveryDuplicateData != true {
+		t.Errorf("Default DiscoveryDuplicateData = %v, want true", cfg.DiscoveryDuplicateData)
+	}
 
 	// Test battery thresholds
 	if cfg.BatteryHighThreshold != 60 {
@@ -350,6 +374,196 @@ func TestLoad_InvalidTOML(t *testing.T) {
 }
 
 // TestInit tests the Init function
+func TestLoad_DiscoveryDuplicateData(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"missing key keeps duplicates", "language = \"en\"\n", true},
+		{"explicit false", "discovery_duplicate_data = false\n", false},
+		{"explicit true", "discovery_duplicate_data = true\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+
+			configDir := filepath.Join(home, ".config", "blugo")
+			if err := os.MkdirAll(configDir, 0755); err != nil {
+				t.Fatalf("Failed to create config directory: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.DiscoveryDuplicateData != tt.want {
+				t.Errorf("DiscoveryDuplicateData = %v, want %v", cfg.DiscoveryDuplicateData, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_Pairing(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantTimeout int
+		wantPins    []string
+	}{
+		{"missing keys use defaults", "language = \"en\"\n", 60, []string{"0000", "1234", "1111"}},
+		{"explicit values", "pairing_timeout = 20\npin_codes = [\"8888\"]\n", 20, []string{"8888"}},
+		{"empty list disables automatic PINs", "pin_codes = []\n", 60, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+
+			configDir := filepath.Join(home, ".config", "blugo")
+			if err := os.MkdirAll(configDir, 0755); err != nil {
+				t.Fatalf("Failed to create config directory: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.PairingTimeout != tt.wantTimeout {
+				t.Errorf("PairingTimeout = %d, want %d", cfg.PairingTimeout, tt.wantTimeout)
+			}
+			if !slices.Equal(cfg.PinCodes, tt.wantPins) {
+				t.Errorf("PinCodes = %v, want %v", cfg.PinCodes, tt.wantPins)
+			}
+		})
+	}
+}
+
+func TestLoad_AgentAndAuthorization(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	configDir := filepath.Join(home, ".config", "blugo")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	content := "language = \"en\"\n\n[authorization_by_service]\n\"110b\" = \"allow\"\n\n[authorization_by_device]\n\"00:11:22:33:44:55\" = \"deny\"\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Authorization != "prompt" {
+		t.Errorf("Authorization = %q, want the prompt default", cfg.Authorization)
+	}
+	if cfg.AgentCapability != "KeyboardDisplay" || !cfg.AgentDefault {
+		t.Errorf("agent = %q, default %v, want the KeyboardDisplay default agent", cfg.AgentCapability, cfg.AgentDefault)
+	}
+	if cfg.AuthorizationByService["110b"] != "allow" {
+		t.Errorf("AuthorizationByService = %v, want 110b allowed", cfg.AuthorizationByService)
+	}
+	if cfg.AuthorizationByDevice["00:11:22:33:44:55"] != "deny" {
+		t.Errorf("AuthorizationByDevice = %v, want the device denied", cfg.AuthorizationByDevice)
+	}
+}
+
+func TestLoad_Timeouts(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	configDir := filepath.Join(home, ".config", "blugo")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	content := "language = \"en\"\nconnect_timeout = 0\npair_timeout = 120\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.PairTimeout != 120 {
+		t.Errorf("PairTimeout = %d, want 120", cfg.PairTimeout)
+	}
+	if cfg.ConnectTimeout != 0 {
+		t.Errorf("ConnectTimeout = %d, want the explicit 0 kept", cfg.ConnectTimeout)
+	}
+	if cfg.DisconnectTimeout != 10 || cfg.CallTimeout != 10 {
+		t.Errorf("DisconnectTimeout = %d, CallTimeout = %d, want the defaults", cfg.DisconnectTimeout, cfg.CallTimeout)
+	}
+}
+
+func TestLoad_Retry(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	configDir := filepath.Join(home, ".config", "blugo")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	content := "retry_attempts = 5\n\n[retry_by_device.\"00:11:22:33:44:55\"]\nattempts = 8\nbase_delay = 2000\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.RetryAttempts != 5 {
+		t.Errorf("RetryAttempts = %d, want 5", cfg.RetryAttempts)
+	}
+	if cfg.RetryBaseDelay != 500 || cfg.RetryJitter != 0.2 || cfg.RetryDeadline != 60 {
+		t.Errorf("missing retry settings should get the defaults, got %d ms, %v, %d s", cfg.RetryBaseDelay, cfg.RetryJitter, cfg.RetryDeadline)
+	}
+	want := RetrySettings{Attempts: 8, BaseDelay: 2000}
+	if got := cfg.RetryByDevice["00:11:22:33:44:55"]; got != want {
+		t.Errorf("RetryByDevice = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoad_AutoReconnect(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	configDir := filepath.Join(home, ".config", "blugo")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	content := "auto_reconnect_devices = [\"00:11:22:33:44:55\"]\nauto_reconnect_max_failures = 0\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.AutoReconnectDevices) != 1 || cfg.AutoReconnectDevices[0] != "00:11:22:33:44:55" {
+		t.Errorf("AutoReconnectDevices = %v, want the listed device", cfg.AutoReconnectDevices)
+	}
+	if cfg.AutoReconnectMaxFailures != 0 {
+		t.Errorf("AutoReconnectMaxFailures = %d, want the explicit 0 kept", cfg.AutoReconnectMaxFailures)
+	}
+	if cfg.AutoReconnectDelay != 5 {
+		t.Errorf("AutoReconnectDelay = %d, want the default", cfg.AutoReconnectDelay)
+	}
+}
+
 func TestInit(t *testing.T) {
 	// Save original global
 	originalGlobal := Global
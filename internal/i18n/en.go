@@ -3,25 +3,61 @@ package i18n
 // englishTranslations contains all English translations
 var englishTranslations = Translations{
 	// App
-	AppTitle:     "BLUGO - Bluetooth Manager",
-	Scanning:     "Scanning",
-	Paused:       "Paused",
-	Initializing: "Initializing Bluetooth...",
+	AppTitle:           "BLUGO - Bluetooth Manager",
+	Scanning:           "Scanning",
+	Paused:             "Paused",
+	AgentStatus:        "Agent: %s",
+	AgentStatusDefault: "Agent: %s (default)",
+	AgentMissing:       "No pairing agent",
+	AgentRegistered:    "Pairing agent registered as %s",
+	Initializing:       "Initializing Bluetooth...",
 
 	// Sections
 	AvailableDevices: "AVAILABLE DEVICES",
 	ConnectedDevices: "CONNECTED DEVICES",
+	DeviceDetails:    "DEVICE DETAILS",
 	AdapterInfo:      "Bluetooth Adapter",
 
 	// Device info
 	NoDevicesAvailable: "No devices available",
 	NoDevicesConnected: "No connected devices",
+	NoDeviceSelected:   "No device selected",
 
 	// Actions
-	Connecting:    "Connecting to %s...",
-	Disconnecting: "Disconnecting from %s (keeping pairing)...",
-	Pairing:       "Pairing with %s...",
-	Forgetting:    "Forgetting %s...",
+	Connecting:             "Connecting to %s...",
+	Disconnecting:          "Disconnecting from %s (keeping pairing)...",
+	Pairing:                "Pairing with %s...",
+	Forgetting:             "Forgetting %s...",
+	OpConnecting:           "Connecting",
+	OpPairing:              "Pairing",
+	OpDisconnecting:        "Disconnecting",
+	OpForgetting:           "Forgetting",
+	OpReconnecting:         "Reconnecting",
+	OpConnectingProfile:    "Connecting %s",
+	OpDisconnectingProfile: "Disconnecting %s",
+	StateDisconnected:      "Disconnected",
+	StatePairing:           "Pairing",
+	StateConnecting:        "Connecting",
+	StateResolvingServices: "Resolving services",
+	StateConnected:         "Connected",
+	StateDisconnecting:     "Disconnecting",
+	OpQueued:               "+%d queued",
+	OperationCancelling:    "Cancelling the operation on %s...",
+	OperationCancelled:     "Operation on %s cancelled",
+	OperationRetrying:      "Retrying %s, attempt %d of %d...",
+	Reconnecting:           "Reconnecting to %s...",
+	Reconnected:            "Reconnected to %s",
+	ReconnectFailed:        "Could not reconnect to %s, trying again in %s: %s",
+	ReconnectGaveUp:        "Gave up reconnecting to %s: %s",
+	AutoReconnectOn:        "%s will be reconnected automatically",
+	AutoReconnectOnTrusted: "%s marked as trusted and will be reconnected automatically",
+	AutoReconnectOff:       "%s will no longer be reconnected automatically",
+	AutoReconnectNotPaired: "Pair %s before reconnecting it automatically",
+	ConnectingProfile:      "Connecting %s on %s...",
+	DisconnectingProfile:   "Disconnecting %s on %s...",
+	ProfileConnected:       "%s connected on %s",
+	ProfileDisconnected:    "%s disconnected on %s",
+	NoProfiles:             "%s has no profiles to connect one at a time",
 
 	// Adapter
 	AdapterPoweringOn:        "Turning Bluetooth adapter on...",
@@ -34,8 +70,18 @@ var englishTranslations = Translations{
 	DiscoverableOff:          "Discoverable mode deactivated",
 	PairableActivating:       "Activating pairable mode...",
 	PairableDeactivating:     "Deactivating pairable mode...",
+	AdapterSwitching:         "Switching to adapter %s...",
+	AdapterSwitched:          "Using adapter %s",
+	AdapterRemovedMsg:        "Adapter %s was removed",
+	NoAdapter:                "No Bluetooth adapter - waiting for one to be connected...",
+	BluezUnavailable:         "BlueZ unavailable - waiting for bluetoothd to come back...",
+	BluezRestored:            "BlueZ is back",
 	PairableOn:               "Pairable mode activated",
 	PairableOff:              "Pairable mode deactivated",
+	DiscoveryFilterSet:       "Discovery filter: %s",
+	DiscoveryFilterNone:      "none",
+	FilterUUIDs:              "%d UUIDs",
+	FilterPattern:            "name: %s",
 
 	// Status messages
 	ScanEnabled:        "Scanning enabled",
@@ -50,22 +96,59 @@ var englishTranslations = Translations{
 	ErrorScanToggle: "Error toggling scan: %s",
 
 	// Pairing
-	PairingCode:        "PAIRING CODE: %06d",
-	PairingInstruction: "Type this code on your keyboard and press Enter",
-	PairingConfirm:     "Then press Enter here to confirm, or Esc/N to cancel",
-	PairingCancelled:   "Pairing cancelled",
+	PairingCode:               "PAIRING CODE: %06d",
+	PairingPinCode:            "PIN CODE: %s",
+	PairingDevice:             "Pairing with %s",
+	PairingInstruction:        "Type this code on your keyboard and press Enter",
+	PairingConfirm:            "Then press Enter here to confirm, or Esc/N to cancel",
+	PairingCancelled:          "Pairing cancelled",
+	PinPrompt:                 "PIN CODE for %s",
+	PinInstruction:            "The device did not accept the usual PINs; check its manual or screen",
+	PinConfirm:                "Type the PIN and press Enter, or Esc to cancel",
+	PinTrying:                 "Trying PIN %s with %s...",
+	RequestTimeout:            "No answer for %s in time, pairing cancelled",
+	PasskeyPrompt:             "PASSKEY for %s",
+	PasskeyInstruction:        "Type the 6-digit code shown on the device",
+	PasskeyConfirm:            "Press Enter once all 6 digits are typed, or Esc to cancel",
+	PasskeyProgress:           "Typed on the device: %s",
+	PasskeyDisplayHint:        "Pairing finishes once Enter is pressed on the device; Esc hides this",
+	PairingCompare:            "Make sure the device shows the same code",
+	IncomingTitle:             "PAIR FROM PHONE",
+	IncomingInstruction:       "On the phone, open the Bluetooth settings and pick %s",
+	IncomingCountdown:         "Visible for %s",
+	IncomingCancel:            "Esc to stop waiting",
+	IncomingStarting:          "Making the adapter visible...",
+	IncomingPaired:            "Paired with %s",
+	IncomingPairedTrusted:     "Paired with %s and trusted",
+	IncomingTimeout:           "No device paired in time; adapter settings restored",
+	IncomingCancelled:         "Stopped waiting; adapter settings restored",
+	IncomingNotPowered:        "Turn the adapter on first",
+	AuthorizeService:          "%s wants to use %s",
+	AuthorizePairing:          "%s wants to pair without a code",
+	AuthorizeInstruction:      "Only allow devices you recognise",
+	AuthorizeConfirm:          "Press Enter/Y to allow, or Esc/N to deny",
+	AuthorizeAllowed:          "%s may use %s",
+	AuthorizeDenied:           "%s may not use %s",
+	AuthorizationPolicyDenied: "%s was refused %s by the authorization policy",
+	AuthorizePairingService:   "pairing",
 
 	// Help
-	HelpNavigation:     "↑↓, kj: navigate | enter: connect/disconnect | d/x: forget | q: quit",
+	HelpNavigation:     "↑↓, kj: navigate | enter: connect/disconnect | d/x: forget | z: cancel | o: auto-reconnect | i: details | q: quit",
 	HelpActions:        "↑↓, kj: navigate | enter: disconnect | d/x: forget",
-	HelpAdapterControl: "s: scan | p: power | v: discoverable | b: pairable | l: language | r: refresh",
+	HelpAdapterControl: "s: scan | p: power | v: discoverable | b: pairable | w: pair from phone | a: adapter | t: transport | f: RSSI filter | c: device type | l: language | r: refresh",
 	HelpScroll:         "PgUp/PgDn: scroll page | Ctrl+↑↓, kj: scroll | Home/End: top/bottom | Mouse wheel: scroll",
 	HelpGeneral:        "q: quit",
 	HelpPairing:        "enter: confirm | n/esc: cancel | q: quit",
+	HelpInput:          "enter: send | esc: cancel | ctrl+c: quit",
+	HelpPasskeyDisplay: "esc: hide | q: quit",
+	HelpIncoming:       "esc: stop waiting | q: quit",
+	HelpAuthorization:  "enter/y: allow | n/esc: deny | q: quit",
 	HelpCollapsed:      "?: toggle help | q: quit",
 	HelpExpanded:       "?: hide help",
+	HelpDetails:        "i/esc: close details | ↑↓, kj: navigate | enter: connect/disconnect | [/]: profile | +/-: connect/disconnect profile | q: quit",
 
 	// Adapter table
+	AdapterID:           "Adapter",
 	AdapterName:         "Name",
 	AdapterAlias:        "Alias",
 	AdapterPower:        "Power",
@@ -73,25 +156,54 @@ var englishTranslations = Translations{
 	AdapterDiscoverable: "Discoverable",
 
 	// Device table
-	DeviceIcon:    "Icon",
-	DeviceName:    "Name",
-	DeviceAddress: "Address",
-	DeviceRSSI:    "Signal",
-	DeviceBattery: "Battery",
-	DeviceStatus:  "Status",
+	DeviceIcon:            "Icon",
+	DeviceName:            "Name",
+	DeviceAddress:         "Address",
+	DeviceRSSI:            "Signal",
+	DeviceBattery:         "Battery",
+	DeviceStatus:          "Status",
+	DetailsIdentity:       "Identity",
+	DetailsState:          "State",
+	DetailsClass:          "Device class",
+	DetailsServices:       "Services",
+	DetailsProfiles:       "Profiles",
+	DetailsAdvertising:    "Advertisement data",
+	DetailsMajorClass:     "Major class",
+	DetailsMinorClass:     "Minor class",
+	DetailsServiceClasses: "Service classes",
+	DetailsUnknownService: "Unknown service",
+	DetailsManufacturer:   "Manufacturer",
+	DetailsType:           "Type",
+	UnnamedVendor:         "Unnamed (%s)",
+	DetailsVendor:         "Vendor",
+	TypeFilterSet:         "Device type: %s",
+	CategoryAll:           "All",
+	CategoryAudio:         "Audio",
+	CategoryInput:         "Input",
+	CategoryPhone:         "Phones",
+	CategoryComputer:      "Computers",
+	CategoryWearable:      "Wearables",
+	CategoryHealth:        "Health",
+	CategoryImaging:       "Imaging & video",
+	CategoryOther:         "Other",
+	DetailsYes:            "yes",
+	DetailsNo:             "no",
 
 	// Status
 	StatusOn:  "ON",
 	StatusOff: "OFF",
 
 	// Badges
-	BadgePaired:    "PAIRED",
-	BadgeConnected: "CONNECTED",
-	BadgeTrusted:   "Trusted",
+	BadgePaired:        "PAIRED",
+	BadgeConnected:     "CONNECTED",
+	BadgeTrusted:       "Trusted",
+	BadgeAutoReconnect: "Auto",
 
 	// Error messages
 	ErrorDBusConnection:         "Could not connect to DBus",
 	ErrorAdapterNotFound:        "No Bluetooth adapter found",
+	ErrorAdapterUnknown:         "Bluetooth adapter %s not found",
+	ErrorSelectAdapter:          "Error switching adapter",
 	ErrorStartDiscovery:         "Could not start discovery",
 	ErrorStopDiscovery:          "Could not stop discovery",
 	ErrorRemoveDevice:           "Could not remove device",
@@ -99,6 +211,32 @@ var englishTranslations = Translations{
 	ErrorTrustDevice:            "Error trusting device",
 	ErrorConnectDevice:          "Error connecting device",
 	ErrorDisconnectDevice:       "Error disconnecting device",
+	ErrorCancelPairing:          "Error cancelling pairing",
+	ErrorConnectProfile:         "Could not connect profile",
+	ErrorDisconnectProfile:      "Could not disconnect profile",
+	ErrorUnknownProfile:         "Unknown profile %q (known: %s)",
+	ErrorDeviceNotFound:         "Device %s not found",
+	ErrorCallTimeout:            "no answer after %s",
+	BluezAuthenticationFailed:   "The device did not accept the PIN or passkey",
+	HintAuthenticationFailed:    "Check the code shown on both devices and pair again",
+	BluezAlreadyConnected:       "The device is already connected",
+	HintAlreadyConnected:        "Disconnect it first to connect again",
+	BluezAlreadyPaired:          "The device is already paired",
+	HintAlreadyPaired:           "Connect to it, or forget it (d) to pair again",
+	BluezInProgress:             "Another operation with the device is still running",
+	HintInProgress:              "Wait for it to finish or cancel it (z)",
+	BluezNotReady:               "The adapter is not ready",
+	HintNotReady:                "Turn the adapter on (p) and check that rfkill does not block it",
+	BluezPageTimeout:            "The device did not answer",
+	HintPageTimeout:             "Turn it on, bring it closer and put it in pairing mode if it is not paired",
+	BluezProfileUnavailable:     "No profile the device offers can be used here",
+	HintProfileUnavailable:      "For audio devices, make sure PipeWire or PulseAudio Bluetooth support is running",
+	BluezKeyMissing:             "The device no longer knows this pairing",
+	HintKeyMissing:              "Forget the device (d) and pair it again",
+	BluezNotAuthorized:          "The system policy denied the request",
+	HintNotAuthorized:           "Run blugo as a user allowed to manage Bluetooth, or check the polkit rules",
+	BluezDeviceGone:             "BlueZ no longer knows the device",
+	HintDeviceGone:              "Refresh the list (r) and scan for it again",
 	ErrorGetDevices:             "Error getting devices",
 	ErrorGetAdapterInfo:         "Error getting adapter info",
 	ErrorSetAdapterPowered:      "Error changing adapter state",
@@ -107,6 +245,13 @@ var englishTranslations = Translations{
 	ErrorSetAdapterAlias:        "Error changing adapter alias",
 	ErrorForgetDevice:           "Error forgetting device",
 	ErrorChangeProperty:         "Error changing",
+	ErrorWatchSignals:           "Could not subscribe to BlueZ signals",
+	ErrorDecodeProperty:         "Property %s: cannot decode %q into %s",
+	ErrorSetDiscoveryFilter:     "Failed to set discovery filter",
+	ErrorFilterTransport:        "Invalid discovery transport %q (use auto, bredr or le)",
+	ErrorFilterRSSI:             "Invalid discovery RSSI %d dBm (use -127 to 20, 0 = off)",
+	ErrorFilterPathloss:         "Invalid discovery pathloss %d dB (use 0 to 255)",
+	ErrorFilterRSSIPathloss:     "Discovery RSSI and pathloss cannot be combined",
 
 	// Status messages
 	StatusConfirmingPairing:  "Confirming pairing...",
@@ -117,10 +262,13 @@ var englishTranslations = Translations{
 	WarningAgentRegistrationDetail: "   The app will work but some devices may require manual pairing.",
 
 	// Agent errors (internal)
-	ErrorRequestPasskey:   "Cannot request passkey in TUI",
-	ErrorPairingCancelled: "Pairing cancelled by user",
-	ErrorConfirmRejected:  "Confirmation rejected",
-	ErrorExportAgent:      "Could not export agent",
-	ErrorExportIntrospect: "Could not export introspection",
-	ErrorRegisterAgent:    "Could not register agent",
+	ErrorInvalidPasskey:      "Invalid passkey",
+	ErrorPairingCancelled:    "Pairing cancelled by user",
+	ErrorRequestTimeout:      "No answer in time",
+	ErrorConfirmRejected:     "Confirmation rejected",
+	ErrorAuthorizationDenied: "Not authorized",
+	ErrorExportAgent:         "Could not export agent",
+	ErrorExportIntrospect:    "Could not export introspection",
+	ErrorRegisterAgent:       "Could not register agent",
+	ErrorAgentCapability:     "Unknown agent capability %q",
 }
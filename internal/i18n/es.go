@@ -3,25 +3,61 @@ package i18n
 // spanishTranslations contains all Spanish translations
 var spanishTranslations = Translations{
 	// App
-	AppTitle:     "BLUGO - Gestor Bluetooth",
-	Scanning:     "Escaneando",
-	Paused:       "Pausado",
-	Initializing: "Inicializando Bluetooth...",
+	AppTitle:           "BLUGO - Gestor Bluetooth",
+	Scanning:           "Escaneando",
+	Paused:             "Pausado",
+	AgentStatus:        "Agente: %s",
+	AgentStatusDefault: "Agente: %s (predeterminado)",
+	AgentMissing:       "Sin agente de pairing",
+	AgentRegistered:    "Agente de pairing registrado como %s",
+	Initializing:       "Inicializando Bluetooth...",
 
 	// Sections
 	AvailableDevices: "DISPOSITIVOS DISPONIBLES",
 	ConnectedDevices: "DISPOSITIVOS CONECTADOS",
+	DeviceDetails:    "DETALLES DEL DISPOSITIVO",
 	AdapterInfo:      "Adaptador Bluetooth",
 
 	// Device info
 	NoDevicesAvailable: "No hay dispositivos disponibles",
 	NoDevicesConnected: "No hay dispositivos conectados",
+	NoDeviceSelected:   "Ningún dispositivo seleccionado",
 
 	// Actions
-	Connecting:    "Conectando a %s...",
-	Disconnecting: "Desconectando de %s (manteniendo pairing)...",
-	Pairing:       "Pareando %s...",
-	Forgetting:    "Olvidando %s...",
+	Connecting:             "Conectando a %s...",
+	Disconnecting:          "Desconectando de %s (manteniendo pairing)...",
+	Pairing:                "Pareando %s...",
+	Forgetting:             "Olvidando %s...",
+	OpConnecting:           "Conectando",
+	OpPairing:              "Pareando",
+	OpDisconnecting:        "Desconectando",
+	OpForgetting:           "Olvidando",
+	OpReconnecting:         "Reconectando",
+	OpConnectingProfile:    "Conectando %s",
+	OpDisconnectingProfile: "Desconectando %s",
+	StateDisconnected:      "Desconectado",
+	StatePairing:           "Pareando",
+	StateConnecting:        "Conectando",
+	StateResolvingServices: "Resolviendo servicios",
+	StateConnected:         "Conectado",
+	StateDisconnecting:     "Desconectando",
+	OpQueued:               "+%d en cola",
+	OperationCancelling:    "Cancelando la operación en %s...",
+	OperationCancelled:     "Operación en %s cancelada",
+	OperationRetrying:      "Reintentando %s, intento %d de %d...",
+	Reconnecting:           "Reconectando a %s...",
+	Reconnected:            "Reconectado a %s",
+	ReconnectFailed:        "No se pudo reconectar a %s, reintentando en %s: %s",
+	ReconnectGaveUp:        "Se dejó de reconectar a %s: %s",
+	AutoReconnectOn:        "%s se reconectará automáticamente",
+	AutoReconnectOnTrusted: "%s marcado como confiable y se reconectará automáticamente",
+	AutoReconnectOff:       "%s ya no se reconectará automáticamente",
+	AutoReconnectNotPaired: "Empareja %s antes de reconectarlo automáticamente",
+	ConnectingProfile:      "Conectando %s en %s...",
+	DisconnectingProfile:   "Desconectando %s en %s...",
+	ProfileConnected:       "%s conectado en %s",
+	ProfileDisconnected:    "%s desconectado en %s",
+	NoProfiles:             "%s no tiene perfiles que conectar por separado",
 
 	// Adapter
 	AdapterPoweringOn:        "Encendiendo adaptador Bluetooth...",
@@ -34,8 +70,18 @@ var spanishTranslations = Translations{
 	DiscoverableOff:          "Modo discoverable desactivado",
 	PairableActivating:       "Activando modo pairable...",
 	PairableDeactivating:     "Desactivando modo pairable...",
+	AdapterSwitching:         "Cambiando al adaptador %s...",
+	AdapterSwitched:          "Usando el adaptador %s",
+	AdapterRemovedMsg:        "Se quitó el adaptador %s",
+	NoAdapter:                "Sin adaptador Bluetooth - esperando a que se conecte uno...",
+	BluezUnavailable:         "BlueZ no disponible - esperando a que bluetoothd vuelva...",
+	BluezRestored:            "BlueZ está de vuelta",
 	PairableOn:               "Modo pairable activado",
 	PairableOff:              "Modo pairable desactivado",
+	DiscoveryFilterSet:       "Filtro de descubrimiento: %s",
+	DiscoveryFilterNone:      "ninguno",
+	FilterUUIDs:              "%d UUIDs",
+	FilterPattern:            "nombre: %s",
 
 	// Status messages
 	ScanEnabled:        "Escaneo activado",
@@ -50,22 +96,59 @@ var spanishTranslations = Translations{
 	ErrorScanToggle: "Error al cambiar escaneo: %s",
 
 	// Pairing
-	PairingCode:        "CÓDIGO DE PAIRING: %06d",
-	PairingInstruction: "Escribe este código en tu teclado y presiona Enter",
-	PairingConfirm:     "Luego presiona Enter aquí para confirmar, o Esc/N para cancelar",
-	PairingCancelled:   "Pairing cancelado",
+	PairingCode:               "CÓDIGO DE PAIRING: %06d",
+	PairingPinCode:            "CÓDIGO PIN: %s",
+	PairingDevice:             "Pairing con %s",
+	PairingInstruction:        "Escribe este código en tu teclado y presiona Enter",
+	PairingConfirm:            "Luego presiona Enter aquí para confirmar, o Esc/N para cancelar",
+	PairingCancelled:          "Pairing cancelado",
+	PinPrompt:                 "CÓDIGO PIN de %s",
+	PinInstruction:            "El dispositivo no aceptó los PIN habituales; revisa su manual o pantalla",
+	PinConfirm:                "Escribe el PIN y presiona Enter, o Esc para cancelar",
+	PinTrying:                 "Probando PIN %s con %s...",
+	RequestTimeout:            "Sin respuesta para %s a tiempo, pairing cancelado",
+	PasskeyPrompt:             "PASSKEY de %s",
+	PasskeyInstruction:        "Escribe el código de 6 dígitos que muestra el dispositivo",
+	PasskeyConfirm:            "Presiona Enter con los 6 dígitos escritos, o Esc para cancelar",
+	PasskeyProgress:           "Escrito en el dispositivo: %s",
+	PasskeyDisplayHint:        "El pairing termina al presionar Enter en el dispositivo; Esc oculta esto",
+	PairingCompare:            "Comprueba que el dispositivo muestra el mismo código",
+	IncomingTitle:             "EMPAREJAR DESDE EL TELÉFONO",
+	IncomingInstruction:       "En el teléfono, abre los ajustes de Bluetooth y elige %s",
+	IncomingCountdown:         "Visible durante %s",
+	IncomingCancel:            "Esc para dejar de esperar",
+	IncomingStarting:          "Haciendo visible el adaptador...",
+	IncomingPaired:            "Emparejado con %s",
+	IncomingPairedTrusted:     "Emparejado con %s y marcado como confiable",
+	IncomingTimeout:           "Ningún dispositivo se emparejó a tiempo; ajustes del adaptador restaurados",
+	IncomingCancelled:         "Espera cancelada; ajustes del adaptador restaurados",
+	IncomingNotPowered:        "Enciende primero el adaptador",
+	AuthorizeService:          "%s quiere usar %s",
+	AuthorizePairing:          "%s quiere emparejarse sin código",
+	AuthorizeInstruction:      "Permite solo dispositivos que reconozcas",
+	AuthorizeConfirm:          "Presiona Enter/Y para permitir, o Esc/N para denegar",
+	AuthorizeAllowed:          "%s puede usar %s",
+	AuthorizeDenied:           "%s no puede usar %s",
+	AuthorizationPolicyDenied: "La política de autorización deniega a %s el uso de %s",
+	AuthorizePairingService:   "el pairing",
 
 	// Help
-	HelpNavigation:     "↑↓, kj: navegar | enter: conectar/desconectar | d/x: olvidar | q: salir",
+	HelpNavigation:     "↑↓, kj: navegar | enter: conectar/desconectar | d/x: olvidar | z: cancelar | o: autoconexión | i: detalles | q: salir",
 	HelpActions:        "↑↓, kj: navegar | enter: desconectar | d/x: olvidar",
-	HelpAdapterControl: "s: escaneo | p: encendido | v: descubrible | b: pairable | l: idioma | r: refrescar",
+	HelpAdapterControl: "s: escaneo | p: encendido | v: descubrible | b: pairable | w: emparejar desde teléfono | a: adaptador | t: transporte | f: filtro RSSI | c: tipo de dispositivo | l: idioma | r: refrescar",
 	HelpScroll:         "RePág/AvPág: página | Ctrl+↑↓, kj: scroll | Inicio/Fin: arriba/abajo | Rueda ratón: scroll",
 	HelpGeneral:        "q: salir",
 	HelpPairing:        "enter: confirmar | n/esc: cancelar | q: salir",
+	HelpInput:          "enter: enviar | esc: cancelar | ctrl+c: salir",
+	HelpPasskeyDisplay: "esc: ocultar | q: salir",
+	HelpIncoming:       "esc: dejar de esperar | q: salir",
+	HelpAuthorization:  "enter/y: permitir | n/esc: denegar | q: salir",
 	HelpCollapsed:      "?: mostrar ayuda | q: salir",
 	HelpExpanded:       "?: ocultar ayuda",
+	HelpDetails:        "i/esc: cerrar detalles | ↑↓, kj: navegar | enter: conectar/desconectar | [/]: perfil | +/-: conectar/desconectar perfil | q: salir",
 
 	// Adapter table
+	AdapterID:           "Adaptador",
 	AdapterName:         "Nombre",
 	AdapterAlias:        "Alias",
 	AdapterPower:        "Energía",
@@ -73,25 +156,54 @@ var spanishTranslations = Translations{
 	AdapterDiscoverable: "Descubrible",
 
 	// Device table
-	DeviceIcon:    "Icono",
-	DeviceName:    "Nombre",
-	DeviceAddress: "Dirección",
-	DeviceRSSI:    "Señal",
-	DeviceBattery: "Batería",
-	DeviceStatus:  "Estado",
+	DeviceIcon:            "Icono",
+	DeviceName:            "Nombre",
+	DeviceAddress:         "Dirección",
+	DeviceRSSI:            "Señal",
+	DeviceBattery:         "Batería",
+	DeviceStatus:          "Estado",
+	DetailsIdentity:       "Identidad",
+	DetailsState:          "Estado",
+	DetailsClass:          "Clase de dispositivo",
+	DetailsServices:       "Servicios",
+	DetailsProfiles:       "Perfiles",
+	DetailsAdvertising:    "Datos de anuncio",
+	DetailsMajorClass:     "Clase principal",
+	DetailsMinorClass:     "Subclase",
+	DetailsServiceClasses: "Clases de servicio",
+	DetailsUnknownService: "Servicio desconocido",
+	DetailsManufacturer:   "Fabricante",
+	DetailsType:           "Tipo",
+	UnnamedVendor:         "Sin nombre (%s)",
+	DetailsVendor:         "Proveedor",
+	TypeFilterSet:         "Tipo de dispositivo: %s",
+	CategoryAll:           "Todos",
+	CategoryAudio:         "Audio",
+	CategoryInput:         "Entrada",
+	CategoryPhone:         "Teléfonos",
+	CategoryComputer:      "Computadoras",
+	CategoryWearable:      "Vestibles",
+	CategoryHealth:        "Salud",
+	CategoryImaging:       "Imagen y video",
+	CategoryOther:         "Otros",
+	DetailsYes:            "sí",
+	DetailsNo:             "no",
 
 	// Status
 	StatusOn:  "ON",
 	StatusOff: "OFF",
 
 	// Badges
-	BadgePaired:    "PAREADO",
-	BadgeConnected: "CONECTADO",
-	BadgeTrusted:   "Confiable",
+	BadgePaired:        "PAREADO",
+	BadgeConnected:     "CONECTADO",
+	BadgeTrusted:       "Confiable",
+	BadgeAutoReconnect: "Auto",
 
 	// Error messages
 	ErrorDBusConnection:         "No se pudo conectar a DBus",
 	ErrorAdapterNotFound:        "No se encontró adaptador Bluetooth",
+	ErrorAdapterUnknown:         "No se encontró el adaptador Bluetooth %s",
+	ErrorSelectAdapter:          "Error al cambiar de adaptador",
 	ErrorStartDiscovery:         "No se pudo iniciar descubrimiento",
 	ErrorStopDiscovery:          "No se pudo detener descubrimiento",
 	ErrorRemoveDevice:           "No se pudo eliminar dispositivo",
@@ -99,6 +211,32 @@ var spanishTranslations = Translations{
 	ErrorTrustDevice:            "Error al confiar en dispositivo",
 	ErrorConnectDevice:          "Error al conectar dispositivo",
 	ErrorDisconnectDevice:       "Error al desconectar dispositivo",
+	ErrorCancelPairing:          "Error al cancelar el pairing",
+	ErrorConnectProfile:         "No se pudo conectar el perfil",
+	ErrorDisconnectProfile:      "No se pudo desconectar el perfil",
+	ErrorUnknownProfile:         "Perfil desconocido %q (conocidos: %s)",
+	ErrorDeviceNotFound:         "Dispositivo %s no encontrado",
+	ErrorCallTimeout:            "sin respuesta tras %s",
+	BluezAuthenticationFailed:   "El dispositivo no aceptó el PIN o el passkey",
+	HintAuthenticationFailed:    "Comprueba el código que muestran ambos dispositivos y vuelve a hacer el pairing",
+	BluezAlreadyConnected:       "El dispositivo ya está conectado",
+	HintAlreadyConnected:        "Desconéctalo primero para volver a conectar",
+	BluezAlreadyPaired:          "El dispositivo ya tiene pairing",
+	HintAlreadyPaired:           "Conéctate a él, u olvídalo (d) para repetir el pairing",
+	BluezInProgress:             "Otra operación con el dispositivo sigue en curso",
+	HintInProgress:              "Espera a que termine o cancélala (z)",
+	BluezNotReady:               "El adaptador no está listo",
+	HintNotReady:                "Enciende el adaptador (p) y comprueba que rfkill no lo bloquea",
+	BluezPageTimeout:            "El dispositivo no respondió",
+	HintPageTimeout:             "Enciéndelo, acércalo y ponlo en modo pairing si no lo tiene",
+	BluezProfileUnavailable:     "Ningún perfil que ofrece el dispositivo se puede usar aquí",
+	HintProfileUnavailable:      "Para dispositivos de audio, comprueba que el soporte Bluetooth de PipeWire o PulseAudio está en marcha",
+	BluezKeyMissing:             "El dispositivo ya no conoce este pairing",
+	HintKeyMissing:              "Olvida el dispositivo (d) y vuelve a hacer el pairing",
+	BluezNotAuthorized:          "La política del sistema denegó la petición",
+	HintNotAuthorized:           "Ejecuta blugo con un usuario autorizado a gestionar Bluetooth, o revisa las reglas de polkit",
+	BluezDeviceGone:             "BlueZ ya no conoce el dispositivo",
+	HintDeviceGone:              "Actualiza la lista (r) y vuelve a buscarlo",
 	ErrorGetDevices:             "Error al obtener dispositivos",
 	ErrorGetAdapterInfo:         "Error al obtener info del adaptador",
 	ErrorSetAdapterPowered:      "Error al cambiar estado del adaptador",
@@ -107,6 +245,13 @@ var spanishTranslations = Translations{
 	ErrorSetAdapterAlias:        "Error al cambiar alias del adaptador",
 	ErrorForgetDevice:           "Error al olvidar dispositivo",
 	ErrorChangeProperty:         "Error al cambiar",
+	ErrorWatchSignals:           "No se pudo suscribir a las señales de BlueZ",
+	ErrorDecodeProperty:         "Propiedad %s: no se puede decodificar %q como %s",
+	ErrorSetDiscoveryFilter:     "Error al aplicar el filtro de descubrimiento",
+	ErrorFilterTransport:        "Transporte de descubrimiento inválido %q (usa auto, bredr o le)",
+	ErrorFilterRSSI:             "RSSI de descubrimiento inválido %d dBm (usa -127 a 20, 0 = desactivado)",
+	ErrorFilterPathloss:         "Pathloss de descubrimiento inválido %d dB (usa 0 a 255)",
+	ErrorFilterRSSIPathloss:     "No se pueden combinar RSSI y pathloss en el descubrimiento",
 
 	// Status messages
 	StatusConfirmingPairing:  "Confirmando pairing...",
@@ -117,10 +262,13 @@ var spanishTranslations = Translations{
 	WarningAgentRegistrationDetail: "   La app funcionará pero algunos dispositivos pueden requerir pairing manual.",
 
 	// Agent errors (internal)
-	ErrorRequestPasskey:   "No se puede solicitar passkey en TUI",
-	ErrorPairingCancelled: "Pairing cancelado por el usuario",
-	ErrorConfirmRejected:  "Confirmación rechazada",
-	ErrorExportAgent:      "No se pudo exportar agente",
-	ErrorExportIntrospect: "No se pudo exportar introspección",
-	ErrorRegisterAgent:    "No se pudo registrar agente",
+	ErrorInvalidPasskey:      "Passkey inválido",
+	ErrorPairingCancelled:    "Pairing cancelado por el usuario",
+	ErrorRequestTimeout:      "Sin respuesta a tiempo",
+	ErrorConfirmRejected:     "Confirmación rechazada",
+	ErrorAuthorizationDenied: "No autorizado",
+	ErrorExportAgent:         "No se pudo exportar agente",
+	ErrorExportIntrospect:    "No se pudo exportar introspección",
+	ErrorRegisterAgent:       "No se pudo registrar agente",
+	ErrorAgentCapability:     "Capacidad de agente desconocida %q",
 }
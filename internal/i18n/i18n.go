@@ -16,25 +16,61 @@ const (
 // Translations contains all translations
 type Translations struct {
 	// App
-	AppTitle     string
-	Scanning     string
-	Paused       string
-	Initializing string
+	AppTitle           string
+	Scanning           string
+	Paused             string
+	AgentStatus        string
+	AgentStatusDefault string
+	AgentMissing       string
+	AgentRegistered    string
+	Initializing       string
 
 	// Sections
 	AvailableDevices string
 	ConnectedDevices string
+	DeviceDetails    string
 	AdapterInfo      string
 
 	// Device info
 	NoDevicesAvailable string
 	NoDevicesConnected string
+	NoDeviceSelected   string
 
 	// Actions
-	Connecting    string
-	Disconnecting string
-	Pairing       string
-	Forgetting    string
+	Connecting             string
+	Disconnecting          string
+	Pairing                string
+	Forgetting             string
+	OpConnecting           string
+	OpPairing              string
+	OpDisconnecting        string
+	OpForgetting           string
+	OpReconnecting         string
+	OpConnectingProfile    string
+	OpDisconnectingProfile string
+	StateDisconnected      string
+	StatePairing           string
+	StateConnecting        string
+	StateResolvingServices string
+	StateConnected         string
+	StateDisconnecting     string
+	OpQueued               string
+	OperationCancelling    string
+	OperationCancelled     string
+	OperationRetrying      string
+	Reconnecting           string
+	Reconnected            string
+	ReconnectFailed        string
+	ReconnectGaveUp        string
+	AutoReconnectOn        string
+	AutoReconnectOnTrusted string
+	AutoReconnectOff       string
+	AutoReconnectNotPaired string
+	ConnectingProfile      string
+	DisconnectingProfile   string
+	ProfileConnected       string
+	ProfileDisconnected    string
+	NoProfiles             string
 
 	// Adapter
 	AdapterPoweringOn        string
@@ -47,8 +83,18 @@ type Translations struct {
 	DiscoverableDeactivating string
 	PairableOn               string
 	PairableOff              string
+	DiscoveryFilterSet       string
+	DiscoveryFilterNone      string
+	FilterUUIDs              string
+	FilterPattern            string
 	PairableActivating       string
 	PairableDeactivating     string
+	AdapterSwitching         string
+	AdapterSwitched          string
+	AdapterRemovedMsg        string
+	NoAdapter                string
+	BluezUnavailable         string
+	BluezRestored            string
 
 	// Status messages
 	ScanEnabled        string
@@ -63,10 +109,41 @@ type Translations struct {
 	ErrorScanToggle string
 
 	// Pairing
-	PairingCode        string
-	PairingInstruction string
-	PairingConfirm     string
-	PairingCancelled   string
+	PairingCode               string
+	PairingPinCode            string
+	PairingDevice             string
+	PairingInstruction        string
+	PairingConfirm            string
+	PairingCancelled          string
+	PinPrompt                 string
+	PinInstruction            string
+	PinConfirm                string
+	PinTrying                 string
+	RequestTimeout            string
+	PasskeyPrompt             string
+	PasskeyInstruction        string
+	PasskeyConfirm            string
+	PasskeyProgress           string
+	PasskeyDisplayHint        string
+	PairingCompare            string
+	IncomingTitle             string
+	IncomingInstruction       string
+	IncomingCountdown         string
+	IncomingCancel            string
+	IncomingStarting          string
+	IncomingPaired            string
+	IncomingPairedTrusted     string
+	IncomingTimeout           string
+	IncomingCancelled         string
+	IncomingNotPowered        string
+	AuthorizeService          string
+	AuthorizePairing          string
+	AuthorizeInstruction      string
+	AuthorizeConfirm          string
+	AuthorizeAllowed          string
+	AuthorizeDenied           string
+	AuthorizationPolicyDenied string
+	AuthorizePairingService   string
 
 	// Help
 	HelpNavigation     string
@@ -75,10 +152,16 @@ type Translations struct {
 	HelpScroll         string
 	HelpGeneral        string
 	HelpPairing        string
+	HelpInput          string
+	HelpPasskeyDisplay string
+	HelpIncoming       string
+	HelpAuthorization  string
 	HelpCollapsed      string
 	HelpExpanded       string
+	HelpDetails        string
 
 	// Adapter table
+	AdapterID           string
 	AdapterName         string
 	AdapterAlias        string
 	AdapterPower        string
@@ -86,25 +169,54 @@ type Translations struct {
 	AdapterDiscoverable string
 
 	// Device table
-	DeviceIcon    string
-	DeviceName    string
-	DeviceAddress string
-	DeviceRSSI    string
-	DeviceBattery string
-	DeviceStatus  string
+	DeviceIcon            string
+	DeviceName            string
+	DeviceAddress         string
+	DeviceRSSI            string
+	DeviceBattery         string
+	DeviceStatus          string
+	DetailsIdentity       string
+	DetailsState          string
+	DetailsClass          string
+	DetailsServices       string
+	DetailsProfiles       string
+	DetailsAdvertising    string
+	DetailsMajorClass     string
+	DetailsMinorClass     string
+	DetailsServiceClasses string
+	DetailsUnknownService string
+	DetailsManufacturer   string
+	DetailsType           string
+	UnnamedVendor         string
+	DetailsVendor         string
+	TypeFilterSet         string
+	CategoryAll           string
+	CategoryAudio         string
+	CategoryInput         string
+	CategoryPhone         string
+	CategoryComputer      string
+	CategoryWearable      string
+	CategoryHealth        string
+	CategoryImaging       string
+	CategoryOther         string
+	DetailsYes            string
+	DetailsNo             string
 
 	// Status
 	StatusOn  string
 	StatusOff string
 
 	// Badges
-	BadgePaired    string
-	BadgeConnected string
-	BadgeTrusted   string
+	BadgePaired        string
+	BadgeConnected     string
+	BadgeTrusted       string
+	BadgeAutoReconnect string
 
 	// Error messages
 	ErrorDBusConnection         string
 	ErrorAdapterNotFound        string
+	ErrorAdapterUnknown         string
+	ErrorSelectAdapter          string
 	ErrorStartDiscovery         string
 	ErrorStopDiscovery          string
 	ErrorRemoveDevice           string
@@ -112,6 +224,32 @@ type Translations struct {
 	ErrorTrustDevice            string
 	ErrorConnectDevice          string
 	ErrorDisconnectDevice       string
+	ErrorCancelPairing          string
+	ErrorConnectProfile         string
+	ErrorDisconnectProfile      string
+	ErrorUnknownProfile         string
+	ErrorDeviceNotFound         string
+	ErrorCallTimeout            string
+	BluezAuthenticationFailed   string
+	HintAuthenticationFailed    string
+	BluezAlreadyConnected       string
+	HintAlreadyConnected        string
+	BluezAlreadyPaired          string
+	HintAlreadyPaired           string
+	BluezInProgress             string
+	HintInProgress              string
+	BluezNotReady               string
+	HintNotReady                string
+	BluezPageTimeout            string
+	HintPageTimeout             string
+	BluezProfileUnavailable     string
+	HintProfileUnavailable      string
+	BluezKeyMissing             string
+	HintKeyMissing              string
+	BluezNotAuthorized          string
+	HintNotAuthorized           string
+	BluezDeviceGone             string
+	HintDeviceGone              string
 	ErrorGetDevices             string
 	ErrorGetAdapterInfo         string
 	ErrorSetAdapterPowered      string
@@ -120,6 +258,13 @@ type Translations struct {
 	ErrorSetAdapterAlias        string
 	ErrorForgetDevice           string
 	ErrorChangeProperty         string
+	ErrorWatchSignals           string
+	ErrorDecodeProperty         string
+	ErrorSetDiscoveryFilter     string
+	ErrorFilterTransport        string
+	ErrorFilterRSSI             string
+	ErrorFilterPathloss         string
+	ErrorFilterRSSIPathloss     string
 
 	// Status messages
 	StatusConfirmingPairing  string
@@ -130,12 +275,15 @@ type Translations struct {
 	WarningAgentRegistrationDetail string
 
 	// Agent errors (internal)
-	ErrorRequestPasskey    string
-	ErrorPairingCancelled  string
-	ErrorConfirmRejected   string
-	ErrorExportAgent       string
-	ErrorExportIntrospect  string
-	ErrorRegisterAgent     string
+	ErrorInvalidPasskey      string
+	ErrorPairingCancelled    string
+	ErrorRequestTimeout      string
+	ErrorConfirmRejected     string
+	ErrorAuthorizationDenied string
+	ErrorExportAgent         string
+	ErrorExportIntrospect    string
+	ErrorRegisterAgent       string
+	ErrorAgentCapability     string
 }
 
 var currentLang Language = English // Default language
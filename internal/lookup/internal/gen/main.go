@@ -0,0 +1,359 @@
+// Command gen turns the Bluetooth SIG assigned-numbers YAML files into the
+// Go tables used by the lookup package, and the IEEE MA-L registry into
+// the compressed vendor table embedded next to them.
+//
+// The input files use the layout of the upstream sources (the
+// assigned_numbers directory of https://bitbucket.org/bluetooth-SIG/public
+// and https://standards-oui.ieee.org/oui/oui.csv). With -fetch, the
+// newest files are downloaded into the data directory first. The IEEE
+// registry is not kept in the repository, only the oui.gz built from it,
+// so without a downloaded oui.csv the existing oui.gz is left alone.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// record is one list entry of an assigned-numbers file, e.g. a single
+// "- uuid: 0x180F / name: Battery" item. Nested lists (the appearance
+// subcategories) end up in children.
+type record struct {
+	fields   map[string]string
+	children []record
+}
+
+// Upstream locations of the input files.
+const (
+	sigURL = "https://bitbucket.org/bluetooth-SIG/public/raw/main/assigned_numbers/"
+	ouiURL = "https://standards-oui.ieee.org/oui/oui.csv"
+)
+
+// table describes one generated map.
+type table struct {
+	name     string // Go identifier of the map
+	doc      string // doc comment
+	file     string // source file, relative to the data directory
+	upstream string // source file, relative to the assigned_numbers directory
+	key      string // field holding the numeric key
+	width    int    // hex digits used when printing keys
+}
+
+var tables = []table{
+	{"serviceUUIDs", "GATT service UUIDs (uuids/service_uuids.yaml).", "service_uuids.yaml", "uuids/service_uuids.yaml", "uuid", 4},
+	{"serviceClasses", "SDP service class and profile UUIDs (uuids/service_class.yaml).", "service_class.yaml", "uuids/service_class.yaml", "uuid", 4},
+	{"memberUUIDs", "16-bit UUIDs assigned to SIG members (uuids/member_uuids.yaml).", "member_uuids.yaml", "uuids/member_uuids.yaml", "uuid", 4},
+	{"characteristicUUIDs", "GATT characteristic UUIDs (uuids/characteristic_uuids.yaml).", "characteristic_uuids.yaml", "uuids/characteristic_uuids.yaml", "uuid", 4},
+	{"companyIdentifiers", "Company identifiers (company_identifiers/company_identifiers.yaml).", "company_identifiers.yaml", "company_identifiers/company_identifiers.yaml", "value", 4},
+}
+
+func main() {
+	dataDir := flag.String("data", "data", "directory holding the assigned-numbers YAML and OUI CSV files")
+	output := flag.String("out", "tables.go", "generated Go file")
+	ouiOutput := flag.String("oui", "oui.gz", "generated compressed OUI table")
+	download := flag.Bool("fetch", false, "download the newest upstream files into the data directory first")
+	flag.Parse()
+
+	if *download {
+		if err := fetch(http.DefaultClient, sigURL, ouiURL, *dataDir); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	ouiInput := filepath.Join(*dataDir, "oui.csv")
+	if _, err := os.Stat(ouiInput); err == nil {
+		if err := writeOUI(ouiInput, *ouiOutput); err != nil {
+			log.Fatal(err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by internal/gen from the Bluetooth SIG assigned numbers; DO NOT EDIT.\n\n")
+	buf.WriteString("package lookup\n")
+
+	for _, t := range tables {
+		records, err := parseFile(filepath.Join(*dataDir, t.file))
+		if err != nil {
+			log.Fatal(err)
+		}
+		entries, err := flatten(records, t.key)
+		if err != nil {
+			log.Fatalf("%s: %v", t.file, err)
+		}
+		writeMap(&buf, t.name, t.doc, t.width, entries)
+	}
+
+	categories, subcategories, err := appearances(filepath.Join(*dataDir, "appearance_values.yaml"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	writeMap(&buf, "appearanceCategories", "GAP appearance categories, keyed by the category number (core/appearance_values.yaml).", 3, categories)
+	writeMap(&buf, "appearanceSubcategories", "GAP appearance subcategories, keyed by the full 16-bit appearance value.", 4, subcategories)
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+	if err := os.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// fetch downloads the assigned-numbers files from the directory at sigBase
+// and the IEEE registry from oui into dir.
+func fetch(client *http.Client, sigBase, oui, dir string) error {
+	files := map[string]string{
+		"appearance_values.yaml": sigBase + "core/appearance_values.yaml",
+		"oui.csv":                oui,
+	}
+	for _, t := range tables {
+		files[t.file] = sigBase + t.upstream
+	}
+	for file, url := range files {
+		if err := downloadFile(client, url, filepath.Join(dir, file)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// downloadFile writes the body of url to path, leaving path untouched
+// when the download fails.
+func downloadFile(client *http.Client, url, path string) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", url, err)
+	}
+	return os.WriteFile(path, body, 0644)
+}
+
+// flatten maps every record's key field to its name.
+func flatten(records []record, key string) (map[uint64]string, error) {
+	entries := make(map[uint64]string, len(records))
+	for _, r := range records {
+		value, err := parseNumber(r.fields[key])
+		if err != nil {
+			return nil, err
+		}
+		entries[value] = r.fields["name"]
+	}
+	return entries, nil
+}
+
+// appearances splits the appearance file into category names and
+// subcategory names. Appearance values pack the category into the upper
+// ten bits and the subcategory into the lower six.
+func appearances(path string) (map[uint64]string, map[uint64]string, error) {
+	records, err := parseFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	categories := make(map[uint64]string)
+	subcategories := make(map[uint64]string)
+	for _, r := range records {
+		category, err := parseNumber(r.fields["category"])
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+		categories[category] = r.fields["name"]
+		for _, sub := range r.children {
+			value, err := parseNumber(sub.fields["value"])
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", path, err)
+			}
+			subcategories[category<<6|value] = sub.fields["name"]
+		}
+	}
+	return categories, subcategories, nil
+}
+
+func parseNumber(s string) (uint64, error) {
+	value, err := strconv.ParseUint(s, 0, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return value, nil
+}
+
+func writeMap(buf *bytes.Buffer, name, doc string, width int, entries map[uint64]string) {
+	keys := make([]uint64, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	fmt.Fprintf(buf, "\n// %s holds the %s\n", name, doc)
+	fmt.Fprintf(buf, "var %s = map[uint16]string{\n", name)
+	for _, k := range keys {
+		fmt.Fprintf(buf, "0x%0*X: %q,\n", width, k, entries[k])
+	}
+	buf.WriteString("}\n")
+}
+
+// writeOUI converts the IEEE MA-L registry CSV into a gzip compressed list
+// of "aabbcc<TAB>Organization" lines sorted by prefix. Prefixes use the
+// lowercase form produced by models.NormalizeMAC.
+func writeOUI(path, output string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	vendors, err := parseOUI(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	prefixes := make([]string, 0, len(vendors))
+	for prefix := range vendors {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	for _, prefix := range prefixes {
+		fmt.Fprintf(zw, "%s\t%s\n", prefix, vendors[prefix])
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(output, buf.Bytes(), 0644)
+}
+
+// parseOUI reads the IEEE registry CSV (Registry, Assignment,
+// Organization Name, Organization Address) and keeps the MA-L rows.
+func parseOUI(r io.Reader) (map[string]string, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	vendors := make(map[string]string, len(rows))
+	for i, row := range rows {
+		if i == 0 || len(row) < 3 || row[0] != "MA-L" {
+			continue // header or a registry with longer prefixes
+		}
+		prefix := strings.ToLower(row[1])
+		if len(prefix) != 6 {
+			return nil, fmt.Errorf("row %d: invalid assignment %q", i+1, row[1])
+		}
+		name := strings.Join(strings.Fields(row[2]), " ")
+		if name != "" {
+			vendors[prefix] = name
+		}
+	}
+	return vendors, nil
+}
+
+func parseFile(path string) ([]record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records, err := parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return records, nil
+}
+
+// parse reads the small YAML subset used by the assigned-numbers files: a
+// single top-level key holding a list of flat mappings, where a mapping
+// may contain one nested list of flat mappings. It avoids pulling a full
+// YAML implementation into the module for a build-time tool.
+func parse(r io.Reader) ([]record, error) {
+	var (
+		records   []record
+		listDepth = -1 // indentation of the top-level "- " items
+	)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(text) - len(strings.TrimLeft(text, " "))
+
+		item := strings.HasPrefix(trimmed, "- ")
+		if item {
+			trimmed = strings.TrimSpace(trimmed[2:])
+			if listDepth < 0 {
+				listDepth = indent
+			}
+			switch {
+			case indent == listDepth:
+				records = append(records, record{fields: map[string]string{}})
+			case indent > listDepth && len(records) > 0:
+				parent := &records[len(records)-1]
+				parent.children = append(parent.children, record{fields: map[string]string{}})
+			default:
+				return nil, fmt.Errorf("line %d: unexpected list item", line)
+			}
+			indent += 2 // the item's first key sits after the dash
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key: value", line)
+		}
+		value = unquote(strings.TrimSpace(value))
+		if value == "" || listDepth < 0 {
+			// Top-level key or the header of a nested list
+			continue
+		}
+
+		current := &records[len(records)-1]
+		if indent > listDepth+2 && len(current.children) > 0 {
+			current = &current.children[len(current.children)-1]
+		}
+		current.fields[strings.TrimSpace(key)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// unquote strips YAML single or double quotes from a scalar.
+func unquote(s string) string {
+	if len(s) >= 2 {
+		switch {
+		case s[0] == '\'' && s[len(s)-1] == '\'':
+			return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+		case s[0] == '"' && s[len(s)-1] == '"':
+			if u, err := strconv.Unquote(s); err == nil {
+				return u
+			}
+		}
+	}
+	return s
+}
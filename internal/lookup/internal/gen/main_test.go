@@ -0,0 +1,127 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	input := `appearance_values:
+  # comment
+  - category: 0x003
+    name: Watch
+    subcategory:
+      - value: 0x01
+        name: Sports Watch
+      - value: 0x02
+        name: 'Smart ''watch'''
+  - category: 0x004
+    name: "Clock"
+`
+	records, err := parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parse() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("parse() returned %d records, want 2", len(records))
+	}
+
+	watch := records[0]
+	if watch.fields["category"] != "0x003" || watch.fields["name"] != "Watch" {
+		t.Errorf("first record = %v", watch.fields)
+	}
+	if len(watch.children) != 2 {
+		t.Fatalf("first record has %d children, want 2", len(watch.children))
+	}
+	if got := watch.children[1].fields["name"]; got != "Smart 'watch'" {
+		t.Errorf("child name = %q, want %q", got, "Smart 'watch'")
+	}
+	if got := records[1].fields["name"]; got != "Clock" {
+		t.Errorf("second record name = %q, want Clock", got)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"missing colon", "uuids:\n  - uuid 0x1800\n"},
+		{"item outside the list", "uuids:\n    - uuid: 0x1800\n  - uuid: 0x1801\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parse(strings.NewReader(tt.input)); err == nil {
+				t.Error("parse() should fail")
+			}
+		})
+	}
+}
+
+func TestParseOUI(t *testing.T) {
+	input := `Registry,Assignment,Organization Name,Organization Address
+MA-L,0452C7,Bose Corporation,The Mountain Framingham MA US 01701-9168
+MA-L,00000C,"Cisco Systems,  Inc",170 West Tasman Drive San Jose CA US 95134
+MA-M,70B3D5000,Some Company,Somewhere
+MA-L,ABCDEF,,
+`
+	vendors, err := parseOUI(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseOUI() error = %v", err)
+	}
+
+	want := map[string]string{
+		"0452c7": "Bose Corporation",
+		"00000c": "Cisco Systems, Inc",
+	}
+	if len(vendors) != len(want) {
+		t.Errorf("parseOUI() returned %d vendors, want %d: %v", len(vendors), len(want), vendors)
+	}
+	for prefix, name := range want {
+		if vendors[prefix] != name {
+			t.Errorf("vendors[%q] = %q, want %q", prefix, vendors[prefix], name)
+		}
+	}
+
+	if _, err := parseOUI(strings.NewReader("Registry,Assignment,Organization Name\nMA-L,04:52:C7,Bose\n")); err == nil {
+		t.Error("parseOUI() should reject malformed assignments")
+	}
+}
+
+func TestFetch(t *testing.T) {
+	var missing string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == missing {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("# " + r.URL.Path + "\n"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := fetch(server.Client(), server.URL+"/assigned_numbers/", server.URL+"/oui/oui.csv", dir); err != nil {
+		t.Fatalf("fetch() error = %v", err)
+	}
+	for file, path := range map[string]string{
+		"company_identifiers.yaml": "/assigned_numbers/company_identifiers/company_identifiers.yaml",
+		"appearance_values.yaml":   "/assigned_numbers/core/appearance_values.yaml",
+		"service_class.yaml":       "/assigned_numbers/uuids/service_class.yaml",
+		"oui.csv":                  "/oui/oui.csv",
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil || string(data) != "# "+path+"\n" {
+			t.Errorf("%s = %q (%v), want the body of %s", file, data, err, path)
+		}
+	}
+
+	missing = "/oui/oui.csv"
+	if err := fetch(server.Client(), server.URL+"/assigned_numbers/", server.URL+"/oui/oui.csv", t.TempDir()); err == nil {
+		t.Errorf("fetch() should fail when a file is missing upstream")
+	}
+}
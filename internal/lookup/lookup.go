@@ -0,0 +1,114 @@
+// Package lookup resolves Bluetooth SIG assigned numbers - service and
+// characteristic UUIDs, company identifiers and GAP appearance values -
+// and IEEE OUIs into human readable names.
+//
+// tables.go is generated from the assigned-numbers YAML files in the data
+// directory, and oui.gz from the IEEE oui.csv registry, which is not kept
+// in the repository. oui.gz holds the whole MA-L registry; the YAML files
+// are still a subset of the SIG's. Running the generator with -fetch
+// downloads the newest upstream files and regenerates both.
+package lookup
+
+//go:generate go run ./internal/gen -data data -out tables.go -oui oui.gz
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// baseUUIDSuffix is the tail shared by every UUID derived from the
+// Bluetooth Base UUID (0000xxxx-0000-1000-8000-00805f9b34fb).
+const baseUUIDSuffix = "-0000-1000-8000-00805f9b34fb"
+
+// friendlyNames overrides the SIG names of the classic profiles users
+// recognise by their acronym, and of member UUIDs whose registered name is
+// only the owning company. It is maintained by hand and is not touched by
+// the generator.
+var friendlyNames = map[uint16]string{
+	0x1108: "Headset (HSP)",
+	0x110A: "Audio Source (A2DP)",
+	0x110B: "Audio Sink (A2DP)",
+	0x110C: "Remote Control Target (AVRCP)",
+	0x110E: "Remote Control (AVRCP)",
+	0x110F: "Remote Control Controller (AVRCP)",
+	0x1112: "Headset Audio Gateway (HSP)",
+	0x1115: "PAN User (PANU)",
+	0x1116: "Network Access Point (NAP)",
+	0x111E: "Handsfree (HFP)",
+	0x111F: "Handsfree Audio Gateway (HFP)",
+	0x1124: "Human Interface Device",
+	0x112F: "Phonebook Access Server (PBAP)",
+	0x1132: "Message Access Server (MAP)",
+	0xFE2C: "Google Fast Pair",
+}
+
+// ShortUUID returns the 16-bit assigned number of a UUID built on the
+// Bluetooth Base UUID, e.g. 0x110B for "0000110b-0000-1000-8000-00805f9b34fb".
+func ShortUUID(uuid string) (uint16, bool) {
+	uuid = strings.ToLower(uuid)
+	if len(uuid) != 36 || !strings.HasPrefix(uuid, "0000") || !strings.HasSuffix(uuid, baseUUIDSuffix) {
+		return 0, false
+	}
+	value, err := strconv.ParseUint(uuid[4:8], 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	return uint16(value), true
+}
+
+// FormatUUID renders a UUID in its short "0x110B" form when it is an
+// assigned number and returns it unchanged otherwise.
+func FormatUUID(uuid string) string {
+	if short, ok := ShortUUID(uuid); ok {
+		return fmt.Sprintf("0x%04X", short)
+	}
+	return uuid
+}
+
+// ServiceName returns the name of a service or profile UUID, or an empty
+// string when it is not an assigned number we know about.
+func ServiceName(uuid string) string {
+	short, ok := ShortUUID(uuid)
+	if !ok {
+		return ""
+	}
+	for _, names := range []map[uint16]string{friendlyNames, serviceUUIDs, serviceClasses, memberUUIDs} {
+		if name, ok := names[short]; ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// CharacteristicName returns the name of a GATT characteristic UUID, or
+// an empty string when it is unknown.
+func CharacteristicName(uuid string) string {
+	short, ok := ShortUUID(uuid)
+	if !ok {
+		return ""
+	}
+	return characteristicUUIDs[short]
+}
+
+// CompanyName returns the company registered under a manufacturer
+// identifier, as found in advertised manufacturer data.
+func CompanyName(id uint16) string {
+	return companyIdentifiers[id]
+}
+
+// AppearanceCategory returns the category name of a GAP appearance value,
+// e.g. "Watch" for any kind of watch.
+func AppearanceCategory(appearance uint16) string {
+	return appearanceCategories[appearance>>6]
+}
+
+// AppearanceName returns the most specific name of a GAP appearance
+// value: the subcategory when the SIG defines one ("Smartwatch"),
+// otherwise the category ("Watch").
+func AppearanceName(appearance uint16) string {
+	if name, ok := appearanceSubcategories[appearance]; ok {
+		return name
+	}
+	return AppearanceCategory(appearance)
+}
@@ -0,0 +1,126 @@
+package lookup
+
+import "testing"
+
+func TestServiceName(t *testing.T) {
+	tests := []struct {
+		uuid string
+		want string
+	}{
+		{"0000110b-0000-1000-8000-00805f9b34fb", "Audio Sink (A2DP)"},
+		{"00001124-0000-1000-8000-00805f9b34fb", "Human Interface Device"},
+		{"0000180F-0000-1000-8000-00805F9B34FB", "Battery"},
+		{"00001101-0000-1000-8000-00805f9b34fb", "Serial Port"},
+		{"0000fe2c-0000-1000-8000-00805f9b34fb", "Google Fast Pair"},
+		{"0000ffff-0000-1000-8000-00805f9b34fb", ""},
+		{"12345678-1234-1234-1234-123456789abc", ""},
+		{"110b", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uuid, func(t *testing.T) {
+			if got := ServiceName(tt.uuid); got != tt.want {
+				t.Errorf("ServiceName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCharacteristicName(t *testing.T) {
+	tests := []struct {
+		uuid string
+		want string
+	}{
+		{"00002a19-0000-1000-8000-00805f9b34fb", "Battery Level"},
+		{"00002a29-0000-1000-8000-00805f9b34fb", "Manufacturer Name String"},
+		{"0000180f-0000-1000-8000-00805f9b34fb", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uuid, func(t *testing.T) {
+			if got := CharacteristicName(tt.uuid); got != tt.want {
+				t.Errorf("CharacteristicName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompanyName(t *testing.T) {
+	tests := []struct {
+		id   uint16
+		want string
+	}{
+		{0x004c, "Apple, Inc."},
+		{0x0006, "Microsoft"},
+		{0x009e, "Bose Corporation"},
+		{0xfffe, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := CompanyName(tt.id); got != tt.want {
+				t.Errorf("CompanyName(0x%04x) = %q, want %q", tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppearance(t *testing.T) {
+	tests := []struct {
+		appearance   uint16
+		wantName     string
+		wantCategory string
+	}{
+		{0x0000, "Unknown", "Unknown"},
+		{0x0040, "Phone", "Phone"},
+		{0x00c2, "Smartwatch", "Watch"},
+		{0x00c5, "Watch", "Watch"}, // undefined subcategory falls back
+		{0x03c1, "Keyboard", "Human Interface Device"},
+		{0x0941, "Earbud", "Wearable Audio Device"},
+		{0xffc0, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantName, func(t *testing.T) {
+			if got := AppearanceName(tt.appearance); got != tt.wantName {
+				t.Errorf("AppearanceName(0x%04x) = %q, want %q", tt.appearance, got, tt.wantName)
+			}
+			if got := AppearanceCategory(tt.appearance); got != tt.wantCategory {
+				t.Errorf("AppearanceCategory(0x%04x) = %q, want %q", tt.appearance, got, tt.wantCategory)
+			}
+		})
+	}
+}
+
+func TestVendor(t *testing.T) {
+	tests := []struct {
+		name string
+		mac  string
+		want string
+	}{
+		{"bose prefix", "0452c7", "Bose Corporation"},
+		{"whole normalized address", "0452c7aabbcc", "Bose Corporation"},
+		{"apple", "001b63000000", "Apple, Inc."},
+		{"garmin", "00054f123456", "Garmin International"},
+		{"sennheiser", "001694123456", "Sennheiser Communications A/S"},
+		{"unassigned", "fffffe000000", ""},
+		{"too short", "0452", ""},
+		{"not normalized", "04:52:C7:AA:BB:CC", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Vendor(tt.mac); got != tt.want {
+				t.Errorf("Vendor(%q) = %q, want %q", tt.mac, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVendor_FullRegistry(t *testing.T) {
+	ouiOnce.Do(loadOUI)
+	// A trimmed oui.csv slipping back in would leave most devices unnamed
+	if len(ouiVendors) < 25000 {
+		t.Errorf("OUI registry has %d assignments, want the whole MA-L registry", len(ouiVendors))
+	}
+}
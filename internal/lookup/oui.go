@@ -0,0 +1,53 @@
+package lookup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// ouiData is the gzip compressed IEEE MA-L registry, one
+// "aabbcc<TAB>Organization" line per assignment.
+//
+//go:embed oui.gz
+var ouiData []byte
+
+var (
+	ouiOnce    sync.Once
+	ouiVendors map[string]string
+)
+
+// loadOUI decompresses the registry on first use so programs that never
+// ask for a vendor do not pay for it.
+func loadOUI() {
+	ouiVendors = make(map[string]string)
+
+	zr, err := gzip.NewReader(bytes.NewReader(ouiData))
+	if err != nil {
+		return
+	}
+	defer zr.Close()
+
+	scanner := bufio.NewScanner(zr)
+	for scanner.Scan() {
+		prefix, name, ok := strings.Cut(scanner.Text(), "\t")
+		if ok {
+			ouiVendors[prefix] = name
+		}
+	}
+}
+
+// Vendor returns the organization the IEEE assigned an OUI to. The OUI is
+// given as the first six hex digits of a MAC address in the lowercase,
+// separator-free form of models.NormalizeMAC; longer strings are
+// truncated, so a whole normalized address may be passed.
+func Vendor(mac string) string {
+	if len(mac) < 6 {
+		return ""
+	}
+	ouiOnce.Do(loadOUI)
+	return ouiVendors[mac[:6]]
+}
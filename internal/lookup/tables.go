@@ -0,0 +1,445 @@
+// Code generated by internal/gen from the Bluetooth SIG assigned numbers; DO NOT EDIT.
+
+package lookup
+
+// serviceUUIDs holds the GATT service UUIDs (uuids/service_uuids.yaml).
+var serviceUUIDs = map[uint16]string{
+	0x1800: "Generic Access",
+	0x1801: "Generic Attribute",
+	0x1802: "Immediate Alert",
+	0x1803: "Link Loss",
+	0x1804: "Tx Power",
+	0x1805: "Current Time",
+	0x1806: "Reference Time Update",
+	0x1807: "Next DST Change",
+	0x1808: "Glucose",
+	0x1809: "Health Thermometer",
+	0x180A: "Device Information",
+	0x180D: "Heart Rate",
+	0x180E: "Phone Alert Status",
+	0x180F: "Battery",
+	0x1810: "Blood Pressure",
+	0x1811: "Alert Notification",
+	0x1812: "Human Interface Device",
+	0x1813: "Scan Parameters",
+	0x1814: "Running Speed and Cadence",
+	0x1815: "Automation IO",
+	0x1816: "Cycling Speed and Cadence",
+	0x1818: "Cycling Power",
+	0x1819: "Location and Navigation",
+	0x181A: "Environmental Sensing",
+	0x181B: "Body Composition",
+	0x181C: "User Data",
+	0x181D: "Weight Scale",
+	0x181E: "Bond Management",
+	0x181F: "Continuous Glucose Monitoring",
+	0x1820: "Internet Protocol Support",
+	0x1821: "Indoor Positioning",
+	0x1822: "Pulse Oximeter",
+	0x1823: "HTTP Proxy",
+	0x1824: "Transport Discovery",
+	0x1825: "Object Transfer",
+	0x1826: "Fitness Machine",
+	0x1827: "Mesh Provisioning",
+	0x1828: "Mesh Proxy",
+	0x1829: "Reconnection Configuration",
+	0x183A: "Insulin Delivery",
+	0x183B: "Binary Sensor",
+	0x183C: "Emergency Configuration",
+	0x183D: "Authorization Control",
+	0x183E: "Physical Activity Monitor",
+	0x183F: "Elapsed Time",
+	0x1840: "Generic Health Sensor",
+	0x1843: "Audio Input Control",
+	0x1844: "Volume Control",
+	0x1845: "Volume Offset Control",
+	0x1846: "Coordinated Set Identification",
+	0x1847: "Device Time",
+	0x1848: "Media Control",
+	0x1849: "Generic Media Control",
+	0x184A: "Constant Tone Extension",
+	0x184B: "Telephone Bearer",
+	0x184C: "Generic Telephone Bearer",
+	0x184D: "Microphone Control",
+	0x184E: "Audio Stream Control",
+	0x184F: "Broadcast Audio Scan",
+	0x1850: "Published Audio Capabilities",
+	0x1851: "Basic Audio Announcement",
+	0x1852: "Broadcast Audio Announcement",
+	0x1853: "Common Audio",
+	0x1854: "Hearing Access",
+	0x1855: "Telephony and Media Audio",
+	0x1856: "Public Broadcast Announcement",
+	0x1858: "Gaming Audio",
+}
+
+// serviceClasses holds the SDP service class and profile UUIDs (uuids/service_class.yaml).
+var serviceClasses = map[uint16]string{
+	0x1000: "Service Discovery Server",
+	0x1001: "Browse Group Descriptor",
+	0x1101: "Serial Port",
+	0x1102: "LAN Access Using PPP",
+	0x1103: "Dialup Networking",
+	0x1104: "IrMC Sync",
+	0x1105: "OBEX Object Push",
+	0x1106: "OBEX File Transfer",
+	0x1107: "IrMC Sync Command",
+	0x1108: "Headset",
+	0x1109: "Cordless Telephony",
+	0x110A: "Audio Source",
+	0x110B: "Audio Sink",
+	0x110C: "A/V Remote Control Target",
+	0x110D: "Advanced Audio Distribution",
+	0x110E: "A/V Remote Control",
+	0x110F: "A/V Remote Control Controller",
+	0x1110: "Intercom",
+	0x1111: "Fax",
+	0x1112: "Headset - Audio Gateway",
+	0x1113: "WAP",
+	0x1114: "WAP Client",
+	0x1115: "PANU",
+	0x1116: "NAP",
+	0x1117: "GN",
+	0x1118: "Direct Printing",
+	0x1119: "Reference Printing",
+	0x111A: "Basic Imaging Profile",
+	0x111B: "Imaging Responder",
+	0x111C: "Imaging Automatic Archive",
+	0x111D: "Imaging Referenced Objects",
+	0x111E: "Handsfree",
+	0x111F: "Handsfree Audio Gateway",
+	0x1120: "Direct Printing Reference Objects Service",
+	0x1121: "Reflected UI",
+	0x1122: "Basic Printing",
+	0x1123: "Printing Status",
+	0x1124: "Human Interface Device Service",
+	0x1125: "Hardcopy Cable Replacement",
+	0x1126: "HCR Print",
+	0x1127: "HCR Scan",
+	0x1128: "Common ISDN Access",
+	0x112D: "SIM Access",
+	0x112E: "Phonebook Access - PCE",
+	0x112F: "Phonebook Access - PSE",
+	0x1130: "Phonebook Access",
+	0x1131: "Headset - HS",
+	0x1132: "Message Access Server",
+	0x1133: "Message Notification Server",
+	0x1134: "Message Access Profile",
+	0x1135: "GNSS",
+	0x1136: "GNSS Server",
+	0x1137: "3D Display",
+	0x1138: "3D Glasses",
+	0x1139: "3D Synchronization",
+	0x113A: "MPS Profile",
+	0x113B: "MPS Class",
+	0x113C: "CTN Access Service",
+	0x113D: "CTN Notification Service",
+	0x113E: "CTN Profile",
+	0x1200: "PnP Information",
+	0x1201: "Generic Networking",
+	0x1202: "Generic File Transfer",
+	0x1203: "Generic Audio",
+	0x1204: "Generic Telephony",
+	0x1205: "UPNP Service",
+	0x1206: "UPNP IP Service",
+	0x1300: "ESDP UPNP IP PAN",
+	0x1301: "ESDP UPNP IP LAP",
+	0x1302: "ESDP UPNP L2CAP",
+	0x1303: "Video Source",
+	0x1304: "Video Sink",
+	0x1305: "Video Distribution",
+	0x1400: "HDP",
+	0x1401: "HDP Source",
+	0x1402: "HDP Sink",
+}
+
+// memberUUIDs holds the 16-bit UUIDs assigned to SIG members (uuids/member_uuids.yaml).
+var memberUUIDs = map[uint16]string{
+	0xFD6F: "Apple, Inc.",
+	0xFE2C: "Google LLC",
+	0xFE59: "Nordic Semiconductor ASA",
+	0xFE9F: "Google LLC",
+	0xFEAA: "Google LLC",
+}
+
+// characteristicUUIDs holds the GATT characteristic UUIDs (uuids/characteristic_uuids.yaml).
+var characteristicUUIDs = map[uint16]string{
+	0x2A00: "Device Name",
+	0x2A01: "Appearance",
+	0x2A02: "Peripheral Privacy Flag",
+	0x2A03: "Reconnection Address",
+	0x2A04: "Peripheral Preferred Connection Parameters",
+	0x2A05: "Service Changed",
+	0x2A06: "Alert Level",
+	0x2A07: "Tx Power Level",
+	0x2A08: "Date Time",
+	0x2A09: "Day of Week",
+	0x2A0A: "Day Date Time",
+	0x2A19: "Battery Level",
+	0x2A1C: "Temperature Measurement",
+	0x2A1D: "Temperature Type",
+	0x2A1E: "Intermediate Temperature",
+	0x2A21: "Measurement Interval",
+	0x2A22: "Boot Keyboard Input Report",
+	0x2A23: "System ID",
+	0x2A24: "Model Number String",
+	0x2A25: "Serial Number String",
+	0x2A26: "Firmware Revision String",
+	0x2A27: "Hardware Revision String",
+	0x2A28: "Software Revision String",
+	0x2A29: "Manufacturer Name String",
+	0x2A2A: "IEEE 11073-20601 Regulatory Certification Data List",
+	0x2A2B: "Current Time",
+	0x2A31: "Scan Refresh",
+	0x2A32: "Boot Keyboard Output Report",
+	0x2A33: "Boot Mouse Input Report",
+	0x2A35: "Blood Pressure Measurement",
+	0x2A37: "Heart Rate Measurement",
+	0x2A38: "Body Sensor Location",
+	0x2A39: "Heart Rate Control Point",
+	0x2A49: "Blood Pressure Feature",
+	0x2A4A: "HID Information",
+	0x2A4B: "Report Map",
+	0x2A4C: "HID Control Point",
+	0x2A4D: "Report",
+	0x2A4E: "Protocol Mode",
+	0x2A4F: "Scan Interval Window",
+	0x2A50: "PnP ID",
+	0x2A53: "RSC Measurement",
+	0x2A54: "RSC Feature",
+	0x2A55: "SC Control Point",
+	0x2A5B: "CSC Measurement",
+	0x2A5C: "CSC Feature",
+	0x2A5D: "Sensor Location",
+	0x2A63: "Cycling Power Measurement",
+	0x2A65: "Cycling Power Feature",
+	0x2A6D: "Pressure",
+	0x2A6E: "Temperature",
+	0x2A6F: "Humidity",
+	0x2A9D: "Weight Measurement",
+	0x2A9E: "Weight Scale Feature",
+	0x2AA6: "Central Address Resolution",
+	0x2AC9: "Resolvable Private Address Only",
+	0x2B29: "Client Supported Features",
+	0x2B2A: "Database Hash",
+	0x2B3A: "Server Supported Features",
+}
+
+// companyIdentifiers holds the Company identifiers (company_identifiers/company_identifiers.yaml).
+var companyIdentifiers = map[uint16]string{
+	0x0000: "Ericsson AB",
+	0x0001: "Nokia Mobile Phones",
+	0x0002: "Intel Corp.",
+	0x0003: "IBM Corp.",
+	0x0004: "Toshiba Corp.",
+	0x0005: "3Com",
+	0x0006: "Microsoft",
+	0x0007: "Lucent",
+	0x0008: "Motorola",
+	0x0009: "Infineon Technologies AG",
+	0x000A: "Qualcomm Technologies International, Ltd. (QTIL)",
+	0x000B: "Silicon Wave",
+	0x000C: "Digianswer A/S",
+	0x000D: "Texas Instruments Inc.",
+	0x000F: "Broadcom Corporation",
+	0x0010: "Mitel Semiconductor",
+	0x0011: "Widcomm, Inc.",
+	0x0012: "Zeevo, Inc.",
+	0x0013: "Atmel Corporation",
+	0x0014: "Mitsubishi Electric Corporation",
+	0x0015: "RTX A/S",
+	0x001D: "Qualcomm",
+	0x001F: "AVM Berlin",
+	0x0022: "NEC Corporation",
+	0x0024: "Alcatel",
+	0x0025: "NXP B.V.",
+	0x0029: "Hitachi Ltd",
+	0x0030: "ST Microelectronics",
+	0x0031: "Synopsys, Inc.",
+	0x0036: "Renesas Electronics Corporation",
+	0x003A: "Panasonic Holdings Corporation",
+	0x003C: "BlackBerry Limited",
+	0x003F: "Bluetooth SIG, Inc",
+	0x0040: "Seiko Epson Corporation",
+	0x0043: "PARROT AUTOMOTIVE SAS",
+	0x0044: "Socket Mobile",
+	0x0045: "Atheros Communications, Inc.",
+	0x0046: "MediaTek, Inc.",
+	0x0047: "Bluegiga",
+	0x0048: "Marvell Technology Group Ltd.",
+	0x004C: "Apple, Inc.",
+	0x0055: "Plantronics, Inc.",
+	0x0056: "Sony Ericsson Mobile Communications",
+	0x0057: "Harman International Industries, Inc.",
+	0x0058: "Vizio, Inc.",
+	0x0059: "Nordic Semiconductor ASA",
+	0x005C: "Belkin International, Inc.",
+	0x005D: "Realtek Semiconductor Corporation",
+	0x0065: "HP, Inc.",
+	0x0067: "GN Audio A/S",
+	0x006B: "Polar Electro OY",
+	0x0070: "Monster, LLC",
+	0x0075: "Samsung Electronics Co. Ltd.",
+	0x0076: "Creative Technology Ltd.",
+	0x0078: "Nike, Inc.",
+	0x0082: "Sennheiser Communications A/S",
+	0x0087: "Garmin International, Inc.",
+	0x0089: "GN Hearing A/S",
+	0x008A: "Jawbone",
+	0x0094: "Airoha Technology Corp.",
+	0x009E: "Bose Corporation",
+	0x009F: "Suunto Oy",
+	0x00A0: "Kensington Computer Products Group",
+	0x00C3: "adidas AG",
+	0x00C4: "LG Electronics",
+	0x00CC: "Beats Electronics",
+	0x00CD: "Microchip Technology Inc.",
+	0x00D2: "Dialog Semiconductor B.V.",
+	0x00D6: "Timex Group USA, Inc.",
+	0x00D7: "Qualcomm Technologies, Inc.",
+	0x00D9: "Voyetra Turtle Beach, Inc.",
+	0x00DF: "Misfit Wearables Corp",
+	0x00E0: "Google",
+	0x0118: "Radius Networks, Inc.",
+	0x012D: "Sony Corporation",
+	0x0131: "Cypress Semiconductor",
+	0x0154: "Pebble Technology",
+	0x0157: "Anhui Huami Information Technology Co., Ltd.",
+	0x015D: "Estimote, Inc.",
+	0x0171: "Amazon.com Services LLC",
+	0x01DA: "Logitech International SA",
+	0x027D: "HUAWEI Technologies Co., Ltd.",
+	0x02E5: "Espressif Systems (Shanghai) Co., Ltd.",
+	0x02FF: "Silicon Laboratories",
+	0x038F: "Xiaomi Inc.",
+	0x0499: "Ruuvi Innovations Ltd.",
+	0x05A7: "Sonos Inc",
+}
+
+// appearanceCategories holds the GAP appearance categories, keyed by the category number (core/appearance_values.yaml).
+var appearanceCategories = map[uint16]string{
+	0x000: "Unknown",
+	0x001: "Phone",
+	0x002: "Computer",
+	0x003: "Watch",
+	0x004: "Clock",
+	0x005: "Display",
+	0x006: "Remote Control",
+	0x007: "Eye-glasses",
+	0x008: "Tag",
+	0x009: "Keyring",
+	0x00A: "Media Player",
+	0x00B: "Barcode Scanner",
+	0x00C: "Thermometer",
+	0x00D: "Heart Rate Sensor",
+	0x00E: "Blood Pressure",
+	0x00F: "Human Interface Device",
+	0x010: "Glucose Meter",
+	0x011: "Running Walking Sensor",
+	0x012: "Cycling",
+	0x013: "Control Device",
+	0x014: "Network Device",
+	0x015: "Sensor",
+	0x016: "Light Fixtures",
+	0x017: "Fan",
+	0x018: "HVAC",
+	0x019: "Air Conditioning",
+	0x01A: "Humidifier",
+	0x01B: "Heating",
+	0x01C: "Access Control",
+	0x01D: "Motorized Device",
+	0x01E: "Power Device",
+	0x01F: "Light Source",
+	0x020: "Window Covering",
+	0x021: "Audio Sink",
+	0x022: "Audio Source",
+	0x023: "Motorized Vehicle",
+	0x024: "Domestic Appliance",
+	0x025: "Wearable Audio Device",
+	0x026: "Aircraft",
+	0x027: "AV Equipment",
+	0x028: "Display Equipment",
+	0x029: "Hearing aid",
+	0x02A: "Gaming",
+	0x02B: "Signage",
+	0x031: "Pulse Oximeter",
+	0x032: "Weight Scale",
+	0x033: "Personal Mobility Device",
+	0x034: "Continuous Glucose Monitor",
+	0x035: "Insulin Pump",
+	0x036: "Medication Delivery",
+	0x037: "Spirometer",
+	0x051: "Outdoor Sports Activity",
+}
+
+// appearanceSubcategories holds the GAP appearance subcategories, keyed by the full 16-bit appearance value.
+var appearanceSubcategories = map[uint16]string{
+	0x0081: "Desktop Workstation",
+	0x0082: "Server-class Computer",
+	0x0083: "Laptop",
+	0x0084: "Handheld PC/PDA (clamshell)",
+	0x0085: "Palm-size PC/PDA",
+	0x0086: "Wearable computer (watch size)",
+	0x0087: "Tablet",
+	0x0088: "Docking Station",
+	0x0089: "All in One",
+	0x008A: "Blade Server",
+	0x008B: "Convertible",
+	0x008C: "Detachable",
+	0x008D: "IoT Gateway",
+	0x008E: "Mini PC",
+	0x008F: "Stick PC",
+	0x00C1: "Sports Watch",
+	0x00C2: "Smartwatch",
+	0x0301: "Ear Thermometer",
+	0x0341: "Heart Rate Belt",
+	0x0381: "Arm Blood Pressure",
+	0x0382: "Wrist Blood Pressure",
+	0x03C1: "Keyboard",
+	0x03C2: "Mouse",
+	0x03C3: "Joystick",
+	0x03C4: "Gamepad",
+	0x03C5: "Digitizer Tablet",
+	0x03C6: "Card Reader",
+	0x03C7: "Digital Pen",
+	0x03C8: "Barcode Scanner",
+	0x03C9: "Touchpad",
+	0x03CA: "Presentation Remote",
+	0x0441: "In-Shoe Running Walking Sensor",
+	0x0442: "On-Shoe Running Walking Sensor",
+	0x0443: "On-Hip Running Walking Sensor",
+	0x0481: "Cycling Computer",
+	0x0482: "Speed Sensor",
+	0x0483: "Cadence Sensor",
+	0x0484: "Power Sensor",
+	0x0485: "Speed and Cadence Sensor",
+	0x0841: "Standalone Speaker",
+	0x0842: "Soundbar",
+	0x0843: "Bookshelf Speaker",
+	0x0844: "Standmounted Speaker",
+	0x0845: "Speakerphone",
+	0x0881: "Microphone",
+	0x0882: "Alarm",
+	0x0883: "Bell",
+	0x0884: "Horn",
+	0x0885: "Broadcasting Device",
+	0x0886: "Service Desk",
+	0x0887: "Kiosk",
+	0x0888: "Broadcasting Room",
+	0x0889: "Auditorium",
+	0x0941: "Earbud",
+	0x0942: "Headset",
+	0x0943: "Headphones",
+	0x0944: "Neck Band",
+	0x0A41: "In-ear hearing aid",
+	0x0A42: "Behind-ear hearing aid",
+	0x0A43: "Cochlear Implant",
+	0x0A81: "Home Video Game Console",
+	0x0A82: "Portable handheld console",
+	0x0C41: "Fingertip Pulse Oximeter",
+	0x0C42: "Wrist Worn Pulse Oximeter",
+	0x1441: "Location Display",
+	0x1442: "Location and Navigation Display",
+	0x1443: "Location Pod",
+	0x1444: "Location and Navigation Pod",
+}
@@ -1,17 +1,22 @@
 package models
 
-import "github.com/godbus/dbus/v5"
+import (
+	"path"
+
+	"github.com/godbus/dbus/v5"
+)
 
 // Adapter represents a Bluetooth adapter in the system.
+// Fields tagged with `dbus` are decoded from the org.bluez.Adapter1 interface.
 type Adapter struct {
 	Path         dbus.ObjectPath
-	Address      string
-	Name         string
-	Alias        string
-	Powered      bool
-	Discoverable bool
-	Pairable     bool
-	Discovering  bool
+	Address      string `dbus:"Address"`
+	Name         string `dbus:"Name"`
+	Alias        string `dbus:"Alias"`
+	Powered      bool   `dbus:"Powered"`
+	Discoverable bool   `dbus:"Discoverable"`
+	Pairable     bool   `dbus:"Pairable"`
+	Discovering  bool   `dbus:"Discovering"`
 }
 
 // GetDisplayName returns the display name of the adapter.
@@ -25,6 +30,14 @@ func (a *Adapter) GetDisplayName() string {
 	return a.Address
 }
 
+// ID returns the short adapter name taken from its object path (e.g. "hci0").
+func (a *Adapter) ID() string {
+	if a.Path == "" {
+		return ""
+	}
+	return path.Base(string(a.Path))
+}
+
 // GetStatusIcon returns the icon based on adapter status.
 func (a *Adapter) GetStatusIcon() string {
 	if !a.Powered {
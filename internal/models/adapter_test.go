@@ -195,3 +195,24 @@ func TestAdapter_Integration(t *testing.T) {
 		t.Errorf("GetStatusIcon() failed for powered on test, got %v, want 🔵", adapter.GetStatusIcon())
 	}
 }
+
+func TestAdapter_ID(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     dbus.ObjectPath
+		expected string
+	}{
+		{name: "returns hci0 for first adapter", path: "/org/bluez/hci0", expected: "hci0"},
+		{name: "returns hci1 for second adapter", path: "/org/bluez/hci1", expected: "hci1"},
+		{name: "returns empty for empty path", path: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Adapter{Path: tt.path}
+			if got := a.ID(); got != tt.expected {
+				t.Errorf("ID() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package models
+
+import "strings"
+
+// majorClassNames maps the major device class (bits 8-12 of the
+// Class of Device) to its assigned name.
+var majorClassNames = map[uint8]string{
+	0:  "Miscellaneous",
+	1:  "Computer",
+	2:  "Phone",
+	3:  "LAN/Network Access Point",
+	4:  "Audio/Video",
+	5:  "Peripheral",
+	6:  "Imaging",
+	7:  "Wearable",
+	8:  "Toy",
+	9:  "Health",
+	31: "Uncategorized",
+}
+
+// minorClassNames maps the minor device class to its assigned name for
+// the major classes that use a plain enumeration.
+var minorClassNames = map[uint8]map[uint8]string{
+	1: { // Computer
+		0: "Uncategorized",
+		1: "Desktop Workstation",
+		2: "Server-class Computer",
+		3: "Laptop",
+		4: "Handheld PC/PDA",
+		5: "Palm-size PC/PDA",
+		6: "Wearable Computer",
+		7: "Tablet",
+	},
+	2: { // Phone
+		0: "Uncategorized",
+		1: "Cellular",
+		2: "Cordless",
+		3: "Smartphone",
+		4: "Wired Modem or Voice Gateway",
+		5: "Common ISDN Access",
+	},
+	4: { // Audio/Video
+		0:  "Uncategorized",
+		1:  "Wearable Headset",
+		2:  "Hands-free Device",
+		4:  "Microphone",
+		5:  "Loudspeaker",
+		6:  "Headphones",
+		7:  "Portable Audio",
+		8:  "Car Audio",
+		9:  "Set-top Box",
+		10: "HiFi Audio Device",
+		11: "VCR",
+		12: "Video Camera",
+		13: "Camcorder",
+		14: "Video Monitor",
+		15: "Video Display and Loudspeaker",
+		16: "Video Conferencing",
+		18: "Gaming/Toy",
+	},
+	7: { // Wearable
+		1: "Wristwatch",
+		2: "Pager",
+		3: "Jacket",
+		4: "Helmet",
+		5: "Glasses",
+		6: "Pin",
+	},
+	8: { // Toy
+		1: "Robot",
+		2: "Vehicle",
+		3: "Doll/Action Figure",
+		4: "Controller",
+		5: "Game",
+	},
+	9: { // Health
+		1:  "Blood Pressure Monitor",
+		2:  "Thermometer",
+		3:  "Weighing Scale",
+		4:  "Glucose Meter",
+		5:  "Pulse Oximeter",
+		6:  "Heart/Pulse Rate Monitor",
+		7:  "Health Data Display",
+		8:  "Step Counter",
+		9:  "Body Composition Analyzer",
+		10: "Peak Flow Monitor",
+		11: "Medication Monitor",
+		12: "Knee Prosthesis",
+		13: "Ankle Prosthesis",
+		14: "Generic Health Manager",
+		15: "Personal Mobility Device",
+	},
+}
+
+// peripheralInputNames and peripheralTypeNames name the two fields of a
+// Peripheral minor class: the keyboard/pointing bits (6-7) and the device
+// type bits (2-5).
+var (
+	peripheralInputNames = map[uint8]string{
+		1: "Keyboard",
+		2: "Pointing Device",
+		3: "Combo Keyboard/Pointing Device",
+	}
+	peripheralTypeNames = map[uint8]string{
+		1: "Joystick",
+		2: "Gamepad",
+		3: "Remote Control",
+		4: "Sensing Device",
+		5: "Digitizer Tablet",
+		6: "Card Reader",
+		7: "Digital Pen",
+		8: "Handheld Scanner",
+		9: "Handheld Gestural Input Device",
+	}
+)
+
+// imagingNames names the Imaging minor class flags (bits 4-7), keyed by
+// their position in the minor class.
+var imagingNames = []struct {
+	bit  uint
+	name string
+}{
+	{2, "Display"},
+	{3, "Camera"},
+	{4, "Scanner"},
+	{5, "Printer"},
+}
+
+// serviceClassNames lists the service class bits (13-23) in bit order.
+var serviceClassNames = []struct {
+	bit  uint
+	name string
+}{
+	{13, "Limited Discoverable Mode"},
+	{14, "LE Audio"},
+	{16, "Positioning"},
+	{17, "Networking"},
+	{18, "Rendering"},
+	{19, "Capturing"},
+	{20, "Object Transfer"},
+	{21, "Audio"},
+	{22, "Telephony"},
+	{23, "Information"},
+}
+
+// MajorClass returns the major device class (bits 8-12 of Class).
+func (d *Device) MajorClass() uint8 {
+	return uint8((d.Class >> 8) & 0x1F)
+}
+
+// MinorClass returns the minor device class (bits 2-7 of Class).
+func (d *Device) MinorClass() uint8 {
+	return uint8((d.Class >> 2) & 0x3F)
+}
+
+// MajorClassName returns the name of the major device class.
+func (d *Device) MajorClassName() string {
+	if name, ok := majorClassNames[d.MajorClass()]; ok {
+		return name
+	}
+	return "Reserved"
+}
+
+// MinorClassName returns the name of the minor device class, or an empty
+// string when it is not assigned for the device's major class.
+func (d *Device) MinorClassName() string {
+	minor := d.MinorClass()
+	var names []string
+
+	switch major := d.MajorClass(); major {
+	case 5: // Peripheral
+		if name, ok := peripheralInputNames[minor>>4]; ok {
+			names = append(names, name)
+		}
+		if name, ok := peripheralTypeNames[minor&0x0F]; ok {
+			names = append(names, name)
+		}
+	case 6: // Imaging
+		for _, flag := range imagingNames {
+			if minor&(1<<flag.bit) != 0 {
+				names = append(names, flag.name)
+			}
+		}
+	default:
+		return minorClassNames[major][minor]
+	}
+	return strings.Join(names, ", ")
+}
+
+// ServiceClasses returns the names of the service class bits set in Class.
+func (d *Device) ServiceClasses() []string {
+	var names []string
+	for _, service := range serviceClassNames {
+		if d.Class&(1<<service.bit) != 0 {
+			names = append(names, service.name)
+		}
+	}
+	return names
+}
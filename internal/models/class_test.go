@@ -0,0 +1,41 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDevice_ClassFields(t *testing.T) {
+	tests := []struct {
+		name         string
+		class        uint32
+		wantMajor    uint8
+		wantMinor    uint8
+		wantName     string
+		wantServices []string
+	}{
+		{"headphones", 0x240418, 4, 6, "Audio/Video", []string{"Rendering", "Audio"}},
+		{"smartphone", 0x5a020c, 2, 3, "Phone", []string{"Networking", "Capturing", "Object Transfer", "Telephony"}},
+		{"keyboard", 0x002540, 5, 16, "Peripheral", []string{"Limited Discoverable Mode"}},
+		{"uncategorized", 0x001f00, 31, 0, "Uncategorized", nil},
+		{"reserved major", 0x000f00, 15, 0, "Reserved", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Device{Class: tt.class}
+			if got := d.MajorClass(); got != tt.wantMajor {
+				t.Errorf("MajorClass() = %d, want %d", got, tt.wantMajor)
+			}
+			if got := d.MinorClass(); got != tt.wantMinor {
+				t.Errorf("MinorClass() = %d, want %d", got, tt.wantMinor)
+			}
+			if got := d.MajorClassName(); got != tt.wantName {
+				t.Errorf("MajorClassName() = %q, want %q", got, tt.wantName)
+			}
+			if got := d.ServiceClasses(); !reflect.DeepEqual(got, tt.wantServices) {
+				t.Errorf("ServiceClasses() = %v, want %v", got, tt.wantServices)
+			}
+		})
+	}
+}
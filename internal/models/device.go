@@ -10,19 +10,36 @@ import (
 )
 
 // Device represents a Bluetooth device.
+// Fields tagged with `dbus` are decoded from the org.bluez.Device1 interface.
 type Device struct {
-	Path      dbus.ObjectPath
-	Address   string
-	Name      string
-	Alias     string
-	Paired    bool
-	Trusted   bool
-	Connected bool
-	RSSI      int16
-	Icon      string
-	Class     uint32
-	Battery   *uint8 // Battery level (0-100), nil if not available
-	LastSeen  time.Time
+	Path             dbus.ObjectPath
+	Adapter          dbus.ObjectPath   `dbus:"Adapter"`
+	Address          string            `dbus:"Address"`
+	AddressType      string            `dbus:"AddressType"` // "public" or "random"
+	Name             string            `dbus:"Name"`
+	Alias            string            `dbus:"Alias"`
+	Paired           bool              `dbus:"Paired"`
+	Bonded           bool              `dbus:"Bonded"`
+	Trusted          bool              `dbus:"Trusted"`
+	Blocked          bool              `dbus:"Blocked"`
+	Connected        bool              `dbus:"Connected"`
+	ServicesResolved *bool             `dbus:"ServicesResolved"` // nil on BlueZ versions without it
+	LegacyPairing    bool              `dbus:"LegacyPairing"`
+	WakeAllowed      bool              `dbus:"WakeAllowed"`
+	RSSI             int16             `dbus:"RSSI"`
+	TxPower          int16             `dbus:"TxPower"`
+	Icon             string            `dbus:"Icon"`
+	Class            uint32            `dbus:"Class"`
+	Appearance       uint16            `dbus:"Appearance"`
+	Modalias         string            `dbus:"Modalias"`
+	UUIDs            []string          `dbus:"UUIDs"`
+	ManufacturerData map[uint16][]byte `dbus:"ManufacturerData"` // Company ID -> payload
+	ServiceData      map[string][]byte `dbus:"ServiceData"`      // Service UUID -> payload
+	AdvertisingData  map[byte][]byte   `dbus:"AdvertisingData"`  // AD type -> payload
+	AdvertisingFlags []byte            `dbus:"AdvertisingFlags"`
+	Battery          *uint8            // Battery level (0-100), nil if not available
+	Transition       ConnectionState   // Pairing, connecting or disconnecting in flight; StateDisconnected when none
+	LastSeen         time.Time
 }
 
 // emoji returns the emoji if ShowEmojis is enabled, otherwise empty string
@@ -102,44 +119,7 @@ func (d *Device) HasRealName() bool {
 
 // GetIcon returns the appropriate icon based on device type.
 func (d *Device) GetIcon() string {
-	// Icons based on device type
-	if d.Icon != "" {
-		switch d.Icon {
-		case "audio-card", "audio-headset", "audio-headphones":
-			return emoji("🎧")
-		case "phone", "smartphone":
-			return emoji("📱")
-		case "computer", "laptop":
-			return emoji("💻")
-		case "input-keyboard":
-			return emoji("⌨️")
-		case "input-mouse":
-			return emoji("🖱️")
-		case "input-gaming":
-			return emoji("🎮")
-		case "camera":
-			return emoji("📷")
-		case "printer":
-			return emoji("🖨️")
-		}
-	}
-
-	// Fallback based on device class
-	majorClass := (d.Class >> 8) & 0x1F
-	switch majorClass {
-	case 1: // Computer
-		return emoji("💻")
-	case 2: // Phone
-		return emoji("📱")
-	case 4: // Audio/Video
-		return emoji("🎧")
-	case 5: // Peripheral (keyboard, mouse, etc)
-		return emoji("⌨️")
-	case 6: // Imaging (printer, camera)
-		return emoji("📷")
-	}
-
-	return emoji("📶")
+	return emoji(d.Type().Kind.Icon())
 }
 
 // GetBatteryInfo returns the battery icon and text.
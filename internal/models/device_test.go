@@ -191,7 +191,7 @@ func TestDevice_GetIcon(t *testing.T) {
 		},
 		{
 			name:     "returns default signal emoji for unknown class",
-			device:   Device{Icon: "", Class: 0x0700}, // majorClass = 7 (unknown)
+			device:   Device{Icon: "", Class: 0x0F00}, // majorClass = 15 (reserved)
 			expected: "📶",
 		},
 		{
@@ -0,0 +1,424 @@
+package models
+
+// DeviceKind is what a device is, as precisely as its Class of Device,
+// GAP appearance and BlueZ icon allow us to tell.
+type DeviceKind int
+
+const (
+	KindUnknown DeviceKind = iota
+	KindComputer
+	KindLaptop
+	KindTablet
+	KindPhone
+	KindNetwork
+	KindAudioVideo // Audio/video device of no more specific kind
+	KindHeadset
+	KindHeadphones
+	KindEarbuds
+	KindSpeaker
+	KindMicrophone
+	KindCarAudio
+	KindDisplay
+	KindPeripheral // Input device of no more specific kind
+	KindKeyboard
+	KindMouse
+	KindJoystick
+	KindGamepad
+	KindRemoteControl
+	KindDrawingTablet
+	KindImaging // Imaging device of no more specific kind
+	KindCamera
+	KindPrinter
+	KindScanner
+	KindWearable // Wearable of no more specific kind
+	KindWatch
+	KindGlasses
+	KindHealth
+	KindHearingAid
+	KindSensor
+	KindTag
+	KindToy
+	KindGameConsole
+)
+
+// deviceKinds holds the name, icon and category of every kind.
+var deviceKinds = map[DeviceKind]struct {
+	name     string
+	icon     string
+	category DeviceCategory
+}{
+	KindUnknown:       {"Unknown", "📶", CategoryOther},
+	KindComputer:      {"Computer", "💻", CategoryComputer},
+	KindLaptop:        {"Laptop", "💻", CategoryComputer},
+	KindTablet:        {"Tablet", "📱", CategoryComputer},
+	KindPhone:         {"Phone", "📱", CategoryPhone},
+	KindNetwork:       {"Network Access Point", "🌐", CategoryOther},
+	KindAudioVideo:    {"Audio/Video", "🎧", CategoryAudio},
+	KindHeadset:       {"Headset", "🎧", CategoryAudio},
+	KindHeadphones:    {"Headphones", "🎧", CategoryAudio},
+	KindEarbuds:       {"Earbuds", "🎧", CategoryAudio},
+	KindSpeaker:       {"Speaker", "🔊", CategoryAudio},
+	KindMicrophone:    {"Microphone", "🎤", CategoryAudio},
+	KindCarAudio:      {"Car Audio", "🚗", CategoryAudio},
+	KindDisplay:       {"Display", "📺", CategoryImaging},
+	KindPeripheral:    {"Peripheral", "⌨️", CategoryInput},
+	KindKeyboard:      {"Keyboard", "⌨️", CategoryInput},
+	KindMouse:         {"Mouse", "🖱️", CategoryInput},
+	KindJoystick:      {"Joystick", "🕹️", CategoryInput},
+	KindGamepad:       {"Gamepad", "🎮", CategoryInput},
+	KindRemoteControl: {"Remote Control", "🎛️", CategoryInput},
+	KindDrawingTablet: {"Drawing Tablet", "✏️", CategoryInput},
+	KindImaging:       {"Imaging", "📷", CategoryImaging},
+	KindCamera:        {"Camera", "📷", CategoryImaging},
+	KindPrinter:       {"Printer", "🖨️", CategoryImaging},
+	KindScanner:       {"Scanner", "📠", CategoryImaging},
+	KindWearable:      {"Wearable", "⌚", CategoryWearable},
+	KindWatch:         {"Watch", "⌚", CategoryWearable},
+	KindGlasses:       {"Glasses", "👓", CategoryWearable},
+	KindHealth:        {"Health", "🩺", CategoryHealth},
+	KindHearingAid:    {"Hearing Aid", "🦻", CategoryHealth},
+	KindSensor:        {"Sensor", "🌡️", CategoryOther},
+	KindTag:           {"Tag", "🏷️", CategoryOther},
+	KindToy:           {"Toy", "🧸", CategoryOther},
+	KindGameConsole:   {"Game Console", "🎮", CategoryOther},
+}
+
+// String returns the English name of the kind.
+func (k DeviceKind) String() string {
+	if kind, ok := deviceKinds[k]; ok {
+		return kind.name
+	}
+	return deviceKinds[KindUnknown].name
+}
+
+// Icon returns the emoji used for the kind, regardless of ShowEmojis.
+func (k DeviceKind) Icon() string {
+	if kind, ok := deviceKinds[k]; ok {
+		return kind.icon
+	}
+	return deviceKinds[KindUnknown].icon
+}
+
+// Category returns the broad category the kind is filtered under.
+func (k DeviceKind) Category() DeviceCategory {
+	if kind, ok := deviceKinds[k]; ok {
+		return kind.category
+	}
+	return CategoryOther
+}
+
+// DeviceCategory groups device kinds for filtering.
+type DeviceCategory int
+
+const (
+	// CategoryAll is not a category of its own: a filter set to it
+	// matches every device.
+	CategoryAll DeviceCategory = iota
+	CategoryAudio
+	CategoryInput
+	CategoryPhone
+	CategoryComputer
+	CategoryWearable
+	CategoryHealth
+	CategoryImaging
+	CategoryOther
+)
+
+// DeviceCategories lists the categories in the order they are cycled
+// through when filtering, starting with CategoryAll.
+var DeviceCategories = []DeviceCategory{
+	CategoryAll,
+	CategoryAudio,
+	CategoryInput,
+	CategoryPhone,
+	CategoryComputer,
+	CategoryWearable,
+	CategoryHealth,
+	CategoryImaging,
+	CategoryOther,
+}
+
+// Matches reports whether a device of the given kind passes a filter set
+// to this category.
+func (c DeviceCategory) Matches(kind DeviceKind) bool {
+	return c == CategoryAll || kind.Category() == c
+}
+
+// DeviceType is the decoded type of a device: its kind plus the Class of
+// Device and appearance fields it was derived from.
+type DeviceType struct {
+	Kind DeviceKind
+
+	// Class of Device breakdown; empty when the device reports no Class
+	MajorClass     string
+	MinorClass     string
+	ServiceClasses []string
+
+	// GAP appearance name; empty when the device advertises none
+	Appearance string
+}
+
+// Category returns the category of the device's kind.
+func (t DeviceType) Category() DeviceCategory {
+	return t.Kind.Category()
+}
+
+// Type decodes the Class of Device, the GAP appearance and the BlueZ icon
+// into the device's type. A specific answer from any source beats a
+// generic one ("Headphones" over "Audio/Video"); between equally specific
+// answers the icon wins, then the class, then the appearance.
+func (d *Device) Type() DeviceType {
+	t := DeviceType{
+		Appearance: d.AppearanceName(),
+	}
+	if d.Class != 0 {
+		t.MajorClass = d.MajorClassName()
+		t.MinorClass = d.MinorClassName()
+		t.ServiceClasses = d.ServiceClasses()
+	}
+
+	candidates := []kindGuess{
+		iconKind(d.Icon),
+		guessKind(classKind(d.Class)),
+		guessKind(appearanceKind(d.Appearance)),
+	}
+	for _, c := range candidates {
+		if c.specific {
+			t.Kind = c.kind
+			return t
+		}
+	}
+	for _, c := range candidates {
+		if c.kind != KindUnknown {
+			t.Kind = c.kind
+			return t
+		}
+	}
+	return t
+}
+
+// kindGuess is the kind one source of information suggests.
+type kindGuess struct {
+	kind     DeviceKind
+	specific bool
+}
+
+// guessKind wraps a decoded kind, treating broad families as unspecific.
+func guessKind(kind DeviceKind) kindGuess {
+	return kindGuess{kind, kind != KindUnknown && !kind.generic()}
+}
+
+// generic reports whether the kind only names a broad family of devices.
+func (k DeviceKind) generic() bool {
+	switch k {
+	case KindAudioVideo, KindPeripheral, KindImaging, KindWearable:
+		return true
+	}
+	return false
+}
+
+// iconKinds maps BlueZ icon names to kinds.
+var iconKinds = map[string]DeviceKind{
+	"computer":          KindComputer,
+	"laptop":            KindLaptop,
+	"phone":             KindPhone,
+	"smartphone":        KindPhone,
+	"modem":             KindPhone,
+	"network-wireless":  KindNetwork,
+	"audio-card":        KindAudioVideo,
+	"multimedia-player": KindAudioVideo,
+	"audio-headset":     KindHeadset,
+	"audio-headphones":  KindHeadphones,
+	"video-display":     KindDisplay,
+	"input-keyboard":    KindKeyboard,
+	"input-mouse":       KindMouse,
+	"input-tablet":      KindDrawingTablet,
+	"camera":            KindCamera,
+	"camera-photo":      KindCamera,
+	"camera-video":      KindCamera,
+	"printer":           KindPrinter,
+	"scanner":           KindScanner,
+}
+
+// iconKind decodes a BlueZ icon name.
+// BlueZ reports gamepads and joysticks alike as "input-gaming", so that
+// icon only yields a gamepad when nothing more precise is known.
+func iconKind(icon string) kindGuess {
+	if icon == "input-gaming" {
+		return kindGuess{KindGamepad, false}
+	}
+	return guessKind(iconKinds[icon])
+}
+
+// classKind decodes the major and minor device class fields.
+func classKind(class uint32) DeviceKind {
+	minor := uint8((class >> 2) & 0x3F)
+
+	switch (class >> 8) & 0x1F {
+	case 1: // Computer
+		switch minor {
+		case 3:
+			return KindLaptop
+		case 4, 5, 7:
+			return KindTablet
+		case 6:
+			return KindWatch
+		}
+		return KindComputer
+	case 2: // Phone
+		return KindPhone
+	case 3: // LAN/Network Access Point
+		return KindNetwork
+	case 4: // Audio/Video
+		switch minor {
+		case 1, 2:
+			return KindHeadset
+		case 4:
+			return KindMicrophone
+		case 5, 7, 10:
+			return KindSpeaker
+		case 6:
+			return KindHeadphones
+		case 8:
+			return KindCarAudio
+		case 9, 14, 15:
+			return KindDisplay
+		case 12, 13:
+			return KindCamera
+		case 18:
+			return KindToy
+		}
+		return KindAudioVideo
+	case 5: // Peripheral: bits 6-7 keyboard/pointing, bits 2-5 device type
+		switch minor & 0x0F {
+		case 1:
+			return KindJoystick
+		case 2:
+			return KindGamepad
+		case 3:
+			return KindRemoteControl
+		case 4:
+			return KindSensor
+		case 5, 7:
+			return KindDrawingTablet
+		case 8:
+			return KindScanner
+		}
+		switch minor >> 4 {
+		case 1, 3:
+			return KindKeyboard
+		case 2:
+			return KindMouse
+		}
+		return KindPeripheral
+	case 6: // Imaging: bits 4-7 are flags, the most specific one wins
+		switch {
+		case minor&0x20 != 0:
+			return KindPrinter
+		case minor&0x10 != 0:
+			return KindScanner
+		case minor&0x08 != 0:
+			return KindCamera
+		case minor&0x04 != 0:
+			return KindDisplay
+		}
+		return KindImaging
+	case 7: // Wearable
+		switch minor {
+		case 1:
+			return KindWatch
+		case 5:
+			return KindGlasses
+		}
+		return KindWearable
+	case 8: // Toy
+		if minor == 4 {
+			return KindGamepad
+		}
+		return KindToy
+	case 9: // Health
+		return KindHealth
+	}
+	return KindUnknown
+}
+
+// appearanceKind decodes a GAP appearance value (category in bits 6-15,
+// subcategory in bits 0-5).
+func appearanceKind(appearance uint16) DeviceKind {
+	sub := appearance & 0x3F
+
+	switch appearance >> 6 {
+	case 0x001:
+		return KindPhone
+	case 0x002: // Computer
+		switch sub {
+		case 0x03, 0x0B, 0x0C:
+			return KindLaptop
+		case 0x04, 0x05, 0x07:
+			return KindTablet
+		case 0x06:
+			return KindWatch
+		}
+		return KindComputer
+	case 0x003:
+		return KindWatch
+	case 0x005, 0x028:
+		return KindDisplay
+	case 0x006:
+		return KindRemoteControl
+	case 0x007:
+		return KindGlasses
+	case 0x008, 0x009:
+		return KindTag
+	case 0x00A, 0x027:
+		return KindAudioVideo
+	case 0x00B:
+		return KindScanner
+	case 0x00C, 0x00D, 0x00E, 0x010, 0x031, 0x032, 0x034, 0x035, 0x036, 0x037:
+		return KindHealth
+	case 0x00F: // Human Interface Device
+		switch sub {
+		case 0x01:
+			return KindKeyboard
+		case 0x02, 0x09:
+			return KindMouse
+		case 0x03:
+			return KindJoystick
+		case 0x04:
+			return KindGamepad
+		case 0x05, 0x07:
+			return KindDrawingTablet
+		case 0x08:
+			return KindScanner
+		case 0x0A:
+			return KindRemoteControl
+		}
+		return KindPeripheral
+	case 0x011, 0x012, 0x015, 0x051:
+		return KindSensor
+	case 0x014:
+		return KindNetwork
+	case 0x021:
+		return KindSpeaker
+	case 0x022:
+		if sub == 0x01 {
+			return KindMicrophone
+		}
+		return KindAudioVideo
+	case 0x025: // Wearable Audio Device
+		switch sub {
+		case 0x01:
+			return KindEarbuds
+		case 0x02:
+			return KindHeadset
+		case 0x03, 0x04:
+			return KindHeadphones
+		}
+		return KindAudioVideo
+	case 0x029:
+		return KindHearingAid
+	case 0x02A:
+		return KindGameConsole
+	}
+	return KindUnknown
+}
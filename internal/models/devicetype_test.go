@@ -0,0 +1,193 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDevice_Type_Kind(t *testing.T) {
+	tests := []struct {
+		name   string
+		device Device
+		want   DeviceKind
+	}{
+		// Class of Device
+		{"desktop", Device{Class: 0x000104}, KindComputer},
+		{"laptop", Device{Class: 0x00010c}, KindLaptop},
+		{"tablet", Device{Class: 0x00011c}, KindTablet},
+		{"smartphone", Device{Class: 0x5a020c}, KindPhone},
+		{"access point", Device{Class: 0x000300}, KindNetwork},
+		{"wearable headset", Device{Class: 0x240404}, KindHeadset},
+		{"hands-free", Device{Class: 0x240408}, KindHeadset},
+		{"headphones", Device{Class: 0x240418}, KindHeadphones},
+		{"loudspeaker", Device{Class: 0x240414}, KindSpeaker},
+		{"hifi", Device{Class: 0x240428}, KindSpeaker},
+		{"microphone", Device{Class: 0x000410}, KindMicrophone},
+		{"car audio", Device{Class: 0x240420}, KindCarAudio},
+		{"video display", Device{Class: 0x00043c}, KindDisplay},
+		{"uncategorized audio/video", Device{Class: 0x000400}, KindAudioVideo},
+		{"keyboard", Device{Class: 0x002540}, KindKeyboard},
+		{"mouse", Device{Class: 0x000580}, KindMouse},
+		{"combo keyboard", Device{Class: 0x0005c0}, KindKeyboard},
+		{"joystick", Device{Class: 0x000504}, KindJoystick},
+		{"gamepad", Device{Class: 0x000508}, KindGamepad},
+		{"remote control", Device{Class: 0x00050c}, KindRemoteControl},
+		{"digitizer", Device{Class: 0x000514}, KindDrawingTablet},
+		{"uncategorized peripheral", Device{Class: 0x000500}, KindPeripheral},
+		{"printer", Device{Class: 0x040680}, KindPrinter},
+		{"scanner", Device{Class: 0x000640}, KindScanner},
+		{"camera", Device{Class: 0x000620}, KindCamera},
+		{"imaging display", Device{Class: 0x000610}, KindDisplay},
+		{"wristwatch", Device{Class: 0x000704}, KindWatch},
+		{"glasses", Device{Class: 0x000714}, KindGlasses},
+		{"pager", Device{Class: 0x000708}, KindWearable},
+		{"toy robot", Device{Class: 0x000804}, KindToy},
+		{"toy controller", Device{Class: 0x000810}, KindGamepad},
+		{"thermometer", Device{Class: 0x000908}, KindHealth},
+		{"uncategorized", Device{Class: 0x001f00}, KindUnknown},
+		{"reserved major", Device{Class: 0x000f00}, KindUnknown},
+
+		// GAP appearance (LE-only devices)
+		{"appearance phone", Device{Appearance: 0x0040}, KindPhone},
+		{"appearance laptop", Device{Appearance: 0x0083}, KindLaptop},
+		{"appearance smartwatch", Device{Appearance: 0x00c2}, KindWatch},
+		{"appearance tag", Device{Appearance: 0x0200}, KindTag},
+		{"appearance heart rate belt", Device{Appearance: 0x0341}, KindHealth},
+		{"appearance keyboard", Device{Appearance: 0x03c1}, KindKeyboard},
+		{"appearance mouse", Device{Appearance: 0x03c2}, KindMouse},
+		{"appearance joystick", Device{Appearance: 0x03c3}, KindJoystick},
+		{"appearance gamepad", Device{Appearance: 0x03c4}, KindGamepad},
+		{"appearance generic HID", Device{Appearance: 0x03c0}, KindPeripheral},
+		{"appearance cycling sensor", Device{Appearance: 0x0482}, KindSensor},
+		{"appearance soundbar", Device{Appearance: 0x0842}, KindSpeaker},
+		{"appearance earbud", Device{Appearance: 0x0941}, KindEarbuds},
+		{"appearance headset", Device{Appearance: 0x0942}, KindHeadset},
+		{"appearance headphones", Device{Appearance: 0x0943}, KindHeadphones},
+		{"appearance hearing aid", Device{Appearance: 0x0a41}, KindHearingAid},
+		{"appearance console", Device{Appearance: 0x0a81}, KindGameConsole},
+		{"appearance unknown", Device{Appearance: 0x0000}, KindUnknown},
+
+		// BlueZ icon
+		{"icon phone", Device{Icon: "phone"}, KindPhone},
+		{"icon headset", Device{Icon: "audio-headset"}, KindHeadset},
+		{"icon audio card", Device{Icon: "audio-card"}, KindAudioVideo},
+		{"icon gaming", Device{Icon: "input-gaming"}, KindGamepad},
+		{"icon unknown", Device{Icon: "something-new"}, KindUnknown},
+
+		// Precedence
+		{"specific icon beats class", Device{Icon: "phone", Class: 0x000100}, KindPhone},
+		{"class beats generic icon", Device{Icon: "audio-card", Class: 0x240414}, KindSpeaker},
+		{"class joystick beats gaming icon", Device{Icon: "input-gaming", Class: 0x000504}, KindJoystick},
+		{"appearance beats generic class", Device{Class: 0x000400, Appearance: 0x0941}, KindEarbuds},
+		{"class beats appearance", Device{Class: 0x240404, Appearance: 0x0943}, KindHeadset},
+		{"generic class beats unknown appearance", Device{Class: 0x000400, Appearance: 0xffc0}, KindAudioVideo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.device.Type().Kind; got != tt.want {
+				t.Errorf("Type().Kind = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDevice_Type_Fields(t *testing.T) {
+	tests := []struct {
+		name   string
+		device Device
+		want   DeviceType
+	}{
+		{
+			name:   "headphones with class",
+			device: Device{Class: 0x240418},
+			want: DeviceType{
+				Kind:           KindHeadphones,
+				MajorClass:     "Audio/Video",
+				MinorClass:     "Headphones",
+				ServiceClasses: []string{"Rendering", "Audio"},
+			},
+		},
+		{
+			name:   "LE-only smartwatch",
+			device: Device{Appearance: 0x00c2},
+			want: DeviceType{
+				Kind:       KindWatch,
+				Appearance: "Smartwatch",
+			},
+		},
+		{
+			name:   "nothing known",
+			device: Device{},
+			want:   DeviceType{Kind: KindUnknown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.device.Type(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Type() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDevice_MinorClassName(t *testing.T) {
+	tests := []struct {
+		name  string
+		class uint32
+		want  string
+	}{
+		{"laptop", 0x00010c, "Laptop"},
+		{"smartphone", 0x5a020c, "Smartphone"},
+		{"loudspeaker", 0x240414, "Loudspeaker"},
+		{"keyboard", 0x002540, "Keyboard"},
+		{"combo with gamepad", 0x0005c8, "Combo Keyboard/Pointing Device, Gamepad"},
+		{"printer and scanner", 0x0006c0, "Scanner, Printer"},
+		{"wristwatch", 0x000704, "Wristwatch"},
+		{"unassigned minor", 0x0004fc, ""},
+		{"network has no names", 0x000300, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Device{Class: tt.class}
+			if got := d.MinorClassName(); got != tt.want {
+				t.Errorf("MinorClassName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceKind_Category(t *testing.T) {
+	tests := []struct {
+		kind DeviceKind
+		want DeviceCategory
+	}{
+		{KindHeadphones, CategoryAudio},
+		{KindSpeaker, CategoryAudio},
+		{KindGamepad, CategoryInput},
+		{KindPhone, CategoryPhone},
+		{KindTablet, CategoryComputer},
+		{KindWatch, CategoryWearable},
+		{KindHearingAid, CategoryHealth},
+		{KindPrinter, CategoryImaging},
+		{KindToy, CategoryOther},
+		{KindUnknown, CategoryOther},
+		{DeviceKind(-1), CategoryOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.String(), func(t *testing.T) {
+			if got := tt.kind.Category(); got != tt.want {
+				t.Errorf("Category() = %v, want %v", got, tt.want)
+			}
+			if !CategoryAll.Matches(tt.kind) {
+				t.Error("CategoryAll should match every kind")
+			}
+			if !tt.want.Matches(tt.kind) {
+				t.Errorf("%v should match its own category", tt.kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// profiles lists the profiles BlueZ can connect one at a time, by the
+// name used on the command line and the 16-bit UUID of the service on the
+// remote device.
+var profiles = []struct {
+	name string
+	uuid uint16
+}{
+	{"a2dp-sink", 0x110b},
+	{"a2dp-source", 0x110a},
+	{"avrcp", 0x110e},
+	{"avrcp-target", 0x110c},
+	{"hfp-hf", 0x111e},
+	{"hfp-ag", 0x111f},
+	{"hsp-hs", 0x1108},
+	{"hsp-ag", 0x1112},
+	{"hid", 0x1124},
+	{"hogp", 0x1812},
+	{"panu", 0x1115},
+	{"pan-nap", 0x1116},
+	{"pan-gn", 0x1117},
+	{"spp", 0x1101},
+}
+
+// fullUUID expands a 16-bit UUID built on the Bluetooth base UUID.
+func fullUUID(short uint16) string {
+	return fmt.Sprintf("0000%04x-0000-1000-8000-00805f9b34fb", short)
+}
+
+// ProfileNames returns the names ProfileUUID accepts.
+func ProfileNames() []string {
+	names := make([]string, len(profiles))
+	for i, profile := range profiles {
+		names[i] = profile.name
+	}
+	return names
+}
+
+// ProfileUUID returns the UUID BlueZ connects for profile, which is a
+// name such as "a2dp-sink", a 16-bit UUID such as "0x110b" or a full UUID.
+func ProfileUUID(profile string) (string, bool) {
+	profile = strings.ToLower(strings.TrimSpace(profile))
+	for _, known := range profiles {
+		if known.name == profile {
+			return fullUUID(known.uuid), true
+		}
+	}
+	if len(profile) == 36 && strings.Count(profile, "-") == 4 {
+		return profile, true
+	}
+	if short, err := strconv.ParseUint(strings.TrimPrefix(profile, "0x"), 16, 16); err == nil {
+		return fullUUID(uint16(short)), true
+	}
+	return "", false
+}
+
+// ProfileName returns the name of the profile connected through uuid, or
+// an empty string when BlueZ cannot connect it on its own.
+func ProfileName(uuid string) string {
+	short, ok := ShortUUID(uuid)
+	if !ok {
+		return ""
+	}
+	for _, profile := range profiles {
+		if profile.uuid == short {
+			return profile.name
+		}
+	}
+	return ""
+}
+
+// ProfileLabel names the profile of uuid for messages: by its name when
+// it has one, otherwise by the UUID.
+func ProfileLabel(uuid string) string {
+	if name := ProfileName(uuid); name != "" {
+		return name
+	}
+	return FormatUUID(uuid)
+}
+
+// Profiles returns the UUIDs of the device's services that can be
+// connected one at a time, in the order BlueZ reports them.
+func (d *Device) Profiles() []string {
+	var uuids []string
+	for _, uuid := range d.UUIDs {
+		if ProfileName(uuid) != "" {
+			uuids = append(uuids, uuid)
+		}
+	}
+	return uuids
+}
@@ -0,0 +1,44 @@
+package models
+
+import "testing"
+
+func TestProfileUUID(t *testing.T) {
+	tests := []struct {
+		profile string
+		want    string
+		wantOK  bool
+	}{
+		{"a2dp-sink", "0000110b-0000-1000-8000-00805f9b34fb", true},
+		{" HID ", "00001124-0000-1000-8000-00805f9b34fb", true},
+		{"0x111E", "0000111e-0000-1000-8000-00805f9b34fb", true},
+		{"1812", "00001812-0000-1000-8000-00805f9b34fb", true},
+		{"0000110A-0000-1000-8000-00805F9B34FB", "0000110a-0000-1000-8000-00805f9b34fb", true},
+		{"a2dp", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			got, ok := ProfileUUID(tt.profile)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ProfileUUID(%q) = %q, %v, want %q, %v", tt.profile, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDevice_Profiles(t *testing.T) {
+	dev := &Device{UUIDs: []string{
+		"0000110b-0000-1000-8000-00805f9b34fb", // Audio Sink
+		"0000180f-0000-1000-8000-00805f9b34fb", // Battery, not a profile
+		"0000111e-0000-1000-8000-00805f9b34fb", // Handsfree
+	}}
+
+	profiles := dev.Profiles()
+	if len(profiles) != 2 || ProfileName(profiles[0]) != "a2dp-sink" || ProfileName(profiles[1]) != "hfp-hf" {
+		t.Errorf("Profiles() = %v, want the audio sink and handsfree", profiles)
+	}
+	if got := ProfileLabel("0000180f-0000-1000-8000-00805f9b34fb"); got != "0x180F" {
+		t.Errorf("ProfileLabel() = %q, want the UUID of a service without a profile name", got)
+	}
+}
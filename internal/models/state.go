@@ -0,0 +1,61 @@
+package models
+
+// ConnectionState is where a device is in its connection lifecycle.
+type ConnectionState int
+
+const (
+	StateDisconnected      ConnectionState = iota
+	StatePairing                           // Pair is in flight
+	StateConnecting                        // Connect is in flight and the link is not up yet
+	StateResolvingServices                 // Connected, but the profiles are still being set up
+	StateConnected                         // Connected with its services resolved
+	StateDisconnecting                     // Disconnect is in flight and the link is still up
+)
+
+// stateNames holds the name of every state, as used in logs.
+var stateNames = map[ConnectionState]string{
+	StateDisconnected:      "disconnected",
+	StatePairing:           "pairing",
+	StateConnecting:        "connecting",
+	StateResolvingServices: "resolving services",
+	StateConnected:         "connected",
+	StateDisconnecting:     "disconnecting",
+}
+
+// String returns the state's name.
+func (s ConnectionState) String() string {
+	if name, ok := stateNames[s]; ok {
+		return name
+	}
+	return stateNames[StateDisconnected]
+}
+
+// Transitional reports whether the device is on its way to another state.
+func (s ConnectionState) Transitional() bool {
+	switch s {
+	case StatePairing, StateConnecting, StateResolvingServices, StateDisconnecting:
+		return true
+	}
+	return false
+}
+
+// State derives the device's connection state from its Device1
+// properties and the operation in flight on it, if any.
+func (d *Device) State() ConnectionState {
+	switch {
+	case d.Transition == StateDisconnecting && d.Connected:
+		return StateDisconnecting
+	case d.Transition == StatePairing && !d.Paired:
+		return StatePairing
+	case d.Connected && d.ServicesResolved != nil && !*d.ServicesResolved:
+		// BlueZ reports the link before the profiles are up, which
+		// takes seconds with some audio devices. Versions without the
+		// property never say, so the device counts as connected there.
+		return StateResolvingServices
+	case d.Connected:
+		return StateConnected
+	case d.Transition == StateConnecting:
+		return StateConnecting
+	}
+	return StateDisconnected
+}
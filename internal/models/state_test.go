@@ -0,0 +1,53 @@
+package models
+
+import "testing"
+
+func TestDevice_State(t *testing.T) {
+	resolved, unresolved := true, false
+	tests := []struct {
+		name string
+		dev  Device
+		want ConnectionState
+	}{
+		{"idle", Device{Paired: true}, StateDisconnected},
+		{"pairing", Device{Transition: StatePairing}, StatePairing},
+		{"paired, about to connect", Device{Paired: true, Transition: StatePairing}, StateDisconnected},
+		{"connecting", Device{Paired: true, Transition: StateConnecting}, StateConnecting},
+		{"link up, profiles pending", Device{Paired: true, Connected: true, ServicesResolved: &unresolved, Transition: StateConnecting}, StateResolvingServices},
+		{"connected", Device{Paired: true, Connected: true, ServicesResolved: &resolved}, StateConnected},
+		{"connected by the device", Device{Paired: true, Connected: true, ServicesResolved: &unresolved}, StateResolvingServices},
+		{"connected, BlueZ without ServicesResolved", Device{Paired: true, Connected: true}, StateConnected},
+		{"disconnecting", Device{Connected: true, ServicesResolved: &resolved, Transition: StateDisconnecting}, StateDisconnecting},
+		{"disconnected, call not returned yet", Device{Transition: StateDisconnecting}, StateDisconnected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dev.State(); got != tt.want {
+				t.Errorf("State() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectionState_Transitional(t *testing.T) {
+	tests := []struct {
+		state ConnectionState
+		want  bool
+	}{
+		{StateDisconnected, false},
+		{StatePairing, true},
+		{StateConnecting, true},
+		{StateResolvingServices, true},
+		{StateConnected, false},
+		{StateDisconnecting, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state.String(), func(t *testing.T) {
+			if got := tt.state.Transitional(); got != tt.want {
+				t.Errorf("Transitional() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
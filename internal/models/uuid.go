@@ -0,0 +1,66 @@
+package models
+
+import "github.com/ivangsm/blugo/internal/lookup"
+
+// ShortUUID returns the 16-bit value of a UUID built on the Bluetooth base
+// UUID, e.g. 0x110b for "0000110b-0000-1000-8000-00805f9b34fb".
+func ShortUUID(uuid string) (uint16, bool) {
+	return lookup.ShortUUID(uuid)
+}
+
+// ServiceName returns a human-readable name for a service UUID, or an
+// empty string when it is not known.
+func ServiceName(uuid string) string {
+	return lookup.ServiceName(uuid)
+}
+
+// FormatUUID returns the compact form of a UUID: "0x110B" for SIG
+// assigned numbers and the full string otherwise.
+func FormatUUID(uuid string) string {
+	return lookup.FormatUUID(uuid)
+}
+
+// CompanyName returns the company registered under a Bluetooth SIG
+// company identifier, or an empty string when it is not known.
+func CompanyName(id uint16) string {
+	return lookup.CompanyName(id)
+}
+
+// ServiceNames returns the names of the known services advertised by the
+// device, in the order BlueZ reports them. Unknown UUIDs are skipped.
+func (d *Device) ServiceNames() []string {
+	var names []string
+	for _, uuid := range d.UUIDs {
+		if name := ServiceName(uuid); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Manufacturer returns the company behind the device's manufacturer data,
+// e.g. "Apple, Inc.", or an empty string when there is none or it is not
+// a registered identifier. With several entries the lowest ID wins so the
+// result is stable.
+func (d *Device) Manufacturer() string {
+	found := false
+	var lowest uint16
+	for id := range d.ManufacturerData {
+		if CompanyName(id) != "" && (!found || id < lowest) {
+			lowest, found = id, true
+		}
+	}
+	if !found {
+		return ""
+	}
+	return CompanyName(lowest)
+}
+
+// AppearanceName returns the GAP appearance of the device, e.g.
+// "Smartwatch", or an empty string when it does not advertise one.
+func (d *Device) AppearanceName() string {
+	if d.Appearance == 0 {
+		return ""
+	}
+	return lookup.AppearanceName(d.Appearance)
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShortUUID(t *testing.T) {
+	tests := []struct {
+		uuid      string
+		wantShort uint16
+		wantOK    bool
+	}{
+		{"0000110b-0000-1000-8000-00805f9b34fb", 0x110b, true},
+		{"0000180F-0000-1000-8000-00805F9B34FB", 0x180f, true},
+		{"0001110b-0000-1000-8000-00805f9b34fb", 0, false},
+		{"12345678-1234-1234-1234-123456789abc", 0, false},
+		{"110b", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uuid, func(t *testing.T) {
+			short, ok := ShortUUID(tt.uuid)
+			if short != tt.wantShort || ok != tt.wantOK {
+				t.Errorf("ShortUUID() = (0x%04x, %v), want (0x%04x, %v)", short, ok, tt.wantShort, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestServiceName(t *testing.T) {
+	tests := []struct {
+		uuid string
+		want string
+	}{
+		{"0000110b-0000-1000-8000-00805f9b34fb", "Audio Sink (A2DP)"},
+		{"00001124-0000-1000-8000-00805f9b34fb", "Human Interface Device"},
+		{"0000ffff-0000-1000-8000-00805f9b34fb", ""},
+		{"12345678-1234-1234-1234-123456789abc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uuid, func(t *testing.T) {
+			if got := ServiceName(tt.uuid); got != tt.want {
+				t.Errorf("ServiceName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatUUID(t *testing.T) {
+	if got := FormatUUID("0000110b-0000-1000-8000-00805f9b34fb"); got != "0x110B" {
+		t.Errorf("FormatUUID() = %q, want 0x110B", got)
+	}
+	custom := "12345678-1234-1234-1234-123456789abc"
+	if got := FormatUUID(custom); got != custom {
+		t.Errorf("FormatUUID() = %q, want %q", got, custom)
+	}
+}
+
+func TestDevice_LookupNames(t *testing.T) {
+	tests := []struct {
+		name             string
+		dev              Device
+		wantServices     []string
+		wantManufacturer string
+		wantAppearance   string
+	}{
+		{
+			name: "headphones",
+			dev: Device{
+				UUIDs:            []string{"0000110b-0000-1000-8000-00805f9b34fb", "12345678-1234-1234-1234-123456789abc"},
+				ManufacturerData: map[uint16][]byte{0xfffe: {0x01}, 0x009e: {0x02}},
+				Appearance:       0x0943,
+			},
+			wantServices:     []string{"Audio Sink (A2DP)"},
+			wantManufacturer: "Bose Corporation",
+			wantAppearance:   "Headphones",
+		},
+		{
+			name: "lowest registered ID wins",
+			dev: Device{
+				ManufacturerData: map[uint16][]byte{0x0075: nil, 0x004c: nil},
+			},
+			wantManufacturer: "Apple, Inc.",
+		},
+		{
+			name: "nothing advertised",
+			dev:  Device{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services := tt.dev.ServiceNames()
+			if strings.Join(services, ",") != strings.Join(tt.wantServices, ",") {
+				t.Errorf("ServiceNames() = %v, want %v", services, tt.wantServices)
+			}
+			if got := tt.dev.Manufacturer(); got != tt.wantManufacturer {
+				t.Errorf("Manufacturer() = %q, want %q", got, tt.wantManufacturer)
+			}
+			if got := tt.dev.AppearanceName(); got != tt.wantAppearance {
+				t.Errorf("AppearanceName() = %q, want %q", got, tt.wantAppearance)
+			}
+		})
+	}
+}
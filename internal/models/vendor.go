@@ -0,0 +1,77 @@
+package models
+
+import (
+	"strconv"
+
+	"github.com/ivangsm/blugo/internal/lookup"
+)
+
+// Kinds of LE random address, told apart by the two most significant bits
+// of the address.
+const (
+	RandomStatic        = "static"
+	RandomResolvable    = "resolvable"
+	RandomNonResolvable = "non-resolvable"
+)
+
+// addressTypeRandom is the AddressType BlueZ reports for LE random addresses.
+const addressTypeRandom = "random"
+
+// locallyAdministered is the first-octet bit set on addresses that were
+// not assigned from the IEEE registry.
+const locallyAdministered = 0x02
+
+// firstOctet parses the most significant byte of the device address.
+func (d *Device) firstOctet() (byte, bool) {
+	mac := NormalizeMAC(d.Address)
+	if len(mac) != 12 {
+		return 0, false
+	}
+	value, err := strconv.ParseUint(mac[:2], 16, 8)
+	if err != nil {
+		return 0, false
+	}
+	return byte(value), true
+}
+
+// RandomAddressKind returns which kind of LE random address the device
+// uses, or an empty string for public addresses.
+func (d *Device) RandomAddressKind() string {
+	if d.AddressType != addressTypeRandom {
+		return ""
+	}
+	octet, ok := d.firstOctet()
+	if !ok {
+		return ""
+	}
+	switch octet >> 6 {
+	case 0b11:
+		return RandomStatic
+	case 0b01:
+		return RandomResolvable
+	case 0b00:
+		return RandomNonResolvable
+	}
+	return "" // 0b10 is reserved
+}
+
+// IsPrivateAddress reports whether the address was not assigned by the
+// IEEE: LE random addresses and locally administered ones. Their leading
+// bytes are not an OUI, so looking up a vendor would be meaningless.
+func (d *Device) IsPrivateAddress() bool {
+	if d.AddressType == addressTypeRandom {
+		return true
+	}
+	octet, ok := d.firstOctet()
+	return !ok || octet&locallyAdministered != 0
+}
+
+// Vendor returns the organization the device's address is registered to,
+// e.g. "Bose Corporation", or an empty string for private addresses and
+// unknown OUIs.
+func (d *Device) Vendor() string {
+	if d.IsPrivateAddress() {
+		return ""
+	}
+	return lookup.Vendor(NormalizeMAC(d.Address))
+}
@@ -0,0 +1,76 @@
+package models
+
+import "testing"
+
+func TestDevice_AddressClassification(t *testing.T) {
+	tests := []struct {
+		name        string
+		device      Device
+		wantRandom  string
+		wantPrivate bool
+		wantVendor  string
+	}{
+		{
+			name:       "public bose address",
+			device:     Device{Address: "04:52:C7:12:34:56", AddressType: "public"},
+			wantVendor: "Bose Corporation",
+		},
+		{
+			name:       "classic device without address type",
+			device:     Device{Address: "04-52-c7-12-34-56"},
+			wantVendor: "Bose Corporation",
+		},
+		{
+			name:       "unknown OUI",
+			device:     Device{Address: "FC:FF:FF:33:44:55", AddressType: "public"},
+			wantVendor: "",
+		},
+		{
+			name:        "locally administered",
+			device:      Device{Address: "06:52:C7:12:34:56", AddressType: "public"},
+			wantPrivate: true,
+		},
+		{
+			name:        "random static",
+			device:      Device{Address: "C4:52:C7:12:34:56", AddressType: "random"},
+			wantRandom:  RandomStatic,
+			wantPrivate: true,
+		},
+		{
+			name:        "resolvable private shares a registered prefix",
+			device:      Device{Address: "44:65:0D:12:34:56", AddressType: "random"},
+			wantRandom:  RandomResolvable,
+			wantPrivate: true,
+		},
+		{
+			name:        "non-resolvable private",
+			device:      Device{Address: "04:52:C7:12:34:56", AddressType: "random"},
+			wantRandom:  RandomNonResolvable,
+			wantPrivate: true,
+		},
+		{
+			name:        "reserved random bits",
+			device:      Device{Address: "84:52:C7:12:34:56", AddressType: "random"},
+			wantPrivate: true,
+		},
+		{
+			name:        "malformed address",
+			device:      Device{Address: "not-a-mac"},
+			wantPrivate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.device.RandomAddressKind(); got != tt.wantRandom {
+				t.Errorf("RandomAddressKind() = %q, want %q", got, tt.wantRandom)
+			}
+			if got := tt.device.IsPrivateAddress(); got != tt.wantPrivate {
+				t.Errorf("IsPrivateAddress() = %v, want %v", got, tt.wantPrivate)
+			}
+			if got := tt.device.Vendor(); got != tt.wantVendor {
+				t.Errorf("Vendor() = %q, want %q", got, tt.wantVendor)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -13,16 +15,44 @@ import (
 	"github.com/ivangsm/blugo/internal/models"
 )
 
+// Session holds the settings given on the command line. They apply to
+// this run only and are never written to the config file.
+type Session struct {
+	Adapter string                           // Used instead of the configured adapter when set
+	Filter  func(*bluetooth.DiscoveryFilter) // Overrides parts of the configured discovery filter
+}
+
 // InitializeCmd initializes the Bluetooth manager and agent.
-func InitializeCmd(program *tea.Program) tea.Cmd {
+func InitializeCmd(program *tea.Program, session Session) tea.Cmd {
 	return func() tea.Msg {
-		manager, err := bluetooth.NewManager()
+		preferredAdapter := session.Adapter
+		if preferredAdapter == "" && config.Global != nil {
+			preferredAdapter = config.Global.Adapter
+		}
+
+		manager, err := bluetooth.NewManager(preferredAdapter)
 		if err != nil {
 			return InitMsg{Err: err}
 		}
 
+		manager.SetTimeouts(bluetooth.TimeoutsFromConfig())
+		filter := discoveryFilterFromConfig()
+		if session.Filter != nil {
+			session.Filter(&filter)
+		}
+		// Applied by StartDiscovery, so a powered off adapter does not
+		// keep blugo from starting
+		if err := manager.UseDiscoveryFilter(filter); err != nil {
+			manager.Close()
+			return InitMsg{Err: err}
+		}
+
+		// Subscribe to BlueZ signals; fall back to polling if unavailable
+		_ = manager.Watch()
+
 		// Create and register the agent
 		btAgent := agent.NewAgent(program)
+		btAgent.SetDeviceLookup(manager.GetDevice)
 		err = btAgent.Register(manager.GetConnection())
 		if err != nil {
 			// Not critical, the app will work but may require manual pairing
@@ -36,8 +66,9 @@ func InitializeCmd(program *tea.Program) tea.Cmd {
 			autoStart = config.Global.AutoStartScanning
 		}
 
+		// Without an adapter, scanning is resumed once one is plugged in
 		scanningStarted := false
-		if autoStart {
+		if autoStart && manager.HasAdapter() {
 			err = manager.StartDiscovery()
 			if err != nil {
 				return InitMsg{Err: fmt.Errorf("%s: %w", i18n.T.ErrorStartDiscovery, err)}
@@ -49,6 +80,43 @@ func InitializeCmd(program *tea.Program) tea.Cmd {
 	}
 }
 
+// discoveryFilterFromConfig builds the discovery filter from the global config.
+func discoveryFilterFromConfig() bluetooth.DiscoveryFilter {
+	if config.Global == nil {
+		return bluetooth.DefaultDiscoveryFilter()
+	}
+	return bluetooth.DiscoveryFilter{
+		Transport:     config.Global.DiscoveryTransport,
+		RSSI:          config.Global.DiscoveryRSSI,
+		Pathloss:      config.Global.DiscoveryPathloss,
+		UUIDs:         config.Global.DiscoveryUUIDs,
+		Pattern:       config.Global.DiscoveryPattern,
+		DuplicateData: config.Global.DiscoveryDuplicateData,
+	}
+}
+
+// reconnectorFromConfig builds the auto-reconnect state from the global
+// config.
+func reconnectorFromConfig() *bluetooth.Reconnector {
+	if config.Global == nil {
+		return bluetooth.NewReconnector(bluetooth.DefaultReconnectPolicy(), nil)
+	}
+	policy := bluetooth.DefaultReconnectPolicy()
+	policy.BaseDelay = time.Duration(config.Global.AutoReconnectDelay) * time.Second
+	policy.MaxFailures = config.Global.AutoReconnectMaxFailures
+	return bluetooth.NewReconnector(policy, config.Global.AutoReconnectDevices)
+}
+
+// setDiscoveryFilterCmd applies a new discovery filter.
+func setDiscoveryFilterCmd(manager *bluetooth.Manager, filter bluetooth.DiscoveryFilter) tea.Cmd {
+	return func() tea.Msg {
+		if err := manager.SetDiscoveryFilter(filter); err != nil {
+			return DiscoveryFilterMsg{Filter: filter, Err: err}
+		}
+		return DiscoveryFilterMsg{Filter: filter}
+	}
+}
+
 // toggleScanningCmd toggles scanning state.
 func toggleScanningCmd(manager *bluetooth.Manager, currentlyScanning bool) tea.Cmd {
 	return func() tea.Msg {
@@ -60,7 +128,7 @@ func toggleScanningCmd(manager *bluetooth.Manager, currentlyScanning bool) tea.C
 		}
 
 		if err != nil {
-			return StatusMsg{Message: fmt.Sprintf(i18n.T.ErrorScanToggle, err), IsError: true}
+			return StatusMsg{Message: fmt.Sprintf(i18n.T.ErrorScanToggle, describeError(err)), IsError: true}
 		}
 
 		return ScanningMsg{Scanning: !currentlyScanning}
@@ -71,19 +139,155 @@ func toggleScanningCmd(manager *bluetooth.Manager, currentlyScanning bool) tea.C
 func updateDevicesCmd(manager *bluetooth.Manager) tea.Cmd {
 	return func() tea.Msg {
 		devices, err := manager.GetDevices()
-		if err != nil {
+		// Devices with undecodable properties are still listed
+		if err != nil && devices == nil {
 			return StatusMsg{Message: fmt.Sprintf(i18n.T.ErrorGetDevices+": %s", err), IsError: true}
 		}
 		return DeviceUpdateMsg{Devices: devices}
 	}
 }
 
-// connectToDeviceCmd connects to a device.
-func connectToDeviceCmd(manager *bluetooth.Manager, dev *models.Device) tea.Cmd {
+// listenEventsCmd waits for the next BlueZ change pushed by the manager.
+func listenEventsCmd(manager *bluetooth.Manager) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-manager.Events()
+		if !ok {
+			return nil
+		}
+		return BluetoothEventMsg{Event: ev}
+	}
+}
+
+// registerAgentCmd registers the pairing agent again, e.g. after bluetoothd restarted.
+func registerAgentCmd(btAgent *agent.Agent, manager *bluetooth.Manager) tea.Cmd {
+	if btAgent == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		if err := btAgent.Register(manager.GetConnection()); err != nil {
+			return StatusMsg{Message: fmt.Sprintf("%s: %v", i18n.T.WarningAgentRegistration, err), IsError: true}
+		}
+		capability, _ := btAgent.Registration()
+		return StatusMsg{Message: fmt.Sprintf(i18n.T.AgentRegistered, capability)}
+	}
+}
+
+// adapterOperations is the queue key of the operations on the adapter
+// itself, which must not overlap one another.
+const adapterOperations = "adapter"
+
+// queuedCmd runs cmd in the manager's operation queue under key, after
+// the operations queued before it for the same key.
+func queuedCmd(manager *bluetooth.Manager, key string, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		var msg tea.Msg
+		manager.Operations().Run(key, func() { msg = cmd() })
+		return msg
+	}
+}
+
+// deviceOperationCmd queues cmd, the operation started with id, behind
+// the other operations on dev. Operations on other devices run alongside
+// it.
+func deviceOperationCmd(manager *bluetooth.Manager, dev *models.Device, id int, cmd tea.Cmd) tea.Cmd {
+	queued := queuedCmd(manager, dev.Address, cmd)
+	return func() tea.Msg {
+		return DeviceOperationMsg{Address: dev.Address, ID: id, Result: queued()}
+	}
+}
+
+// currentDevice returns dev as BlueZ knows it now. Operations waiting in
+// a queue use it, since the device may have paired or disconnected
+// meanwhile.
+func currentDevice(manager *bluetooth.Manager, dev *models.Device) *models.Device {
+	if current, err := manager.GetDevice(dev.Path); err == nil && current != nil {
+		return current
+	}
+	return dev
+}
+
+// retryPolicyFor builds the retry policy for dev from the global config,
+// with the settings configured for its address taking precedence.
+func retryPolicyFor(dev *models.Device) bluetooth.RetryPolicy {
+	if config.Global == nil {
+		return bluetooth.DefaultRetryPolicy()
+	}
+
+	settings := config.RetrySettings{
+		Attempts:  config.Global.RetryAttempts,
+		BaseDelay: config.Global.RetryBaseDelay,
+		Jitter:    config.Global.RetryJitter,
+		Deadline:  config.Global.RetryDeadline,
+	}
+	address := models.NormalizeMAC(dev.Address)
+	for key, device := range config.Global.RetryByDevice {
+		if models.NormalizeMAC(key) != address {
+			continue
+		}
+		if device.Attempts > 0 {
+			settings.Attempts = device.Attempts
+		}
+		if device.BaseDelay > 0 {
+			settings.BaseDelay = device.BaseDelay
+		}
+		if device.Jitter > 0 {
+			settings.Jitter = device.Jitter
+		}
+		if device.Deadline > 0 {
+			settings.Deadline = device.Deadline
+		}
+	}
+
+	return bluetooth.RetryPolicy{
+		Attempts:  settings.Attempts,
+		BaseDelay: time.Duration(settings.BaseDelay) * time.Millisecond,
+		Jitter:    settings.Jitter,
+		Deadline:  time.Duration(settings.Deadline) * time.Second,
+	}
+}
+
+// retry runs op on dev with its retry policy, telling progress about
+// every attempt after the first.
+func retry(ctx context.Context, progress chan<- tea.Msg, dev *models.Device, op func(ctx context.Context) error) error {
+	policy := retryPolicyFor(dev)
+	return policy.Do(ctx, func(ctx context.Context, attempt int) error {
+		if attempt > 1 {
+			report(progress, OperationAttemptMsg{Address: dev.Address, Attempt: attempt, Attempts: policy.Attempts})
+		}
+		return op(ctx)
+	})
+}
+
+// report hands msg to the program through progress. Progress is only
+// informative, so it is dropped rather than holding up the operation.
+func report(progress chan<- tea.Msg, msg tea.Msg) {
+	select {
+	case progress <- msg:
+	default:
+	}
+}
+
+// listenProgressCmd waits for the next progress report of an operation.
+func listenProgressCmd(progress <-chan tea.Msg) tea.Cmd {
+	if progress == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return <-progress
+	}
+}
+
+// connectToDeviceCmd connects to a device, pairing first if needed. The
+// operation is abandoned when ctx is cancelled; retries are reported on
+// progress.
+func connectToDeviceCmd(ctx context.Context, progress chan<- tea.Msg, manager *bluetooth.Manager, btAgent *agent.Agent, dev *models.Device) tea.Cmd {
 	return func() tea.Msg {
+		dev := currentDevice(manager, dev)
+
 		// If not paired, try pairing
 		if !dev.Paired {
-			err := manager.PairDevice(dev.Path)
+			err := pairDevice(ctx, manager, btAgent, dev)
 			if err != nil {
 				return ConnectResultMsg{Address: dev.Address, Success: false, Err: fmt.Errorf("%s: %w", i18n.T.ErrorPairDevice, err)}
 			}
@@ -92,20 +296,13 @@ func connectToDeviceCmd(manager *bluetooth.Manager, dev *models.Device) tea.Cmd
 			if config.Global != nil && config.Global.AutoTrustOnPair {
 				_ = manager.TrustDevice(dev.Path)
 			}
-
-			// Wait a moment after pairing (configurable)
-			delay := 1000 // Default 1 second
-			if config.Global != nil {
-				delay = config.Global.PairingDelay
-			}
-			time.Sleep(time.Duration(delay) * time.Millisecond)
 		}
 
-		// Small delay before attempting connection (helps with reconnection after disconnect)
-		time.Sleep(500 * time.Millisecond)
-
-		// Connect
-		err := manager.ConnectDevice(dev.Path)
+		// Right after pairing or a disconnect the device may still be
+		// busy, so failures that may pass are retried
+		err := retry(ctx, progress, dev, func(ctx context.Context) error {
+			return manager.ConnectDeviceContext(ctx, dev.Path)
+		})
 		if err != nil {
 			return ConnectResultMsg{Address: dev.Address, Success: false, Err: fmt.Errorf("%s: %w", i18n.T.ErrorConnectDevice, err)}
 		}
@@ -114,10 +311,28 @@ func connectToDeviceCmd(manager *bluetooth.Manager, dev *models.Device) tea.Cmd
 	}
 }
 
+// pairDevice pairs dev. When a legacy device rejects the PIN the agent
+// picked from the configured list, pairing is retried so the agent can
+// offer the next one, and finally ask the user.
+func pairDevice(ctx context.Context, manager *bluetooth.Manager, btAgent *agent.Agent, dev *models.Device) error {
+	if btAgent == nil {
+		return manager.PairDeviceContext(ctx, dev.Path)
+	}
+	btAgent.TryPinCodes(dev.Path)
+	defer btAgent.ResetPinCode(dev.Path)
+
+	for {
+		err := manager.PairDeviceContext(ctx, dev.Path)
+		if err == nil || !bluetooth.IsAuthenticationFailed(err) || !btAgent.RetryPinCode(dev.Path) {
+			return err
+		}
+	}
+}
+
 // disconnectFromDeviceCmd disconnects from a device.
-func disconnectFromDeviceCmd(manager *bluetooth.Manager, dev *models.Device) tea.Cmd {
+func disconnectFromDeviceCmd(ctx context.Context, manager *bluetooth.Manager, dev *models.Device) tea.Cmd {
 	return func() tea.Msg {
-		err := manager.DisconnectDevice(dev.Path)
+		err := manager.DisconnectDeviceContext(ctx, dev.Path)
 		if err != nil {
 			return ConnectResultMsg{Address: dev.Address, Success: false, Err: fmt.Errorf("%s: %w", i18n.T.ErrorDisconnectDevice, err)}
 		}
@@ -126,38 +341,72 @@ func disconnectFromDeviceCmd(manager *bluetooth.Manager, dev *models.Device) tea
 }
 
 // forgetDeviceCmd forgets (removes) a device.
-func forgetDeviceCmd(manager *bluetooth.Manager, dev *models.Device) tea.Cmd {
+func forgetDeviceCmd(ctx context.Context, progress chan<- tea.Msg, manager *bluetooth.Manager, dev *models.Device) tea.Cmd {
 	return func() tea.Msg {
+		dev := currentDevice(manager, dev)
+
 		// Disconnect first if connected
 		if dev.Connected {
-			_ = manager.DisconnectDevice(dev.Path)
-			// Wait before removing (configurable)
-			delay := 500 // Default 500ms
-			if config.Global != nil {
-				delay = config.Global.DisconnectDelay
-			}
-			time.Sleep(time.Duration(delay) * time.Millisecond)
+			_ = manager.DisconnectDeviceContext(ctx, dev.Path)
 		}
 
-		// Remove the device
-		err := manager.RemoveDevice(dev.Path)
+		// Remove the device, retried while the disconnect is still going on
+		err := retry(ctx, progress, dev, func(ctx context.Context) error {
+			return manager.RemoveDeviceContext(ctx, dev.Path)
+		})
+		if errors.Is(err, context.Canceled) {
+			return StatusMsg{Message: fmt.Sprintf(i18n.T.OperationCancelled, dev.GetDisplayName())}
+		}
 		if err != nil {
-			return StatusMsg{Message: fmt.Sprintf(i18n.T.ErrorForgetDevice+": %s", err), IsError: true}
+			return StatusMsg{Message: fmt.Sprintf(i18n.T.ErrorForgetDevice+": %s", describeError(err)), IsError: true}
 		}
 
 		return ForgetDeviceMsg{Address: dev.Address, Message: fmt.Sprintf(i18n.T.Forgotten+": %s", dev.GetDisplayName())}
 	}
 }
 
-// waitForPasskeyCmd waits for a passkey to be received.
-func waitForPasskeyCmd(agent *agent.Agent) tea.Cmd {
-	if agent == nil {
-		return nil
+// profileCmd connects or disconnects the profile of dev identified by
+// uuid. Connects are retried like whole device connects.
+func profileCmd(ctx context.Context, progress chan<- tea.Msg, manager *bluetooth.Manager, dev *models.Device, uuid string, connect bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if connect {
+			err = retry(ctx, progress, dev, func(ctx context.Context) error {
+				return manager.ConnectProfileContext(ctx, dev.Path, uuid)
+			})
+		} else {
+			err = manager.DisconnectProfileContext(ctx, dev.Path, uuid)
+		}
+		return ProfileResultMsg{Address: dev.Address, UUID: uuid, Connect: connect, Err: err}
 	}
+}
 
+// reconnectDueCmd waits delay before reconnecting the device at address.
+func reconnectDueCmd(address string, delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return ReconnectDueMsg{Address: address}
+	})
+}
+
+// reconnectDeviceCmd makes one attempt at reconnecting dev. The
+// reconnector spaces the attempts out, so failures are not retried here.
+func reconnectDeviceCmd(ctx context.Context, manager *bluetooth.Manager, dev *models.Device) tea.Cmd {
 	return func() tea.Msg {
-		passkey := <-agent.GetPasskeyChannel()
-		return PasskeyDisplayMsg{Passkey: passkey}
+		err := manager.ConnectDeviceContext(ctx, dev.Path)
+		if errors.Is(err, bluetooth.ErrAlreadyConnected) {
+			err = nil
+		}
+		return ReconnectResultMsg{Address: dev.Address, Err: err}
+	}
+}
+
+// cancelPairingCmd tells BlueZ to stop pairing with dev. The pairing may
+// already be over, e.g. while the operation waits to connect, so a
+// failure is not reported.
+func cancelPairingCmd(manager *bluetooth.Manager, dev *models.Device) tea.Cmd {
+	return func() tea.Msg {
+		_ = manager.CancelPairing(dev.Path)
+		return nil
 	}
 }
 
@@ -181,11 +430,41 @@ func tickCmd() tea.Cmd {
 // updateAdapterInfoCmd updates the adapter information.
 func updateAdapterInfoCmd(manager *bluetooth.Manager) tea.Cmd {
 	return func() tea.Msg {
+		adapters, err := manager.GetAdapters()
+		if err != nil {
+			return StatusMsg{Message: fmt.Sprintf(i18n.T.ErrorGetAdapterInfo+": %s", err), IsError: true}
+		}
 		adapter, err := manager.GetAdapterInfo()
+		if errors.Is(err, bluetooth.ErrNoAdapter) {
+			return AdapterUpdateMsg{Adapters: adapters}
+		}
 		if err != nil {
 			return StatusMsg{Message: fmt.Sprintf(i18n.T.ErrorGetAdapterInfo+": %s", err), IsError: true}
 		}
-		return AdapterUpdateMsg{Adapter: adapter}
+		return AdapterUpdateMsg{Adapter: adapter, Adapters: adapters}
+	}
+}
+
+// switchAdapterCmd makes another adapter the active one.
+// Scanning is moved along with the selection when it was active.
+func switchAdapterCmd(manager *bluetooth.Manager, name string, scanning bool) tea.Cmd {
+	return func() tea.Msg {
+		if scanning {
+			_ = manager.StopDiscovery()
+		}
+
+		adapter, err := manager.SelectAdapter(name)
+		if err != nil {
+			return AdapterSwitchedMsg{Err: fmt.Errorf("%s: %w", i18n.T.ErrorSelectAdapter, err)}
+		}
+
+		if scanning {
+			if err := manager.StartDiscovery(); err != nil {
+				return AdapterSwitchedMsg{Adapter: adapter, Err: err}
+			}
+		}
+
+		return AdapterSwitchedMsg{Adapter: adapter, Scanning: scanning}
 	}
 }
 
@@ -197,7 +476,7 @@ func toggleAdapterPoweredCmd(manager *bluetooth.Manager, currentState bool) tea.
 		if err != nil {
 			return AdapterPropertyChangedMsg{Property: "Powered", Success: false, Err: err}
 		}
-		return AdapterPropertyChangedMsg{Property: "Powered", Success: true}
+		return AdapterPropertyChangedMsg{Property: "Powered", Value: newState, Success: true}
 	}
 }
 
@@ -209,7 +488,7 @@ func toggleAdapterDiscoverableCmd(manager *bluetooth.Manager, currentState bool)
 		if err != nil {
 			return AdapterPropertyChangedMsg{Property: "Discoverable", Success: false, Err: err}
 		}
-		return AdapterPropertyChangedMsg{Property: "Discoverable", Success: true}
+		return AdapterPropertyChangedMsg{Property: "Discoverable", Value: newState, Success: true}
 	}
 }
 
@@ -221,6 +500,51 @@ func toggleAdapterPairableCmd(manager *bluetooth.Manager, currentState bool) tea
 		if err != nil {
 			return AdapterPropertyChangedMsg{Property: "Pairable", Success: false, Err: err}
 		}
-		return AdapterPropertyChangedMsg{Property: "Pairable", Success: true}
+		return AdapterPropertyChangedMsg{Property: "Pairable", Value: newState, Success: true}
+	}
+}
+
+// startIncomingPairingCmd makes the adapter pairable and discoverable so a
+// phone can find it and start pairing.
+func startIncomingPairingCmd(manager *bluetooth.Manager) tea.Cmd {
+	return func() tea.Msg {
+		if err := manager.SetAdapterPairable(true); err != nil {
+			return IncomingPairingMsg{Err: err}
+		}
+		if err := manager.SetAdapterDiscoverable(true); err != nil {
+			return IncomingPairingMsg{Err: err}
+		}
+		return IncomingPairingMsg{}
+	}
+}
+
+// incomingPairingTickCmd ticks the pair from phone countdown every second.
+func incomingPairingTickCmd(started time.Time) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return IncomingPairingTickMsg{Started: started}
+	})
+}
+
+// restoreAdapterCmd puts the adapter's discoverable and pairable settings
+// back the way they were before the pair from phone wizard.
+func restoreAdapterCmd(manager *bluetooth.Manager, discoverable, pairable bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := manager.SetAdapterDiscoverable(discoverable); err != nil {
+			return StatusMsg{Message: fmt.Sprintf("%s Discoverable: %s", i18n.T.ErrorChangeProperty, err), IsError: true}
+		}
+		if err := manager.SetAdapterPairable(pairable); err != nil {
+			return StatusMsg{Message: fmt.Sprintf("%s Pairable: %s", i18n.T.ErrorChangeProperty, err), IsError: true}
+		}
+		return updateAdapterInfoCmd(manager)()
+	}
+}
+
+// trustDeviceCmd trusts a device that paired on its own initiative.
+func trustDeviceCmd(manager *bluetooth.Manager, dev *models.Device) tea.Cmd {
+	return func() tea.Msg {
+		if err := manager.TrustDevice(dev.Path); err != nil {
+			return StatusMsg{Message: fmt.Sprintf("%s: %s", i18n.T.ErrorTrustDevice, err), IsError: true}
+		}
+		return nil
 	}
 }
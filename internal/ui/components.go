@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ivangsm/blugo/internal/bluetooth"
 	"github.com/ivangsm/blugo/internal/config"
 	"github.com/ivangsm/blugo/internal/i18n"
 	"github.com/ivangsm/blugo/internal/models"
@@ -33,6 +34,18 @@ func (m Model) renderHeader() string {
 		scanStatus = MutedStyle.Render(statusText)
 	}
 
+	// Show the discovery filter next to the scan status when it hides devices
+	if m.manager != nil {
+		if filter := m.manager.DiscoveryFilter(); filter.IsActive() {
+			scanStatus = MutedStyle.Render(discoveryFilterSummary(filter)) + "  " + scanStatus
+		}
+	}
+
+	// Show how pairing requests are handled, or that they are not
+	if m.agent != nil {
+		scanStatus = m.renderAgentStatus() + "  " + scanStatus
+	}
+
 	// Use effective width
 	effectiveWidth := min(m.width, GetMaxWidth())
 
@@ -55,18 +68,103 @@ func (m Model) renderHeader() string {
 	return HeaderBoxStyle.Render(lipgloss.JoinHorizontal(lipgloss.Top, title, "  ", scanStatus))
 }
 
+// renderAgentStatus renders the IO capability the pairing agent is
+// registered with.
+func (m Model) renderAgentStatus() string {
+	capability, isDefault := m.agent.Registration()
+	switch {
+	case capability == "":
+		return WarningStyle.Render(i18n.T.AgentMissing)
+	case isDefault:
+		return MutedStyle.Render(fmt.Sprintf(i18n.T.AgentStatusDefault, capability))
+	}
+	return MutedStyle.Render(fmt.Sprintf(i18n.T.AgentStatus, capability))
+}
+
+// discoveryFilterSummary describes a discovery filter in a few words,
+// e.g. "LE · ≥ -70 dBm · 1 UUIDs".
+func discoveryFilterSummary(filter bluetooth.DiscoveryFilter) string {
+	parts := []string{}
+
+	switch filter.Transport {
+	case bluetooth.TransportLE:
+		parts = append(parts, "LE")
+	case bluetooth.TransportBREDR:
+		parts = append(parts, "BR/EDR")
+	}
+	if filter.RSSI != 0 {
+		parts = append(parts, fmt.Sprintf("≥ %d dBm", filter.RSSI))
+	}
+	if filter.Pathloss != 0 {
+		parts = append(parts, fmt.Sprintf("≤ %d dB", filter.Pathloss))
+	}
+	if len(filter.UUIDs) > 0 {
+		parts = append(parts, fmt.Sprintf(i18n.T.FilterUUIDs, len(filter.UUIDs)))
+	}
+	if filter.Pattern != "" {
+		parts = append(parts, fmt.Sprintf(i18n.T.FilterPattern, filter.Pattern))
+	}
+
+	if len(parts) == 0 {
+		return i18n.T.DiscoveryFilterNone
+	}
+	return strings.Join(parts, " · ")
+}
+
+// categoryLabel returns the localized name of a device category.
+func categoryLabel(category models.DeviceCategory) string {
+	switch category {
+	case models.CategoryAudio:
+		return i18n.T.CategoryAudio
+	case models.CategoryInput:
+		return i18n.T.CategoryInput
+	case models.CategoryPhone:
+		return i18n.T.CategoryPhone
+	case models.CategoryComputer:
+		return i18n.T.CategoryComputer
+	case models.CategoryWearable:
+		return i18n.T.CategoryWearable
+	case models.CategoryHealth:
+		return i18n.T.CategoryHealth
+	case models.CategoryImaging:
+		return i18n.T.CategoryImaging
+	case models.CategoryOther:
+		return i18n.T.CategoryOther
+	}
+	return i18n.T.CategoryAll
+}
+
+// availableDevicesTitle returns the device list title, naming the device
+// type filter when one is selected.
+func (m Model) availableDevicesTitle() string {
+	if m.typeFilter == models.CategoryAll {
+		return i18n.T.AvailableDevices
+	}
+	return i18n.T.AvailableDevices + " · " + categoryLabel(m.typeFilter)
+}
+
 // renderFooter renders the footer with help.
 func (m Model) renderFooter() string {
 	var helpText string
 
-	if m.pairingPasskey != nil {
+	if m.pairingPromptOpen() && m.typedRemotely() {
+		helpText = HelpStyle.Render(i18n.T.HelpPasskeyDisplay)
+	} else if m.pairingPromptOpen() {
 		helpText = HelpStyle.Render(i18n.T.HelpPairing)
+	} else if m.input != nil {
+		helpText = HelpStyle.Render(i18n.T.HelpInput)
+	} else if m.authorization != nil {
+		helpText = HelpStyle.Render(i18n.T.HelpAuthorization)
+	} else if m.incoming != nil {
+		helpText = HelpStyle.Render(i18n.T.HelpIncoming)
+	} else if m.showDetails {
+		helpText = HelpStyle.Render(i18n.T.HelpDetails)
 	} else if m.showHelp {
 		// Show full help when expanded
 		helpText = HelpStyle.Render(
 			i18n.T.HelpNavigation + " | " + i18n.T.HelpExpanded + "\n" +
-			i18n.T.HelpAdapterControl + "\n" +
-			i18n.T.HelpScroll,
+				i18n.T.HelpAdapterControl + "\n" +
+				i18n.T.HelpScroll,
 		)
 	} else {
 		// Show collapsed help
@@ -90,7 +188,7 @@ func (m Model) renderStatusBar() string {
 	}
 
 	var styled string
-	if m.busy {
+	if m.statusPending {
 		text := m.statusMessage
 		if Emoji(EmojiLoading) != "" {
 			text = Emoji(EmojiLoading) + " " + text
@@ -120,24 +218,98 @@ func (m Model) renderStatusBar() string {
 	return BoxStyle.Render(styled)
 }
 
-// renderPasskeyPrompt renders the passkey prompt.
+// renderUnavailableBanner renders the banner shown while bluetoothd is gone.
+func (m Model) renderUnavailableBanner() string {
+	text := i18n.T.BluezUnavailable
+	if Emoji(EmojiError) != "" {
+		text = Emoji(EmojiError) + " " + text
+	}
+	styled := ErrorStyle.Render(text)
+
+	// Use effective width
+	effectiveWidth := min(m.width, GetMaxWidth())
+
+	if effectiveWidth > 0 {
+		return BoxStyle.Width(effectiveWidth - 4).Align(lipgloss.Center).Render(styled)
+	}
+
+	return BoxStyle.Render(styled)
+}
+
+// renderPasskeyPrompt renders the passkey or PIN prompt.
 func (m Model) renderPasskeyPrompt() string {
-	passkeyFormat := i18n.T.PairingCode
+	var codeText string
+	if m.pairingPinCode != "" {
+		codeText = fmt.Sprintf(i18n.T.PairingPinCode, m.pairingPinCode)
+	} else {
+		codeText = fmt.Sprintf(i18n.T.PairingCode, *m.pairingPasskey)
+	}
 	if Emoji(EmojiPairingKey) != "" {
-		passkeyFormat = Emoji(EmojiPairingKey) + " " + passkeyFormat
+		codeText = Emoji(EmojiPairingKey) + " " + codeText
+	}
+
+	var lines []string
+	if m.pairingDevice != nil {
+		lines = append(lines, InfoStyle.Render(fmt.Sprintf(i18n.T.PairingDevice, deviceListName(m.pairingDevice))), "")
 	}
-	passkeyText := fmt.Sprintf(passkeyFormat, *m.pairingPasskey)
+	lines = append(lines, codeText, "")
 
-	instructionText := i18n.T.PairingInstruction
+	// The code is either typed on the remote device, whose progress BlueZ
+	// reports for passkeys, or compared with the one it shows and
+	// confirmed here
+	instructionText, confirmText := i18n.T.PairingCompare, i18n.T.PairingConfirm
+	if m.typedRemotely() {
+		instructionText, confirmText = i18n.T.PairingInstruction, i18n.T.PasskeyDisplayHint
+	}
+	if m.passkeyEntered != nil {
+		progress := fmt.Sprintf(i18n.T.PasskeyProgress, passkeyProgress(*m.passkeyEntered))
+		lines = append(lines, InfoStyle.Render(progress), "")
+	}
 	if Emoji(EmojiKeyboard) != "" {
 		instructionText = Emoji(EmojiKeyboard) + "  " + instructionText
 	}
+	lines = append(lines, WarningStyle.Render(instructionText), HelpStyle.Render(confirmText))
+
+	content := lipgloss.JoinVertical(lipgloss.Center, lines...)
+
+	// Use effective width
+	effectiveWidth := min(m.width, GetMaxWidth())
+
+	if effectiveWidth > 0 {
+		return PasskeyBoxStyle.Width(min(effectiveWidth-4, 70)).Render(content)
+	}
+
+	return PasskeyBoxStyle.Render(content)
+}
+
+// passkeyProgress draws one dot per passkey digit, filled for the digits
+// already typed on the remote device.
+func passkeyProgress(entered uint16) string {
+	typed := min(int(entered), passkeyDigits)
+	return strings.Repeat("●", typed) + strings.Repeat("○", passkeyDigits-typed)
+}
+
+// renderInputPrompt renders the prompt asking for a legacy device's PIN
+// or for the passkey shown by the device.
+func (m Model) renderInputPrompt() string {
+	titleFormat, instructionText, confirmText := i18n.T.PinPrompt, i18n.T.PinInstruction, i18n.T.PinConfirm
+	if m.input.passkey {
+		titleFormat, instructionText, confirmText = i18n.T.PasskeyPrompt, i18n.T.PasskeyInstruction, i18n.T.PasskeyConfirm
+	}
+	title := fmt.Sprintf(titleFormat, deviceListName(m.input.device))
+	if Emoji(EmojiPairingKey) != "" {
+		title = Emoji(EmojiPairingKey) + " " + title
+	}
+
+	input := SelectedStyle.Render(" " + m.input.value + "_ ")
 	instruction := WarningStyle.Render(instructionText)
-	confirm := HelpStyle.Render(i18n.T.PairingConfirm)
+	confirm := HelpStyle.Render(confirmText)
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
-		passkeyText,
+		title,
+		"",
+		input,
 		"",
 		instruction,
 		confirm,
@@ -153,6 +325,36 @@ func (m Model) renderPasskeyPrompt() string {
 	return PasskeyBoxStyle.Render(content)
 }
 
+// renderAuthorizationPrompt renders the prompt asking whether a device may
+// pair or use a service.
+func (m Model) renderAuthorizationPrompt() string {
+	request := m.authorization
+	title := fmt.Sprintf(i18n.T.AuthorizePairing, deviceListName(request.device))
+	if request.uuid != "" {
+		title = fmt.Sprintf(i18n.T.AuthorizeService, deviceListName(request.device), request.profile())
+	}
+	if Emoji(EmojiPairingKey) != "" {
+		title = Emoji(EmojiPairingKey) + " " + title
+	}
+
+	lines := []string{title, ""}
+	if request.uuid != "" {
+		lines = append(lines, MutedStyle.Render(models.FormatUUID(request.uuid)), "")
+	}
+	lines = append(lines, WarningStyle.Render(i18n.T.AuthorizeInstruction), HelpStyle.Render(i18n.T.AuthorizeConfirm))
+
+	content := lipgloss.JoinVertical(lipgloss.Center, lines...)
+
+	// Use effective width
+	effectiveWidth := min(m.width, GetMaxWidth())
+
+	if effectiveWidth > 0 {
+		return PasskeyBoxStyle.Width(min(effectiveWidth-4, 70)).Render(content)
+	}
+
+	return PasskeyBoxStyle.Render(content)
+}
+
 // renderDeviceCount renders the device counter.
 func renderDeviceCount(count int) string {
 	return MutedStyle.Render(fmt.Sprintf("(%d)", count))
@@ -172,10 +374,30 @@ func renderSectionHeader(icon, title string, count int, isFocused bool) string {
 	return HeaderStyle.Render(header)
 }
 
+// deviceListName returns the name shown for a device in lists. Devices
+// that only have an address are labelled with their vendor when known.
+func deviceListName(dev *models.Device) string {
+	if !dev.HasRealName() {
+		if vendor := dev.Vendor(); vendor != "" {
+			return fmt.Sprintf(i18n.T.UnnamedVendor, vendor)
+		}
+	}
+	return dev.GetDisplayName()
+}
+
+// describeError renders err for the status bar, followed by how to fix
+// it when BlueZ reported a known failure.
+func describeError(err error) string {
+	if hint := bluetooth.Hint(err); hint != "" {
+		return fmt.Sprintf("%s. %s", err.Error(), hint)
+	}
+	return err.Error()
+}
+
 // renderDeviceItem renders a device item.
 func renderDeviceItem(dev *models.Device, isSelected bool, showRSSI bool) string {
 	icon := DeviceIconStyle.Render(dev.GetIcon())
-	name := DeviceNameStyle.Render(dev.GetDisplayName())
+	name := DeviceNameStyle.Render(deviceListName(dev))
 
 	// Address (conditional based on config)
 	parts := []string{icon, name}
@@ -214,7 +436,9 @@ func renderDeviceItem(dev *models.Device, isSelected bool, showRSSI bool) string
 
 	// Badges
 	var badges []string
-	if dev.Connected {
+	if state := dev.State(); state.Transitional() {
+		badges = append(badges, ConnectingStyle.Render(stateLabel(state)))
+	} else if state == models.StateConnected {
 		badges = append(badges, ConnectedBadgeStyle.Render(i18n.T.BadgeConnected))
 	} else if dev.Paired {
 		badges = append(badges, PairedBadgeStyle.Render(i18n.T.BadgePaired))
@@ -286,6 +510,9 @@ func (m Model) renderThickSeparator() string {
 // renderAdapterTable renders the adapter information table.
 func (m Model) renderAdapterTable() string {
 	if m.adapter == nil {
+		if m.manager != nil && !m.manager.HasAdapter() {
+			return BoxStyle.Render(WarningStyle.Render(i18n.T.NoAdapter))
+		}
 		return BoxStyle.Render(MutedStyle.Render(i18n.T.StatusLoadingAdapterInfo))
 	}
 
@@ -300,8 +527,8 @@ func (m Model) renderAdapterTable() string {
 	// Additional adjustment for alignment
 	availableWidth := effectiveWidth - 6
 
-	// Calculate column width (5 columns, distribute evenly)
-	colWidth := availableWidth / 5
+	// Calculate column width (6 columns, distribute evenly)
+	colWidth := availableWidth / 6
 
 	// Header style
 	headerStyle := lipgloss.NewStyle().
@@ -317,6 +544,7 @@ func (m Model) renderAdapterTable() string {
 
 	// Build header row
 	headers := []string{
+		headerStyle.Render(i18n.T.AdapterID),
 		headerStyle.Render(i18n.T.AdapterName),
 		headerStyle.Render(i18n.T.AdapterAlias),
 		headerStyle.Render(i18n.T.AdapterPower),
@@ -328,38 +556,19 @@ func (m Model) renderAdapterTable() string {
 	// Build separator
 	separator := SeparatorStyle.Render(strings.Repeat("─", availableWidth))
 
-	// Build data row with color coding
-	powerText := ErrorStyle.Render(i18n.T.StatusOff)
-	if m.adapter.Powered {
-		powerText = SuccessStyle.Render(i18n.T.StatusOn)
-	}
-
-	pairableText := MutedStyle.Render(i18n.T.StatusOff)
-	if m.adapter.Pairable {
-		pairableText = SuccessStyle.Render(i18n.T.StatusOn)
-	}
-
-	discoverableText := MutedStyle.Render(i18n.T.StatusOff)
-	if m.adapter.Discoverable {
-		discoverableText = SuccessStyle.Render(i18n.T.StatusOn)
+	// One row per adapter, the active one is marked
+	adapters := m.adapters
+	if len(adapters) == 0 {
+		adapters = []*models.Adapter{m.adapter}
 	}
 
-	cells := []string{
-		cellStyle.Render(m.adapter.Name),
-		cellStyle.Render(m.adapter.Alias),
-		cellStyle.Render(powerText),
-		cellStyle.Render(pairableText),
-		cellStyle.Render(discoverableText),
+	rows := []string{headerRow, separator}
+	for _, adapter := range adapters {
+		active := len(adapters) > 1 && adapter.Path == m.adapter.Path
+		rows = append(rows, renderAdapterRow(adapter, active, cellStyle))
 	}
-	dataRow := lipgloss.JoinHorizontal(lipgloss.Top, cells...)
 
-	// Combine table content
-	tableContent := lipgloss.JoinVertical(
-		lipgloss.Left,
-		headerRow,
-		separator,
-		dataRow,
-	)
+	tableContent := lipgloss.JoinVertical(lipgloss.Left, rows...)
 
 	// Add title header with icon
 	title := HeaderStyle.Render(
@@ -376,3 +585,39 @@ func (m Model) renderAdapterTable() string {
 
 	return BoxStyle.Width(effectiveWidth - 4).Render(table)
 }
+
+// renderAdapterRow renders a single adapter row with color-coded states.
+func renderAdapterRow(adapter *models.Adapter, active bool, cellStyle lipgloss.Style) string {
+	powerText := ErrorStyle.Render(i18n.T.StatusOff)
+	if adapter.Powered {
+		powerText = SuccessStyle.Render(i18n.T.StatusOn)
+	}
+
+	pairableText := MutedStyle.Render(i18n.T.StatusOff)
+	if adapter.Pairable {
+		pairableText = SuccessStyle.Render(i18n.T.StatusOn)
+	}
+
+	discoverableText := MutedStyle.Render(i18n.T.StatusOff)
+	if adapter.Discoverable {
+		discoverableText = SuccessStyle.Render(i18n.T.StatusOn)
+	}
+
+	id := adapter.ID()
+	if active {
+		if Emoji(EmojiSelector) != "" {
+			id = Emoji(EmojiSelector) + " " + id
+		}
+		id = SelectedStyle.Render(id)
+	}
+
+	cells := []string{
+		cellStyle.Render(id),
+		cellStyle.Render(adapter.Name),
+		cellStyle.Render(adapter.Alias),
+		cellStyle.Render(powerText),
+		cellStyle.Render(pairableText),
+		cellStyle.Render(discoverableText),
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+}
@@ -1,9 +1,13 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/bluetooth"
 	"github.com/ivangsm/blugo/internal/config"
 	"github.com/ivangsm/blugo/internal/i18n"
 	"github.com/ivangsm/blugo/internal/models"
@@ -421,6 +425,121 @@ func TestModel_RenderAdapterTable(t *testing.T) {
 		}
 	})
 
-	// We can't easily test with a real adapter without full initialization
-	// but we've verified the nil case works
+	t.Run("lists every adapter", func(t *testing.T) {
+		hci0 := &models.Adapter{Path: "/org/bluez/hci0", Name: "laptop", Powered: true}
+		hci1 := &models.Adapter{Path: "/org/bluez/hci1", Name: "dongle"}
+		m := Model{adapter: hci1, adapters: []*models.Adapter{hci0, hci1}, width: 140}
+		result := m.renderAdapterTable()
+
+		for _, want := range []string{"hci0", "hci1", "laptop", "dongle"} {
+			if !strings.Contains(result, want) {
+				t.Errorf("renderAdapterTable() should contain %q", want)
+			}
+		}
+	})
+}
+
+func TestDiscoveryFilterSummary(t *testing.T) {
+	i18n.SetLanguage(i18n.English)
+
+	tests := []struct {
+		name   string
+		filter bluetooth.DiscoveryFilter
+		want   string
+	}{
+		{"default", bluetooth.DefaultDiscoveryFilter(), "none"},
+		{"le with rssi", bluetooth.DiscoveryFilter{Transport: bluetooth.TransportLE, RSSI: -70}, "LE · ≥ -70 dBm"},
+		{"bredr with pathloss", bluetooth.DiscoveryFilter{Transport: bluetooth.TransportBREDR, Pathloss: 60}, "BR/EDR · ≤ 60 dB"},
+		{"uuids and pattern", bluetooth.DiscoveryFilter{UUIDs: []string{"180d", "180f"}, Pattern: "Polar"}, "2 UUIDs · name: Polar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := discoveryFilterSummary(tt.filter); got != tt.want {
+				t.Errorf("discoveryFilterSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceListName(t *testing.T) {
+	tests := []struct {
+		name   string
+		device models.Device
+		want   string
+	}{
+		{
+			name:   "named device keeps its name",
+			device: models.Device{Address: "04:52:C7:12:34:56", Name: "QC35"},
+			want:   "QC35",
+		},
+		{
+			name:   "unnamed public address shows the vendor",
+			device: models.Device{Address: "04:52:C7:12:34:56", Alias: "04-52-C7-12-34-56", AddressType: "public"},
+			want:   "Unnamed (Bose Corporation)",
+		},
+		{
+			name:   "unnamed random address shows the address",
+			device: models.Device{Address: "44:65:0D:12:34:56", Alias: "44-65-0D-12-34-56", AddressType: "random"},
+			want:   "44-65-0D-12-34-56",
+		},
+		{
+			name:   "unknown vendor shows the address",
+			device: models.Device{Address: "FC:FF:FF:33:44:55"},
+			want:   "FC:FF:FF:33:44:55",
+		},
+	}
+
+	i18n.SetLanguage(i18n.English)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deviceListName(&tt.device); got != tt.want {
+				t.Errorf("deviceListName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasskeyProgress(t *testing.T) {
+	tests := []struct {
+		entered uint16
+		want    string
+	}{
+		{0, "○○○○○○"},
+		{2, "●●○○○○"},
+		{6, "●●●●●●"},
+		{7, "●●●●●●"},
+	}
+
+	for _, tt := range tests {
+		if got := passkeyProgress(tt.entered); got != tt.want {
+			t.Errorf("passkeyProgress(%d) = %q, want %q", tt.entered, got, tt.want)
+		}
+	}
+}
+
+func TestDescribeError(t *testing.T) {
+	i18n.SetLanguage(i18n.English)
+
+	timeout := bluetooth.Classify(dbus.Error{Name: "org.bluez.Error.Failed", Body: []interface{}{"br-connection-page-timeout"}})
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"known failure", fmt.Errorf("%s: %w", i18n.T.ErrorConnectDevice, timeout),
+			[]string{i18n.T.ErrorConnectDevice, i18n.T.BluezPageTimeout, i18n.T.HintPageTimeout}},
+		{"other error", errors.New("boom"), []string{"boom"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := describeError(tt.err)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("describeError() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
 }
@@ -0,0 +1,279 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// detailsSideBySideWidth is the narrowest effective width at which the
+// detail panel is shown next to the device list instead of as a modal.
+const detailsSideBySideWidth = 120
+
+// detailsLabelWidth is the width of the property name column.
+const detailsLabelWidth = 20
+
+// detailsSideBySide reports whether the detail panel fits next to the device list.
+func (m Model) detailsSideBySide() bool {
+	return min(m.width, GetMaxWidth()) >= detailsSideBySideWidth
+}
+
+// columnWidth returns the width of each column in the two column layout.
+func (m Model) columnWidth() int {
+	effectiveWidth := min(m.width, GetMaxWidth())
+	return (effectiveWidth - 4) / 2
+}
+
+// devicesWidth returns the width available to the devices panel.
+func (m Model) devicesWidth() int {
+	if m.showDetails && m.detailsSideBySide() {
+		return m.columnWidth()
+	}
+	return min(m.width, GetMaxWidth())
+}
+
+// renderDeviceDetails renders every known property of the selected device.
+func (m Model) renderDeviceDetails(width int) string {
+	dev := m.GetSelectedDevice()
+	if dev == nil {
+		return PanelStyle.Width(width - 4).Render(renderEmptyState(i18n.T.NoDeviceSelected))
+	}
+
+	title := HeaderStyle.Render(fmt.Sprintf("%s %s", dev.GetIcon(), i18n.T.DeviceDetails))
+	name := DeviceNameStyle.Render(deviceListName(dev))
+
+	addressType := dev.AddressType
+	if kind := dev.RandomAddressKind(); kind != "" {
+		addressType += " (" + kind + ")"
+	}
+
+	deviceType := dev.Type()
+	identity := [][2]string{
+		{i18n.T.DetailsType, fmt.Sprintf("%s (%s)", deviceType.Kind, categoryLabel(deviceType.Category()))},
+		{"Address", dev.Address},
+		{"AddressType", addressType},
+		{i18n.T.DetailsVendor, dev.Vendor()},
+		{"Name", dev.Name},
+		{"Alias", dev.Alias},
+		{"Icon", dev.Icon},
+		{"Adapter", string(dev.Adapter)},
+		{"Modalias", dev.Modalias},
+	}
+	if dev.Appearance != 0 {
+		appearance := fmt.Sprintf("0x%04x", dev.Appearance)
+		if deviceType.Appearance != "" {
+			appearance += " (" + deviceType.Appearance + ")"
+		}
+		identity = append(identity, [2]string{"Appearance", appearance})
+	}
+	identity = append(identity, [2]string{i18n.T.DetailsManufacturer, dev.Manufacturer()})
+
+	sections := []string{title, m.renderDetailsSeparator(width), name}
+	sections = append(sections, renderDetailsSection(i18n.T.DetailsIdentity, identity))
+	sections = append(sections, renderDetailsSection(i18n.T.DetailsState, deviceStateRows(dev)))
+	if rows := m.deviceProfileRows(dev); len(rows) > 0 {
+		sections = append(sections, renderDetailsSection(i18n.T.DetailsProfiles, rows))
+	}
+	sections = append(sections, renderDetailsSection(i18n.T.DetailsClass, deviceClassRows(dev, deviceType)))
+	sections = append(sections, renderDetailsSection(i18n.T.DetailsServices, deviceServiceRows(dev)))
+	sections = append(sections, renderDetailsSection(i18n.T.DetailsAdvertising, deviceAdvertisingRows(dev)))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
+	return FocusedPanelStyle.Width(width - 4).Render(content)
+}
+
+// renderDetailsSeparator renders a separator that fits inside the detail panel.
+func (m Model) renderDetailsSeparator(width int) string {
+	// FocusedPanelStyle has Padding(1, 2) and borders
+	return SeparatorStyle.Render(strings.Repeat("─", max(width-10, 10)))
+}
+
+// renderDetailsSection renders a titled list of label/value rows.
+// Rows with an empty value are skipped.
+func renderDetailsSection(title string, rows [][2]string) string {
+	lines := []string{"", SubtitleStyle.Render(title)}
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		label := MutedStyle.Width(detailsLabelWidth).Render(row[0])
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, label, row[1]))
+	}
+	if len(lines) == 2 {
+		lines = append(lines, MutedStyle.Render("-"))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// deviceStateRows lists the connection, bonding and signal state of a device.
+func deviceStateRows(dev *models.Device) [][2]string {
+	rows := [][2]string{
+		{"State", stateLabel(dev.State())},
+		{"Paired", formatBool(dev.Paired)},
+		{"Bonded", formatBool(dev.Bonded)},
+		{"Trusted", formatBool(dev.Trusted)},
+		{"Blocked", formatBool(dev.Blocked)},
+		{"Connected", formatBool(dev.Connected)},
+	}
+	if dev.ServicesResolved != nil {
+		rows = append(rows, [2]string{"ServicesResolved", formatBool(*dev.ServicesResolved)})
+	}
+	rows = append(rows,
+		[2]string{"LegacyPairing", formatBool(dev.LegacyPairing)},
+		[2]string{"WakeAllowed", formatBool(dev.WakeAllowed)},
+	)
+	if dev.RSSI != 0 {
+		rows = append(rows, [2]string{"RSSI", fmt.Sprintf("%d dBm", dev.RSSI)})
+	}
+	if dev.TxPower != 0 {
+		rows = append(rows, [2]string{"TxPower", fmt.Sprintf("%d dBm", dev.TxPower)})
+	}
+	if dev.HasBattery() {
+		rows = append(rows, [2]string{"Battery", fmt.Sprintf("%d%%", *dev.Battery)})
+	}
+	return rows
+}
+
+// deviceProfileRows lists the profiles of a device that can be connected
+// one at a time, marking the one "+" and "-" act on.
+func (m Model) deviceProfileRows(dev *models.Device) [][2]string {
+	selected, _ := m.profileOf(dev)
+	var rows [][2]string
+	for _, uuid := range dev.Profiles() {
+		marker := "  "
+		if uuid == selected {
+			marker = "> "
+			if Emoji(EmojiSelector) != "" {
+				marker = Emoji(EmojiSelector) + " "
+			}
+		}
+		value := models.FormatUUID(uuid)
+		if name := models.ServiceName(uuid); name != "" {
+			value = name + "  " + MutedStyle.Render(value)
+		}
+		rows = append(rows, [2]string{marker + models.ProfileName(uuid), value})
+	}
+	return rows
+}
+
+// profileOf returns the UUID of the profile of dev selected in the
+// detail panel.
+func (m Model) profileOf(dev *models.Device) (string, bool) {
+	profiles := dev.Profiles()
+	if len(profiles) == 0 {
+		return "", false
+	}
+	return profiles[max(min(m.selectedProfile, len(profiles)-1), 0)], true
+}
+
+// selectProfile moves the profile selection of the detail panel.
+func (m *Model) selectProfile(next bool) {
+	count := 0
+	if dev := m.GetSelectedDevice(); dev != nil {
+		count = len(dev.Profiles())
+	}
+	// The selection may be left over from a device with more profiles
+	current := max(min(m.selectedProfile, count-1), 0)
+	if next {
+		current++
+	} else {
+		current--
+	}
+	m.selectedProfile = max(min(current, count-1), 0)
+}
+
+// deviceClassRows breaks the Class of Device down into its fields.
+func deviceClassRows(dev *models.Device, deviceType models.DeviceType) [][2]string {
+	if dev.Class == 0 {
+		return nil
+	}
+	minor := fmt.Sprintf("0x%02x", dev.MinorClass())
+	if deviceType.MinorClass != "" {
+		minor = deviceType.MinorClass + " (" + minor + ")"
+	}
+	return [][2]string{
+		{"Class", fmt.Sprintf("0x%06x", dev.Class)},
+		{i18n.T.DetailsMajorClass, deviceType.MajorClass},
+		{i18n.T.DetailsMinorClass, minor},
+		{i18n.T.DetailsServiceClasses, strings.Join(deviceType.ServiceClasses, ", ")},
+	}
+}
+
+// deviceServiceRows lists the service UUIDs of a device with their names.
+func deviceServiceRows(dev *models.Device) [][2]string {
+	rows := make([][2]string, 0, len(dev.UUIDs))
+	for _, uuid := range dev.UUIDs {
+		name := models.ServiceName(uuid)
+		if name == "" {
+			name = i18n.T.DetailsUnknownService
+		}
+		rows = append(rows, [2]string{models.FormatUUID(uuid), name})
+	}
+	return rows
+}
+
+// deviceAdvertisingRows lists the raw advertisement payloads as hex.
+func deviceAdvertisingRows(dev *models.Device) [][2]string {
+	var rows [][2]string
+
+	companies := make([]uint16, 0, len(dev.ManufacturerData))
+	for company := range dev.ManufacturerData {
+		companies = append(companies, company)
+	}
+	sort.Slice(companies, func(i, j int) bool { return companies[i] < companies[j] })
+	for _, company := range companies {
+		label := fmt.Sprintf("Manufacturer 0x%04x", company)
+		value := formatHex(dev.ManufacturerData[company])
+		if name := models.CompanyName(company); name != "" {
+			value += "  " + MutedStyle.Render(name)
+		}
+		rows = append(rows, [2]string{label, value})
+	}
+
+	services := make([]string, 0, len(dev.ServiceData))
+	for uuid := range dev.ServiceData {
+		services = append(services, uuid)
+	}
+	sort.Strings(services)
+	for _, uuid := range services {
+		label := "Service " + models.FormatUUID(uuid)
+		value := formatHex(dev.ServiceData[uuid])
+		if name := models.ServiceName(uuid); name != "" {
+			value += "  " + MutedStyle.Render(name)
+		}
+		rows = append(rows, [2]string{label, value})
+	}
+
+	types := make([]byte, 0, len(dev.AdvertisingData))
+	for adType := range dev.AdvertisingData {
+		types = append(types, adType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	for _, adType := range types {
+		label := fmt.Sprintf("AD 0x%02x", adType)
+		rows = append(rows, [2]string{label, formatHex(dev.AdvertisingData[adType])})
+	}
+
+	if len(dev.AdvertisingFlags) > 0 {
+		rows = append(rows, [2]string{"AdvertisingFlags", formatHex(dev.AdvertisingFlags)})
+	}
+
+	return rows
+}
+
+// formatBool renders a boolean property as a localized yes/no.
+func formatBool(value bool) string {
+	if value {
+		return SuccessStyle.Render(i18n.T.DetailsYes)
+	}
+	return MutedStyle.Render(i18n.T.DetailsNo)
+}
+
+// formatHex renders a payload as space separated hex bytes.
+func formatHex(data []byte) string {
+	return fmt.Sprintf("% x", data)
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ivangsm/blugo/internal/config"
+	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+func TestModel_RenderDeviceDetails(t *testing.T) {
+	i18n.SetLanguage(i18n.English)
+
+	originalConfig := config.Global
+	defer func() { config.Global = originalConfig }()
+	config.Global = &config.Config{MaxTerminalWidth: 140, ShowEmojis: true}
+
+	dev := &models.Device{
+		Path:             "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF",
+		Address:          "AA:BB:CC:DD:EE:FF",
+		AddressType:      "public",
+		Name:             "Headphones",
+		Paired:           true,
+		Bonded:           true,
+		Class:            0x240418,
+		UUIDs:            []string{"0000110b-0000-1000-8000-00805f9b34fb", "12345678-1234-1234-1234-123456789abc"},
+		ManufacturerData: map[uint16][]byte{0x009e: {0x01, 0xab}},
+		ServiceData:      map[string][]byte{"0000fe2c-0000-1000-8000-00805f9b34fb": {0xff}},
+	}
+
+	newModel := func(width int) Model {
+		m := NewModel()
+		m.width = width
+		m.showDetails = true
+		m.devices[dev.Address] = dev
+		m.deviceOrder = []string{dev.Address}
+		m.initDevicesTable()
+		return m
+	}
+
+	t.Run("shows every section", func(t *testing.T) {
+		m := newModel(140)
+		result := m.renderDeviceDetails(m.columnWidth())
+
+		for _, want := range []string{
+			"Headphones (Audio)", "AA:BB:CC:DD:EE:FF", "public", "Bonded",
+			"0x240418", "Audio/Video", "Headphones (0x06)", "Rendering, Audio",
+			"0x110B", "Audio Sink (A2DP)", "Unknown service",
+			"Manufacturer 0x009e", "01 ab", "Bose Corporation", "Service 0xFE2C", "ff",
+		} {
+			if !strings.Contains(result, want) {
+				t.Errorf("renderDeviceDetails() should contain %q", want)
+			}
+		}
+	})
+
+	t.Run("renders empty state without a selection", func(t *testing.T) {
+		m := NewModel()
+		m.width = 80
+		result := m.renderDeviceDetails(80)
+		if !strings.Contains(result, i18n.T.NoDeviceSelected) {
+			t.Errorf("renderDeviceDetails() should show the empty state")
+		}
+	})
+
+	t.Run("uses a side panel on wide terminals", func(t *testing.T) {
+		m := newModel(140)
+		if !m.detailsSideBySide() {
+			t.Fatalf("detailsSideBySide() should be true at width 140")
+		}
+		if m.devicesWidth() != m.columnWidth() {
+			t.Errorf("devicesWidth() = %d, want column width %d", m.devicesWidth(), m.columnWidth())
+		}
+	})
+
+	t.Run("uses a modal on narrow terminals", func(t *testing.T) {
+		m := newModel(100)
+		if m.detailsSideBySide() {
+			t.Fatalf("detailsSideBySide() should be false at width 100")
+		}
+		if m.devicesWidth() != 100 {
+			t.Errorf("devicesWidth() = %d, want 100", m.devicesWidth())
+		}
+	})
+}
+
+func TestFormatHex(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{nil, ""},
+		{[]byte{0x06}, "06"},
+		{[]byte{0x4c, 0x00, 0x02, 0x15}, "4c 00 02 15"},
+	}
+
+	for _, tt := range tests {
+		if got := formatHex(tt.data); got != tt.want {
+			t.Errorf("formatHex(%v) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
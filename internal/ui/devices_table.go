@@ -8,14 +8,15 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ivangsm/blugo/internal/config"
 	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
 )
 
 // initDevicesTable initializes the devices table with current devices
 func (m *Model) initDevicesTable() {
 	devices := m.GetFoundDevices()
 
-	// Use effective width
-	effectiveWidth := min(m.width, GetMaxWidth())
+	// Use the width left by the detail panel, if any
+	effectiveWidth := m.devicesWidth()
 	if effectiveWidth <= 0 {
 		effectiveWidth = 80
 	}
@@ -30,6 +31,12 @@ func (m *Model) initDevicesTable() {
 		showBattery = config.Global.ShowBattery
 	}
 
+	// Next to the detail panel, leave address and battery to the panel
+	if m.showDetails && m.detailsSideBySide() {
+		showAddress = false
+		showBattery = false
+	}
+
 	// The panel will use (effectiveWidth - 4) for its content width
 	// We need to account for table's internal spacing (columns have separators)
 	// Each column adds ~3 chars for spacing/borders
@@ -117,11 +124,11 @@ func (m *Model) initDevicesTable() {
 		icon := dev.GetIcon()
 
 		// Name
-		name := dev.GetDisplayName()
+		name := deviceListName(dev)
 
 		// Status (badges)
 		status := ""
-		if dev.Connected {
+		if dev.State() == models.StateConnected {
 			status = i18n.T.BadgeConnected
 		} else if dev.Paired {
 			status = i18n.T.BadgePaired
@@ -132,6 +139,17 @@ func (m *Model) initDevicesTable() {
 			}
 			status += i18n.T.BadgeTrusted
 		}
+		if m.reconnect.Enabled(dev.Address) {
+			if status != "" {
+				status += " "
+			}
+			status += i18n.T.BadgeAutoReconnect
+		}
+
+		// A running operation or change of state takes the place of the badges
+		if progress := m.operationStatus(dev.Address); progress != "" {
+			status = progress
+		}
 
 		// Build row dynamically based on which columns are shown
 		row := table.Row{icon, name}
@@ -206,8 +224,8 @@ func (m *Model) initDevicesTable() {
 func (m Model) renderDevicesTable() string {
 	devices := m.GetFoundDevices()
 
-	// Use effective width
-	effectiveWidth := min(m.width, GetMaxWidth())
+	// Use the width left by the detail panel, if any
+	effectiveWidth := m.devicesWidth()
 
 	// FocusedPanelStyle has Padding(1, 2) and borders
 	// Border = 2 chars, Padding = 4 chars (2 on each side) = 6 total
@@ -220,7 +238,7 @@ func (m Model) renderDevicesTable() string {
 	// Header
 	header := renderSectionHeader(
 		Emoji(EmojiAvailable),
-		i18n.T.AvailableDevices,
+		m.availableDevicesTitle(),
 		len(devices),
 		true,
 	)
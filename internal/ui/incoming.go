@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// incomingPairingDuration is how long the adapter stays visible while
+// waiting for a phone to pair with it.
+const incomingPairingDuration = 2 * time.Minute
+
+// incomingPairing is the "pair from phone" wizard: the adapter is made
+// discoverable and pairable until a device pairs with it or the countdown
+// ends, and then put back the way it was.
+type incomingPairing struct {
+	started         time.Time
+	deadline        time.Time
+	wasDiscoverable bool
+	wasPairable     bool
+	device          *models.Device // Device pairing with us, once its agent request arrived
+}
+
+// remaining returns the time left on the countdown at now.
+func (p *incomingPairing) remaining(now time.Time) time.Duration {
+	if left := p.deadline.Sub(now); left > 0 {
+		return left
+	}
+	return 0
+}
+
+// pairedWith reports whether dev becoming paired completes the wizard:
+// it is the device whose request was shown, or any device when none was,
+// e.g. with Just Works pairing allowed by the authorization policy.
+func (p *incomingPairing) pairedWith(dev *models.Device) bool {
+	return p.device == nil || models.NormalizeMAC(p.device.Address) == models.NormalizeMAC(dev.Address)
+}
+
+// formatCountdown renders d as minutes and seconds, e.g. "1:05".
+func formatCountdown(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// renderIncomingPairing renders the wizard's instructions and countdown.
+func (m Model) renderIncomingPairing() string {
+	name := ""
+	if m.adapter != nil {
+		name = m.adapter.GetDisplayName()
+	}
+
+	title := i18n.T.IncomingTitle
+	if Emoji(EmojiPairingKey) != "" {
+		title = Emoji(EmojiPairingKey) + " " + title
+	}
+	lines := []string{title, ""}
+	if m.incoming.device != nil {
+		lines = append(lines, InfoStyle.Render(fmt.Sprintf(i18n.T.PairingDevice, deviceListName(m.incoming.device))))
+	} else {
+		lines = append(lines, fmt.Sprintf(i18n.T.IncomingInstruction, name))
+	}
+	lines = append(lines,
+		"",
+		WarningStyle.Render(fmt.Sprintf(i18n.T.IncomingCountdown, formatCountdown(m.incoming.remaining(time.Now())))),
+		HelpStyle.Render(i18n.T.IncomingCancel),
+	)
+
+	content := lipgloss.JoinVertical(lipgloss.Center, lines...)
+
+	// Use effective width
+	effectiveWidth := min(m.width, GetMaxWidth())
+
+	if effectiveWidth > 0 {
+		return PasskeyBoxStyle.Width(min(effectiveWidth-4, 70)).Render(content)
+	}
+
+	return PasskeyBoxStyle.Render(content)
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ivangsm/blugo/internal/agent"
+	"github.com/ivangsm/blugo/internal/bluetooth"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// newIncomingTestModel returns a model with a powered adapter that is
+// neither discoverable nor pairable. The manager is never called: the
+// commands the wizard returns are not run.
+func newIncomingTestModel() Model {
+	m := NewModel()
+	m.manager = &bluetooth.Manager{}
+	m.adapter = &models.Adapter{Path: "/org/bluez/hci0", Alias: "Kiosk", Powered: true}
+	return m
+}
+
+func TestModel_IncomingPairing(t *testing.T) {
+	phone := &models.Device{Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF", Address: "AA:BB:CC:DD:EE:FF", Name: "Phone"}
+	other := &models.Device{Path: "/org/bluez/hci0/dev_11_22_33_44_55_66", Address: "11:22:33:44:55:66", Name: "Speaker"}
+
+	m := newIncomingTestModel()
+	model, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m = model.(Model)
+	if m.incoming == nil || cmd == nil {
+		t.Fatalf("w should start the wizard")
+	}
+	if m.incoming.wasDiscoverable || m.incoming.wasPairable {
+		t.Errorf("the previous adapter settings should be remembered")
+	}
+	if panel := m.renderIncomingPairing(); !strings.Contains(panel, "Kiosk") || !strings.Contains(panel, "2:00") {
+		t.Errorf("the panel should name the adapter and show the countdown:\n%s", panel)
+	}
+
+	// The phone asks to compare passkeys
+	model, _ = m.Update(agent.ConfirmPasskeyMsg{Device: phone, Passkey: 123456})
+	m = model.(Model)
+	if m.incoming.device != phone || m.pairingPasskey == nil {
+		t.Fatalf("the wizard should wait for the phone and show its passkey")
+	}
+
+	// Another device pairing does not end the wizard
+	m.devices[other.Address] = other
+	paired := *other
+	paired.Paired = true
+	if cmd := m.checkIncomingPaired(other, &paired); cmd != nil || m.incoming == nil {
+		t.Errorf("only the phone should complete the wizard")
+	}
+
+	paired = *phone
+	paired.Paired = true
+	if cmd := m.checkIncomingPaired(phone, &paired); cmd == nil {
+		t.Fatalf("the adapter settings should be restored")
+	}
+	if m.incoming != nil {
+		t.Errorf("the wizard should be over")
+	}
+	if !strings.Contains(m.statusMessage, "Phone") {
+		t.Errorf("statusMessage = %q, want it to name the phone", m.statusMessage)
+	}
+}
+
+func TestModel_IncomingPairingTick(t *testing.T) {
+	tests := []struct {
+		name     string
+		left     time.Duration
+		pending  bool
+		wantOver bool
+	}{
+		{"counting down", time.Minute, false, false},
+		{"time is up", -time.Second, false, true},
+		{"time is up while the phone is answered", -time.Second, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newIncomingTestModel()
+			model, _ := m.startIncomingPairing()
+			m = model.(Model)
+			m.incoming.deadline = time.Now().Add(tt.left)
+			if tt.pending {
+				m.input = &inputPrompt{device: &models.Device{Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF"}}
+			}
+
+			model, cmd := m.handleIncomingPairingTick(IncomingPairingTickMsg{Started: m.incoming.started})
+			m = model.(Model)
+			if over := m.incoming == nil; over != tt.wantOver {
+				t.Errorf("wizard over = %v, want %v", over, tt.wantOver)
+			}
+			if cmd == nil {
+				t.Errorf("expected the next tick or the restore command")
+			}
+		})
+	}
+}
+
+func TestModel_IncomingPairing_Cancel(t *testing.T) {
+	m := newIncomingTestModel()
+	m.adapter.Discoverable = true
+	model, _ := m.startIncomingPairing()
+	m = model.(Model)
+
+	stale := IncomingPairingTickMsg{Started: m.incoming.started.Add(-time.Minute)}
+	if _, cmd := m.handleIncomingPairingTick(stale); cmd != nil {
+		t.Errorf("ticks of an earlier run should be ignored")
+	}
+
+	model, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(Model)
+	if m.incoming != nil || cmd == nil {
+		t.Errorf("Esc should stop the wizard and restore the adapter")
+	}
+}
+
+func TestFormatCountdown(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{2 * time.Minute, "2:00"},
+		{65 * time.Second, "1:05"},
+		{400 * time.Millisecond, "0:00"},
+	}
+
+	for _, tt := range tests {
+		if got := formatCountdown(tt.d); got != tt.want {
+			t.Errorf("formatCountdown(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/ivangsm/blugo/internal/agent"
 	"github.com/ivangsm/blugo/internal/bluetooth"
 	"github.com/ivangsm/blugo/internal/models"
@@ -26,6 +27,11 @@ type DeviceUpdateMsg struct {
 	Devices map[string]*models.Device
 }
 
+// BluetoothEventMsg carries an incremental change pushed by BlueZ signals.
+type BluetoothEventMsg struct {
+	Event bluetooth.Event
+}
+
 // StatusMsg shows a status message.
 type StatusMsg struct {
 	Message string
@@ -39,6 +45,45 @@ type ConnectResultMsg struct {
 	Err     error
 }
 
+// DeviceOperationMsg is sent when a queued operation on a device ends.
+// ID is the one startOperation returned for it. Result is the operation's
+// own message, handled once the operation is dropped from the device's
+// queue.
+type DeviceOperationMsg struct {
+	Address string
+	ID      int
+	Result  tea.Msg
+}
+
+// OperationAttemptMsg reports that an operation on a device failed with a
+// transient error and is being attempted again.
+type OperationAttemptMsg struct {
+	Address  string
+	Attempt  int // Number of the attempt starting now
+	Attempts int // Attempts allowed in total
+}
+
+// ProfileResultMsg indicates the result of connecting or disconnecting a
+// single profile.
+type ProfileResultMsg struct {
+	Address string
+	UUID    string
+	Connect bool
+	Err     error
+}
+
+// ReconnectDueMsg says it is time to reconnect a device marked for
+// auto-reconnect.
+type ReconnectDueMsg struct {
+	Address string
+}
+
+// ReconnectResultMsg indicates the result of an automatic reconnect.
+type ReconnectResultMsg struct {
+	Address string
+	Err     error
+}
+
 // PairResultMsg indicates the result of a pairing operation.
 type PairResultMsg struct {
 	Address string
@@ -46,23 +91,32 @@ type PairResultMsg struct {
 	Err     error
 }
 
-// PasskeyDisplayMsg requests to display a passkey.
-type PasskeyDisplayMsg struct {
-	Passkey uint32
-	Device  string
-}
-
 // PasskeyConfirmedMsg indicates that the passkey was confirmed.
 type PasskeyConfirmedMsg struct{}
 
 // AdapterUpdateMsg contains updated adapter information.
 type AdapterUpdateMsg struct {
-	Adapter *models.Adapter
+	Adapter  *models.Adapter
+	Adapters []*models.Adapter // Every adapter known to BlueZ
+}
+
+// AdapterSwitchedMsg indicates that the active adapter changed.
+type AdapterSwitchedMsg struct {
+	Adapter  *models.Adapter
+	Scanning bool
+	Err      error
+}
+
+// DiscoveryFilterMsg indicates that the discovery filter was applied.
+type DiscoveryFilterMsg struct {
+	Filter bluetooth.DiscoveryFilter
+	Err    error
 }
 
 // AdapterPropertyChangedMsg indicates that an adapter property changed.
 type AdapterPropertyChangedMsg struct {
 	Property string
+	Value    bool // New value of the property
 	Success  bool
 	Err      error
 }
@@ -75,3 +129,15 @@ type ForgetDeviceMsg struct {
 
 // TickMsg is a clock tick for periodic updates.
 type TickMsg time.Time
+
+// IncomingPairingMsg reports whether the adapter was made visible for the
+// pair from phone wizard.
+type IncomingPairingMsg struct {
+	Err error
+}
+
+// IncomingPairingTickMsg advances the pair from phone countdown. Started
+// identifies the wizard run it belongs to.
+type IncomingPairingTickMsg struct {
+	Started time.Time
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ivangsm/blugo/internal/bluetooth"
 	"github.com/ivangsm/blugo/internal/models"
 )
 
@@ -197,46 +198,6 @@ func TestPairResultMsg(t *testing.T) {
 	}
 }
 
-func TestPasskeyDisplayMsg(t *testing.T) {
-	tests := []struct {
-		name    string
-		passkey uint32
-		device  string
-	}{
-		{
-			name:    "valid passkey",
-			passkey: 123456,
-			device:  "My Device",
-		},
-		{
-			name:    "zero passkey",
-			passkey: 0,
-			device:  "Another Device",
-		},
-		{
-			name:    "max passkey",
-			passkey: 999999,
-			device:  "Test Device",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			msg := PasskeyDisplayMsg{
-				Passkey: tt.passkey,
-				Device:  tt.device,
-			}
-
-			if msg.Passkey != tt.passkey {
-				t.Errorf("PasskeyDisplayMsg.Passkey = %v, want %v", msg.Passkey, tt.passkey)
-			}
-			if msg.Device != tt.device {
-				t.Errorf("PasskeyDisplayMsg.Device = %v, want %v", msg.Device, tt.device)
-			}
-		})
-	}
-}
-
 func TestPasskeyConfirmedMsg(t *testing.T) {
 	// Empty struct, just verify it can be created
 	msg := PasskeyConfirmedMsg{}
@@ -312,3 +273,19 @@ func TestTickMsg(t *testing.T) {
 		t.Errorf("TickMsg time = %v, want %v", tickTime, now)
 	}
 }
+
+func TestBluetoothEventMsg(t *testing.T) {
+	dev := &models.Device{Address: "AA:BB:CC:DD:EE:FF"}
+	msg := BluetoothEventMsg{Event: bluetooth.Event{
+		Kind:    bluetooth.DeviceChanged,
+		Address: dev.Address,
+		Device:  dev,
+	}}
+
+	if msg.Event.Kind != bluetooth.DeviceChanged {
+		t.Errorf("BluetoothEventMsg.Event.Kind = %v, want DeviceChanged", msg.Event.Kind)
+	}
+	if msg.Event.Device != dev {
+		t.Errorf("BluetoothEventMsg.Event.Device should be preserved")
+	}
+}
@@ -3,12 +3,14 @@ package ui
 import (
 	"sort"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/ivangsm/blugo/internal/agent"
 	"github.com/ivangsm/blugo/internal/bluetooth"
 	"github.com/ivangsm/blugo/internal/config"
+	"github.com/ivangsm/blugo/internal/i18n"
 	"github.com/ivangsm/blugo/internal/models"
 )
 
@@ -17,6 +19,7 @@ type Model struct {
 	manager           *bluetooth.Manager
 	agent             *agent.Agent
 	adapter           *models.Adapter
+	adapters          []*models.Adapter // All adapters, sorted by path
 	devices           map[string]*models.Device
 	deviceOrder       []string // Track insertion order of device addresses
 	selectedIndex     int
@@ -24,16 +27,140 @@ type Model struct {
 	statusMessage     string
 	isError           bool
 	scanning          bool
-	busy              bool
+	resumeScanning    bool                         // Restart discovery once an adapter becomes available
+	bluezUnavailable  bool                         // bluetoothd left the bus and has not come back yet
+	statusPending     bool                         // The status message describes an operation still running
+	operations        map[string][]deviceOperation // Operations per device address, running one first
+	nextOperation     int                          // Id of the last operation started
+	progress          chan tea.Msg                 // Progress reported by running operations
+	reconnect         *bluetooth.Reconnector       // Devices reconnected automatically
+	spinner           spinner.Model                // Shown next to devices changing state
+	spinning          bool                         // The spinner is animating
 	err               error
 	pairingPasskey    *uint32
+	passkeyEntered    *uint16        // Digits typed on the remote device; nil when the passkey is confirmed here
+	pairingPinCode    string         // PIN to type on the remote device
+	pairingDevice     *models.Device // Device the pairing prompt is about, when known
 	waitingForPasskey bool
-	width             int // Terminal width
-	height            int // Terminal height
+	input             *inputPrompt         // PIN or passkey the agent is waiting for
+	authorization     *authorizationPrompt // Pairing or service use waiting for the user's approval
+	incoming          *incomingPairing     // Pair from phone wizard, while waiting for the phone
+	width             int                  // Terminal width
+	height            int                  // Terminal height
 	viewport          viewport.Model
-	ready             bool          // Indicates if the viewport is ready
-	showHelp          bool          // Toggle for showing full help
-	devicesTable      table.Model   // Table for displaying devices
+	ready             bool                  // Indicates if the viewport is ready
+	showHelp          bool                  // Toggle for showing full help
+	showDetails       bool                  // Toggle for the selected device's detail panel
+	selectedProfile   int                   // Profile selected in the detail panel
+	typeFilter        models.DeviceCategory // Only list devices of this category
+	devicesTable      table.Model           // Table for displaying devices
+}
+
+// pairingPromptOpen reports whether a passkey or PIN is being shown.
+func (m Model) pairingPromptOpen() bool {
+	return m.pairingPasskey != nil || m.pairingPinCode != ""
+}
+
+// typedRemotely reports whether the code shown in the pairing prompt is
+// typed on the remote device rather than confirmed here.
+func (m Model) typedRemotely() bool {
+	return m.passkeyEntered != nil || m.pairingPinCode != ""
+}
+
+// closePairingPrompt hides the passkey or PIN being shown.
+func (m *Model) closePairingPrompt() {
+	m.pairingPasskey = nil
+	m.passkeyEntered = nil
+	m.pairingPinCode = ""
+	m.pairingDevice = nil
+}
+
+// pendingRequest returns the device whose agent request the open prompt
+// answers, or nil when no prompt waits for an answer.
+func (m Model) pendingRequest() *models.Device {
+	switch {
+	case m.input != nil:
+		return m.input.device
+	case m.authorization != nil:
+		return m.authorization.device
+	case m.pairingPromptOpen() && !m.typedRemotely():
+		return m.pairingDevice
+	}
+	return nil
+}
+
+// replacePendingRequest makes room for a prompt about dev. A prompt left
+// open for another device is rejected so its request does not linger
+// until the deadline; one for dev itself was superseded by the agent.
+func (m *Model) replacePendingRequest(dev *models.Device) {
+	if pending := m.pendingRequest(); pending != nil && pending.Path != dev.Path && m.agent != nil {
+		m.agent.Reject(pending.Path)
+	}
+	m.input = nil
+	m.authorization = nil
+	m.closePairingPrompt()
+
+	// The pair from phone wizard waits for this device from now on
+	if m.incoming != nil {
+		m.incoming.device = dev
+	}
+}
+
+// authorizationPrompt is a device asking to pair without a code, or to use
+// a service, that the authorization policy leaves to the user.
+type authorizationPrompt struct {
+	device *models.Device
+	uuid   string // Service requested; empty for pairing
+}
+
+// profile names the requested service, or pairing.
+func (p *authorizationPrompt) profile() string {
+	if p.uuid == "" {
+		return i18n.T.AuthorizePairingService
+	}
+	return profileName(p.uuid)
+}
+
+// profileName names a service UUID, falling back to the UUID itself.
+func profileName(uuid string) string {
+	if name := models.ServiceName(uuid); name != "" {
+		return name
+	}
+	return models.FormatUUID(uuid)
+}
+
+// passkeyDigits is the length of a passkey as shown to the user.
+const passkeyDigits = 6
+
+// inputPrompt is a PIN or passkey the user is typing for the agent.
+type inputPrompt struct {
+	device  *models.Device
+	passkey bool   // Six digits instead of a free-form PIN
+	value   string // Typed so far
+}
+
+// accepts reports whether value is a valid, possibly incomplete, answer.
+func (p *inputPrompt) accepts(value string) bool {
+	if !p.passkey {
+		return agent.ValidPinCode(value)
+	}
+	if len(value) > passkeyDigits {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// complete reports whether the typed value can be sent.
+func (p *inputPrompt) complete() bool {
+	if p.passkey {
+		return len(p.value) == passkeyDigits
+	}
+	return p.value != ""
 }
 
 // NewModel creates a new UI model.
@@ -43,6 +170,9 @@ func NewModel() Model {
 		deviceOrder:   make([]string, 0),
 		focusSection:  "found",
 		selectedIndex: 0,
+		progress:      make(chan tea.Msg, 16),
+		reconnect:     reconnectorFromConfig(),
+		spinner:       newSpinner(),
 	}
 }
 
@@ -50,6 +180,7 @@ func NewModel() Model {
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		tickCmd(),
+		listenProgressCmd(m.progress),
 	)
 }
 
@@ -83,6 +214,11 @@ func (m Model) GetFoundDevices() []*models.Device {
 			continue
 		}
 
+		// Filter by device type if one is selected
+		if !m.typeFilter.Matches(dev.Type().Kind) {
+			continue
+		}
+
 		devices = append(devices, dev)
 	}
 
@@ -102,6 +238,80 @@ func (m Model) GetFoundDevices() []*models.Device {
 	return devices
 }
 
+// removeDevice drops a device from the local cache and the ordering list.
+func (m *Model) removeDevice(address string) {
+	delete(m.devices, address)
+
+	for i, addr := range m.deviceOrder {
+		if addr == address {
+			m.deviceOrder = append(m.deviceOrder[:i], m.deviceOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// nextAdapter returns the adapter after the active one, wrapping around.
+// Returns nil when there is nothing to switch to.
+func (m Model) nextAdapter() *models.Adapter {
+	if len(m.adapters) < 2 || m.adapter == nil {
+		return nil
+	}
+	for i, adapter := range m.adapters {
+		if adapter.Path == m.adapter.Path {
+			return m.adapters[(i+1)%len(m.adapters)]
+		}
+	}
+	return m.adapters[0]
+}
+
+// nextTypeFilter returns the device category after current in
+// models.DeviceCategories, wrapping back to all devices.
+func nextTypeFilter(current models.DeviceCategory) models.DeviceCategory {
+	for i, category := range models.DeviceCategories {
+		if category == current {
+			return models.DeviceCategories[(i+1)%len(models.DeviceCategories)]
+		}
+	}
+	return models.CategoryAll
+}
+
+// discoveryRSSISteps are the RSSI thresholds cycled through from the TUI.
+var discoveryRSSISteps = []int{0, -90, -80, -70, -60, -50}
+
+// nextTransportFilter returns filter with the next transport selected
+// (auto -> le -> bredr -> auto).
+func nextTransportFilter(filter bluetooth.DiscoveryFilter) bluetooth.DiscoveryFilter {
+	switch filter.Transport {
+	case bluetooth.TransportLE:
+		filter.Transport = bluetooth.TransportBREDR
+	case bluetooth.TransportBREDR:
+		filter.Transport = bluetooth.TransportAuto
+	default:
+		filter.Transport = bluetooth.TransportLE
+	}
+	return filter
+}
+
+// nextRSSIFilter returns filter with the next RSSI threshold selected.
+// A pathloss threshold is dropped since BlueZ does not allow both.
+func nextRSSIFilter(filter bluetooth.DiscoveryFilter) bluetooth.DiscoveryFilter {
+	next := 0
+	for i, rssi := range discoveryRSSISteps {
+		if filter.RSSI == rssi {
+			next = discoveryRSSISteps[(i+1)%len(discoveryRSSISteps)]
+			break
+		}
+		// Custom thresholds from the config jump to the next stricter step
+		if rssi != 0 && filter.RSSI < rssi {
+			next = rssi
+			break
+		}
+	}
+	filter.RSSI = next
+	filter.Pathloss = 0
+	return filter
+}
+
 // GetConnectedDevices returns the connected devices.
 func (m Model) GetConnectedDevices() []*models.Device {
 	devices := make([]*models.Device, 0)
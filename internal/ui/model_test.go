@@ -1,10 +1,12 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/bluetooth"
 	"github.com/ivangsm/blugo/internal/config"
 	"github.com/ivangsm/blugo/internal/models"
 )
@@ -637,3 +639,136 @@ func TestModel_Integration(t *testing.T) {
 		t.Errorf("Selected device should be the paired one")
 	}
 }
+
+func TestModel_NextAdapter(t *testing.T) {
+	hci0 := &models.Adapter{Path: "/org/bluez/hci0"}
+	hci1 := &models.Adapter{Path: "/org/bluez/hci1"}
+
+	t.Run("returns nil with a single adapter", func(t *testing.T) {
+		m := Model{adapter: hci0, adapters: []*models.Adapter{hci0}}
+		if m.nextAdapter() != nil {
+			t.Errorf("nextAdapter() should be nil with one adapter")
+		}
+	})
+
+	t.Run("returns the following adapter", func(t *testing.T) {
+		m := Model{adapter: hci0, adapters: []*models.Adapter{hci0, hci1}}
+		if got := m.nextAdapter(); got != hci1 {
+			t.Errorf("nextAdapter() = %v, want hci1", got)
+		}
+	})
+
+	t.Run("wraps around to the first adapter", func(t *testing.T) {
+		m := Model{adapter: hci1, adapters: []*models.Adapter{hci0, hci1}}
+		if got := m.nextAdapter(); got != hci0 {
+			t.Errorf("nextAdapter() = %v, want hci0", got)
+		}
+	})
+}
+
+func TestNextTransportFilter(t *testing.T) {
+	tests := []struct {
+		transport string
+		want      string
+	}{
+		{"", bluetooth.TransportLE},
+		{bluetooth.TransportAuto, bluetooth.TransportLE},
+		{bluetooth.TransportLE, bluetooth.TransportBREDR},
+		{bluetooth.TransportBREDR, bluetooth.TransportAuto},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transport, func(t *testing.T) {
+			got := nextTransportFilter(bluetooth.DiscoveryFilter{Transport: tt.transport, RSSI: -70})
+			if got.Transport != tt.want {
+				t.Errorf("Transport = %v, want %v", got.Transport, tt.want)
+			}
+			if got.RSSI != -70 {
+				t.Errorf("RSSI should be kept, got %v", got.RSSI)
+			}
+		})
+	}
+}
+
+func TestNextRSSIFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		rssi int
+		want int
+	}{
+		{"off to weakest step", 0, -90},
+		{"next step", -90, -80},
+		{"strongest step wraps to off", -50, 0},
+		{"custom value jumps to next step", -75, -70},
+		{"custom value below every step", -100, -90},
+		{"custom value above every step", -40, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextRSSIFilter(bluetooth.DiscoveryFilter{RSSI: tt.rssi, Pathloss: 40})
+			if got.RSSI != tt.want {
+				t.Errorf("RSSI = %v, want %v", got.RSSI, tt.want)
+			}
+			if got.Pathloss != 0 {
+				t.Errorf("Pathloss should be cleared, got %v", got.Pathloss)
+			}
+		})
+	}
+}
+
+func TestModel_GetFoundDevices_TypeFilter(t *testing.T) {
+	originalConfig := config.Global
+	defer func() { config.Global = originalConfig }()
+	config.Global = &config.Config{MinRSSIThreshold: -100}
+
+	m := Model{
+		devices: map[string]*models.Device{
+			"AA:AA:AA:AA:AA:AA": {Address: "AA:AA:AA:AA:AA:AA", Name: "Headphones", Class: 0x240418},
+			"BB:BB:BB:BB:BB:BB": {Address: "BB:BB:BB:BB:BB:BB", Name: "Earbuds", Appearance: 0x0941},
+			"CC:CC:CC:CC:CC:CC": {Address: "CC:CC:CC:CC:CC:CC", Name: "Keyboard", Icon: "input-keyboard"},
+			"DD:DD:DD:DD:DD:DD": {Address: "DD:DD:DD:DD:DD:DD", Name: "Beacon"},
+		},
+		deviceOrder: []string{"AA:AA:AA:AA:AA:AA", "BB:BB:BB:BB:BB:BB", "CC:CC:CC:CC:CC:CC", "DD:DD:DD:DD:DD:DD"},
+	}
+
+	tests := []struct {
+		filter models.DeviceCategory
+		want   []string
+	}{
+		{models.CategoryAll, []string{"Headphones", "Earbuds", "Keyboard", "Beacon"}},
+		{models.CategoryAudio, []string{"Headphones", "Earbuds"}},
+		{models.CategoryInput, []string{"Keyboard"}},
+		{models.CategoryOther, []string{"Beacon"}},
+		{models.CategoryHealth, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(categoryLabel(tt.filter), func(t *testing.T) {
+			m.typeFilter = tt.filter
+			var got []string
+			for _, dev := range m.GetFoundDevices() {
+				got = append(got, dev.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("GetFoundDevices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextTypeFilter(t *testing.T) {
+	category := models.CategoryAll
+	for range models.DeviceCategories {
+		category = nextTypeFilter(category)
+	}
+	if category != models.CategoryAll {
+		t.Errorf("cycling through every category should wrap to CategoryAll, got %v", category)
+	}
+	if got := nextTypeFilter(models.CategoryAll); got != models.CategoryAudio {
+		t.Errorf("nextTypeFilter(CategoryAll) = %v, want CategoryAudio", got)
+	}
+	if got := nextTypeFilter(models.DeviceCategory(99)); got != models.CategoryAll {
+		t.Errorf("nextTypeFilter(unknown) = %v, want CategoryAll", got)
+	}
+}
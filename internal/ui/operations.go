@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// deviceOperation is a connect, disconnect or forget started from the
+// list. The manager runs the operations of one device in the order they
+// were started; the UI keeps the same queue to show progress on its row.
+type deviceOperation struct {
+	id      int                // Matches the operation's DeviceOperationMsg
+	label   string             // Shown in the device's status column while it runs
+	pairing bool               // The operation pairs first, so the agent may prompt
+	cancel  context.CancelFunc // Abandons the operation
+}
+
+// startOperation records op as queued for the device at address and
+// returns its id, which the operation's DeviceOperationMsg carries back.
+func (m *Model) startOperation(address string, op deviceOperation) int {
+	if m.operations == nil {
+		m.operations = make(map[string][]deviceOperation)
+	}
+	m.nextOperation++
+	op.id = m.nextOperation
+	m.operations[address] = append(m.operations[address], op)
+	return op.id
+}
+
+// finishOperation drops the operation with the given id from the queue of
+// the device at address. Results need not arrive in the order the
+// operations were started, so it is looked up rather than taken first.
+func (m *Model) finishOperation(address string, id int) {
+	ops := m.operations[address]
+	for i, op := range ops {
+		if op.id != id {
+			continue
+		}
+		if op.cancel != nil {
+			op.cancel()
+		}
+		if len(ops) == 1 {
+			delete(m.operations, address)
+			return
+		}
+		m.operations[address] = append(ops[:i:i], ops[i+1:]...)
+		return
+	}
+}
+
+// deviceName names the device at address for status messages.
+func (m Model) deviceName(address string) string {
+	if dev, ok := m.devices[address]; ok {
+		return dev.GetDisplayName()
+	}
+	return address
+}
+
+// pairingInProgress reports whether any queued operation pairs a device.
+func (m Model) pairingInProgress() bool {
+	for _, ops := range m.operations {
+		for _, op := range ops {
+			if op.pairing {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// operationStatus describes the operations of the device at address for
+// its row, or returns "" when it has none and is not changing state. Once
+// BlueZ is at it, the device's state says more than the operation's label.
+func (m Model) operationStatus(address string) string {
+	ops := m.operations[address]
+	state := models.StateDisconnected
+	if dev, ok := m.devices[address]; ok {
+		state = dev.State()
+	}
+
+	var status string
+	switch {
+	case state.Transitional():
+		status = stateLabel(state)
+	case len(ops) > 0:
+		status = ops[0].label
+	default:
+		return ""
+	}
+
+	status = m.spinner.View() + " " + status
+	if len(ops) > 1 {
+		status += " " + fmt.Sprintf(i18n.T.OpQueued, len(ops)-1)
+	}
+	return status
+}
+
+// stateLabel names a connection state in the current language.
+func stateLabel(state models.ConnectionState) string {
+	switch state {
+	case models.StatePairing:
+		return i18n.T.StatePairing
+	case models.StateConnecting:
+		return i18n.T.StateConnecting
+	case models.StateResolvingServices:
+		return i18n.T.StateResolvingServices
+	case models.StateConnected:
+		return i18n.T.StateConnected
+	case models.StateDisconnecting:
+		return i18n.T.StateDisconnecting
+	}
+	return i18n.T.StateDisconnected
+}
+
+// newSpinner returns the spinner shown next to devices changing state.
+func newSpinner() spinner.Model {
+	return spinner.New(spinner.WithSpinner(spinner.MiniDot))
+}
+
+// changingState reports whether any device has an operation or is on its
+// way to another state, so its row needs the spinner.
+func (m Model) changingState() bool {
+	if len(m.operations) > 0 {
+		return true
+	}
+	for _, dev := range m.devices {
+		if dev.State().Transitional() {
+			return true
+		}
+	}
+	return false
+}
+
+// startSpinner starts animating the spinner if a device needs it and it
+// is not running yet.
+func (m *Model) startSpinner() tea.Cmd {
+	if m.spinning || !m.changingState() {
+		return nil
+	}
+	m.spinning = true
+	return m.spinner.Tick
+}
+
+// handleSpinnerTick advances the spinner, which stops once no device
+// changes state anymore.
+func (m Model) handleSpinnerTick(msg spinner.TickMsg) (tea.Model, tea.Cmd) {
+	if !m.changingState() {
+		m.spinning = false
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	m.initDevicesTable()
+	m.updateViewportContent()
+	return m, cmd
+}
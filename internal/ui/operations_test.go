@@ -0,0 +1,404 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ivangsm/blugo/internal/bluetooth"
+	"github.com/ivangsm/blugo/internal/config"
+	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+// newOperationsTestModel returns a model listing two paired devices. The
+// manager is never called: the commands the keys return are not run.
+func newOperationsTestModel() Model {
+	m := NewModel()
+	m.manager = &bluetooth.Manager{}
+	m.adapter = &models.Adapter{Path: "/org/bluez/hci0", Powered: true}
+	for _, dev := range []*models.Device{
+		{Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF", Address: "AA:BB:CC:DD:EE:FF", Name: "Headphones", Paired: true},
+		{Path: "/org/bluez/hci0/dev_11_22_33_44_55_66", Address: "11:22:33:44:55:66", Name: "Mouse", Paired: true},
+	} {
+		m.devices[dev.Address] = dev
+		m.deviceOrder = append(m.deviceOrder, dev.Address)
+	}
+	m.initDevicesTable()
+	return m
+}
+
+// press sends key to m and returns the updated model and command.
+func press(m Model, key string) (Model, tea.Cmd) {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	}
+	model, cmd := m.handleKeyPress(msg)
+	return model.(Model), cmd
+}
+
+func TestModel_OperationsDoNotLockTheUI(t *testing.T) {
+	i18n.SetLanguage(i18n.English)
+	m := newOperationsTestModel()
+
+	first := m.GetSelectedDevice()
+	m, cmd := press(m, "enter")
+	if cmd == nil || len(m.operations[first.Address]) != 1 {
+		t.Fatalf("enter should queue a connect for %s", first.Name)
+	}
+	if status := m.operationStatus(first.Address); !strings.Contains(status, i18n.T.OpConnecting) {
+		t.Errorf("operationStatus() = %q, want the connect in progress", status)
+	}
+
+	// Navigation, scanning and the other device stay usable meanwhile
+	m, _ = press(m, "down")
+	second := m.GetSelectedDevice()
+	if second == nil || second.Address == first.Address {
+		t.Fatalf("down should move to the other device during the connect")
+	}
+	if _, cmd := press(m, "s"); cmd == nil {
+		t.Errorf("s should toggle scanning during the connect")
+	}
+	m, cmd = press(m, "enter")
+	if cmd == nil || len(m.operations[second.Address]) != 1 {
+		t.Errorf("enter should connect the other device alongside the first")
+	}
+
+	// A second operation on the same device waits behind the first
+	m.startOperation(first.Address, deviceOperation{label: i18n.T.OpForgetting})
+	if status := m.operationStatus(first.Address); !strings.Contains(status, "+1") {
+		t.Errorf("operationStatus() = %q, want the queued forget counted", status)
+	}
+
+	connect := m.operations[first.Address][0].id
+	model, _ := m.Update(DeviceOperationMsg{Address: first.Address, ID: connect, Result: ConnectResultMsg{Address: first.Address, Success: true}})
+	m = model.(Model)
+	if ops := m.operations[first.Address]; len(ops) != 1 || ops[0].label != i18n.T.OpForgetting {
+		t.Errorf("operations = %+v, want the forget left", ops)
+	}
+	if len(m.operations[second.Address]) != 1 {
+		t.Errorf("the other device's connect should still be running")
+	}
+}
+
+func TestModel_FinishOperation_OutOfOrder(t *testing.T) {
+	m := newOperationsTestModel()
+	dev := m.GetSelectedDevice()
+
+	connectCtx, cancelConnect := context.WithCancel(context.Background())
+	connect := m.startOperation(dev.Address, deviceOperation{label: i18n.T.OpConnecting, cancel: cancelConnect})
+	forget := m.startOperation(dev.Address, deviceOperation{label: i18n.T.OpForgetting})
+
+	// The forget reports back while the connect is still running
+	model, _ := m.Update(DeviceOperationMsg{Address: dev.Address, ID: forget, Result: ForgetDeviceMsg{Address: dev.Address}})
+	m = model.(Model)
+	if connectCtx.Err() != nil {
+		t.Errorf("the forget's result cancelled the running connect")
+	}
+	if ops := m.operations[dev.Address]; len(ops) != 1 || ops[0].id != connect {
+		t.Errorf("operations = %+v, want the connect left", ops)
+	}
+
+	model, _ = m.Update(DeviceOperationMsg{Address: dev.Address, ID: connect, Result: ConnectResultMsg{Address: dev.Address, Success: true}})
+	m = model.(Model)
+	if len(m.operations) != 0 {
+		t.Errorf("operations = %+v, want none left", m.operations)
+	}
+}
+
+func TestModel_HandleConnectResult_KeepsOtherPairing(t *testing.T) {
+	m := newOperationsTestModel()
+	speaker := &models.Device{Path: "/org/bluez/hci0/dev_77_88_99_AA_BB_CC", Address: "77:88:99:AA:BB:CC", Name: "Speaker"}
+	passkey := uint32(123456)
+
+	// The speaker pairs while the headphones connect
+	connect := m.startOperation("AA:BB:CC:DD:EE:FF", deviceOperation{label: i18n.T.OpConnecting})
+	m.startOperation(speaker.Address, deviceOperation{label: i18n.T.OpPairing, pairing: true})
+	m.pairingPasskey = &passkey
+	m.pairingDevice = speaker
+
+	model, _ := m.Update(DeviceOperationMsg{Address: "AA:BB:CC:DD:EE:FF", ID: connect, Result: ConnectResultMsg{Address: "AA:BB:CC:DD:EE:FF", Success: true}})
+	m = model.(Model)
+	if m.pairingPasskey == nil {
+		t.Errorf("the speaker's passkey should stay on screen")
+	}
+	if !m.waitingForPasskey {
+		t.Errorf("the speaker is still pairing")
+	}
+}
+
+func TestModel_CancelOperation(t *testing.T) {
+	tests := []struct {
+		name       string
+		pairing    bool
+		wantCancel bool // CancelPairing is sent to BlueZ
+	}{
+		{"pairing", true, true},
+		{"connecting", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i18n.SetLanguage(i18n.English)
+			m := newOperationsTestModel()
+			dev := m.GetSelectedDevice()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			id := m.startOperation(dev.Address, deviceOperation{label: i18n.T.OpPairing, pairing: tt.pairing, cancel: cancel})
+
+			m, cmd := press(m, "z")
+			if ctx.Err() == nil {
+				t.Errorf("z should cancel the running operation")
+			}
+			if (cmd != nil) != tt.wantCancel {
+				t.Errorf("z returned command %v, want CancelPairing %v", cmd != nil, tt.wantCancel)
+			}
+
+			// The abandoned operation reports the cancellation as its result
+			result := ConnectResultMsg{Address: dev.Address, Err: fmt.Errorf("pairing: %w", context.Canceled)}
+			model, _ := m.Update(DeviceOperationMsg{Address: dev.Address, ID: id, Result: result})
+			m = model.(Model)
+			if m.isError || !strings.Contains(m.statusMessage, "cancelled") {
+				t.Errorf("status = %q (error %v), want the cancellation reported", m.statusMessage, m.isError)
+			}
+			if len(m.operations) != 0 {
+				t.Errorf("operations = %+v, want none left", m.operations)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyFor(t *testing.T) {
+	saved := config.Global
+	defer func() { config.Global = saved }()
+	config.Global = config.Default()
+	config.Global.RetryByDevice = map[string]config.RetrySettings{
+		"aa:bb:cc:dd:ee:ff": {Attempts: 6, Deadline: 120},
+	}
+
+	tests := []struct {
+		name    string
+		address string
+		want    bluetooth.RetryPolicy
+	}{
+		{"global settings", "11:22:33:44:55:66", bluetooth.RetryPolicy{Attempts: 3, BaseDelay: 500 * time.Millisecond, Jitter: 0.2, Deadline: time.Minute}},
+		{"device settings", "AA:BB:CC:DD:EE:FF", bluetooth.RetryPolicy{Attempts: 6, BaseDelay: 500 * time.Millisecond, Jitter: 0.2, Deadline: 2 * time.Minute}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryPolicyFor(&models.Device{Address: tt.address}); got != tt.want {
+				t.Errorf("retryPolicyFor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_HandleOperationAttempt(t *testing.T) {
+	i18n.SetLanguage(i18n.English)
+	m := newOperationsTestModel()
+
+	model, cmd := m.Update(OperationAttemptMsg{Address: "AA:BB:CC:DD:EE:FF", Attempt: 2, Attempts: 3})
+	m = model.(Model)
+	if !strings.Contains(m.statusMessage, "Headphones") || !strings.Contains(m.statusMessage, "2 of 3") {
+		t.Errorf("statusMessage = %q, want the device and the attempt", m.statusMessage)
+	}
+	if !m.statusPending {
+		t.Errorf("the retried operation is still running")
+	}
+	if cmd == nil {
+		t.Errorf("the model should keep listening for progress")
+	}
+}
+
+func TestModel_AutoReconnect(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	saved := config.Global
+	defer func() { config.Global = saved }()
+	config.Global = config.Default()
+	i18n.SetLanguage(i18n.English)
+	m := newOperationsTestModel()
+	dev := m.GetSelectedDevice()
+
+	// Marking an untrusted device trusts it and saves the choice
+	m, cmd := press(m, "o")
+	if cmd == nil || !strings.Contains(m.statusMessage, "trusted") {
+		t.Errorf("o should trust %s, status %q", dev.Name, m.statusMessage)
+	}
+	if got := config.Global.AutoReconnectDevices; len(got) != 1 || got[0] != dev.Address {
+		t.Errorf("AutoReconnectDevices = %v, want [%s]", got, dev.Address)
+	}
+
+	// Dropping out schedules a reconnect
+	connected := *dev
+	connected.Connected, connected.Trusted = true, true
+	m.devices[dev.Address] = &connected
+	dropped := connected
+	dropped.Connected = false
+	model, cmd := m.Update(BluetoothEventMsg{Event: bluetooth.Event{Kind: bluetooth.DeviceChanged, Address: dev.Address, Device: &dropped}})
+	m = model.(Model)
+	if cmd == nil || !m.reconnect.Pending(dev.Address) {
+		t.Fatalf("a dropped connection should schedule a reconnect")
+	}
+
+	model, cmd = m.Update(ReconnectDueMsg{Address: dev.Address})
+	m = model.(Model)
+	if cmd == nil || len(m.operations[dev.Address]) != 1 {
+		t.Fatalf("the due reconnect should run as an operation on %s", dev.Name)
+	}
+
+	// A failure waits longer, then another attempt follows
+	result := ReconnectResultMsg{Address: dev.Address, Err: bluetooth.ErrPageTimeout}
+	model, cmd = m.Update(DeviceOperationMsg{Address: dev.Address, ID: m.operations[dev.Address][0].id, Result: result})
+	m = model.(Model)
+	if cmd == nil || !strings.Contains(m.statusMessage, "trying again") {
+		t.Errorf("status = %q, want another attempt scheduled", m.statusMessage)
+	}
+	if len(m.operations) != 0 {
+		t.Errorf("operations = %+v, want none left", m.operations)
+	}
+
+	// Unmarking forgets the device
+	m, _ = press(m, "o")
+	if m.reconnect.Enabled(dev.Address) || len(config.Global.AutoReconnectDevices) != 0 {
+		t.Errorf("o should unmark %s", dev.Name)
+	}
+	model, cmd = m.Update(ReconnectDueMsg{Address: dev.Address})
+	m = model.(Model)
+	if cmd != nil || len(m.operations) != 0 {
+		t.Errorf("an unmarked device should not be reconnected")
+	}
+}
+
+func TestModel_ForgetDropsAutoReconnect(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	saved := config.Global
+	defer func() { config.Global = saved }()
+	config.Global = config.Default()
+	i18n.SetLanguage(i18n.English)
+	m := newOperationsTestModel()
+	dev := m.GetSelectedDevice()
+	dev.Trusted = true
+
+	m, _ = press(m, "o")
+	if len(config.Global.AutoReconnectDevices) != 1 {
+		t.Fatalf("o should mark %s, AutoReconnectDevices = %v", dev.Name, config.Global.AutoReconnectDevices)
+	}
+
+	model, _ := m.Update(ForgetDeviceMsg{Address: dev.Address, Message: "Forgotten"})
+	m = model.(Model)
+	if m.reconnect.Enabled(dev.Address) {
+		t.Errorf("a forgotten device should not be reconnected")
+	}
+	if got := config.Global.AutoReconnectDevices; len(got) != 0 {
+		t.Errorf("AutoReconnectDevices = %v, want none", got)
+	}
+	loaded, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded.AutoReconnectDevices) != 0 {
+		t.Errorf("saved AutoReconnectDevices = %v, want none", loaded.AutoReconnectDevices)
+	}
+}
+
+func TestModel_OperationStatus_State(t *testing.T) {
+	i18n.SetLanguage(i18n.English)
+	m := newOperationsTestModel()
+	dev := m.devices["AA:BB:CC:DD:EE:FF"]
+
+	if status := m.operationStatus(dev.Address); status != "" {
+		t.Errorf("operationStatus() = %q, want nothing for an idle device", status)
+	}
+
+	// The link is up but the profiles are not, with no operation left
+	resolved, unresolved := true, false
+	dev.Connected = true
+	dev.ServicesResolved = &unresolved
+	if status := m.operationStatus(dev.Address); !strings.Contains(status, i18n.T.StateResolvingServices) {
+		t.Errorf("operationStatus() = %q, want the services being resolved", status)
+	}
+	cmd := m.startSpinner()
+	if cmd == nil || !m.spinning {
+		t.Fatalf("a device changing state should start the spinner")
+	}
+	if m.startSpinner() != nil {
+		t.Errorf("a running spinner should not be started twice")
+	}
+
+	// The spinner stops once the device is fully connected
+	dev.ServicesResolved = &resolved
+	model, cmd := m.Update(m.spinner.Tick())
+	m = model.(Model)
+	if cmd != nil || m.spinning {
+		t.Errorf("the spinner should stop when no device changes state")
+	}
+	if status := m.operationStatus(dev.Address); status != "" {
+		t.Errorf("operationStatus() = %q, want the badges back", status)
+	}
+}
+
+func TestModel_Profiles(t *testing.T) {
+	i18n.SetLanguage(i18n.English)
+	m := newOperationsTestModel()
+	dev := m.GetSelectedDevice()
+	dev.UUIDs = []string{
+		"0000110b-0000-1000-8000-00805f9b34fb",
+		"0000111e-0000-1000-8000-00805f9b34fb",
+	}
+
+	// The profile keys only act in the detail panel
+	if _, cmd := press(m, "+"); cmd != nil {
+		t.Errorf("+ should do nothing without the detail panel")
+	}
+	m.showDetails = true
+
+	m, _ = press(m, "]")
+	m, _ = press(m, "]")
+	if uuid, _ := m.profileOf(dev); models.ProfileName(uuid) != "hfp-hf" {
+		t.Errorf("] should select the last profile, got %q", uuid)
+	}
+
+	dev.Connected, dev.Trusted = true, true
+	m.reconnect.SetEnabled(dev.Address, true)
+	m, cmd := press(m, "-")
+	if cmd == nil || len(m.operations[dev.Address]) != 1 || !strings.Contains(m.statusMessage, "hfp-hf") {
+		t.Fatalf("- should disconnect the selected profile, status %q", m.statusMessage)
+	}
+
+	result := ProfileResultMsg{Address: dev.Address, UUID: dev.UUIDs[1]}
+	model, _ := m.Update(DeviceOperationMsg{Address: dev.Address, ID: m.operations[dev.Address][0].id, Result: result})
+	m = model.(Model)
+	if m.isError || m.statusMessage != "hfp-hf disconnected on Headphones" {
+		t.Errorf("status = %q (error %v), want the profile disconnected", m.statusMessage, m.isError)
+	}
+
+	// A2DP keeps the link up, so a later drop is still reconnected
+	dropped := *dev
+	dropped.Connected = false
+	if _, ok := m.reconnect.Changed(dev, &dropped); !ok {
+		t.Errorf("disconnecting one of several profiles should keep auto-reconnect")
+	}
+
+	// Without profiles left the link went down, which is what the user asked for
+	up := *dev
+	dev.Connected = false
+	model, _ = m.handleProfileResult(result)
+	m = model.(Model)
+	if _, ok := m.reconnect.Changed(&up, &dropped); ok {
+		t.Errorf("disconnecting the last profile should stop auto-reconnect")
+	}
+
+	// A device without profiles says so
+	m, _ = press(m, "down")
+	if _, cmd := press(m, "+"); cmd != nil {
+		t.Errorf("+ should not start anything on a device without profiles")
+	}
+}
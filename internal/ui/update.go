@@ -1,12 +1,21 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/godbus/dbus/v5"
+	"github.com/ivangsm/blugo/internal/agent"
+	"github.com/ivangsm/blugo/internal/bluetooth"
 	"github.com/ivangsm/blugo/internal/config"
 	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
 )
 
 // updateViewportContent updates the viewport with current content
@@ -58,8 +67,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case DeviceUpdateMsg:
 		return m.handleDeviceUpdate(msg)
 
-	case PasskeyDisplayMsg:
-		return m.handlePasskeyDisplay(msg)
+	case BluetoothEventMsg:
+		return m.handleBluetoothEvent(msg)
+
+	case agent.PinCodeTryMsg:
+		return m.handlePinCodeTry(msg)
+
+	case agent.PinCodeRequestMsg:
+		return m.handlePinCodeRequest(msg)
+
+	case agent.ConfirmPasskeyMsg:
+		return m.handleConfirmPasskey(msg)
+
+	case agent.PasskeyRequestMsg:
+		return m.handlePasskeyRequest(msg)
+
+	case agent.DisplayPasskeyMsg:
+		return m.handleDisplayPasskey(msg)
+
+	case agent.DisplayPinCodeMsg:
+		return m.handleDisplayPinCode(msg)
+
+	case agent.AuthorizeRequestMsg:
+		return m.handleAuthorizeRequest(msg)
+
+	case agent.AuthorizationDeniedMsg:
+		return m.handleAuthorizationDenied(msg)
+
+	case IncomingPairingMsg:
+		return m.handleIncomingPairing(msg)
+
+	case IncomingPairingTickMsg:
+		return m.handleIncomingPairingTick(msg)
+
+	case agent.RequestCancelledMsg:
+		return m.handleRequestCancelled(msg)
+
+	case DeviceOperationMsg:
+		return m.handleDeviceOperation(msg)
+
+	case OperationAttemptMsg:
+		return m.handleOperationAttempt(msg)
+
+	case ProfileResultMsg:
+		return m.handleProfileResult(msg)
+
+	case ReconnectDueMsg:
+		return m.handleReconnectDue(msg)
+
+	case ReconnectResultMsg:
+		return m.handleReconnectResult(msg)
 
 	case ConnectResultMsg:
 		return m.handleConnectResult(msg)
@@ -76,9 +133,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case AdapterPropertyChangedMsg:
 		return m.handleAdapterPropertyChanged(msg)
 
+	case AdapterSwitchedMsg:
+		return m.handleAdapterSwitched(msg)
+
+	case DiscoveryFilterMsg:
+		return m.handleDiscoveryFilter(msg)
+
 	case TickMsg:
 		return m.handleTick()
 
+	case spinner.TickMsg:
+		return m.handleSpinnerTick(msg)
+
 	case tea.MouseMsg:
 		// Handle mouse wheel scrolling
 		switch msg.Type {
@@ -96,16 +162,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // handleKeyPress handles pressed keys.
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// If we are waiting for passkey confirmation
-	if m.pairingPasskey != nil {
+	if m.pairingPromptOpen() {
 		return m.handlePasskeyConfirmation(msg)
 	}
 
-	// If we are busy, only allow exit
-	if m.busy {
-		if msg.String() == "ctrl+c" || msg.String() == "q" {
-			return m.quit()
-		}
-		return m, nil
+	// If the agent is waiting for a PIN or passkey
+	if m.input != nil {
+		return m.handleInputPrompt(msg)
+	}
+
+	// If the agent is waiting for an authorization
+	if m.authorization != nil {
+		return m.handleAuthorizationPrompt(msg)
+	}
+
+	// If we are waiting for a phone to pair
+	if m.incoming != nil {
+		return m.handleIncomingPairingKey(msg)
 	}
 
 	switch msg.String() {
@@ -147,7 +220,7 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Tab navigation removed since we only have one section now
 
 	case "s":
-		if m.manager != nil {
+		if m.manager != nil && m.adapter != nil {
 			return m, toggleScanningCmd(m.manager, m.scanning)
 		}
 
@@ -157,6 +230,28 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "d", "x":
 		return m.handleForget()
 
+	case "z":
+		// Cancel the selected device's running operation
+		return m.handleCancelOperation()
+
+	case "o":
+		// Toggle auto-reconnect for the selected device
+		return m.handleToggleAutoReconnect()
+
+	case "[", "]":
+		// Select a profile in the detail panel
+		if m.showDetails {
+			m.selectProfile(msg.String() == "]")
+			m.updateViewportContent()
+			return m, nil
+		}
+
+	case "+", "-":
+		// Connect or disconnect the profile selected in the detail panel
+		if m.showDetails {
+			return m.handleProfile(msg.String() == "+")
+		}
+
 	case "r":
 		if m.manager != nil {
 			return m, updateDevicesCmd(m.manager)
@@ -165,39 +260,76 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "p":
 		// Toggle Powered (turn Bluetooth on/off)
 		if m.manager != nil && m.adapter != nil {
-			m.busy = true
+			m.statusPending = true
 			if m.adapter.Powered {
 				m.statusMessage = i18n.T.AdapterPoweringOff
 			} else {
 				m.statusMessage = i18n.T.AdapterPoweringOn
 			}
-			return m, toggleAdapterPoweredCmd(m.manager, m.adapter.Powered)
+			return m, queuedCmd(m.manager, adapterOperations, toggleAdapterPoweredCmd(m.manager, m.adapter.Powered))
 		}
 
 	case "v":
 		// Toggle Discoverable
 		if m.manager != nil && m.adapter != nil {
-			m.busy = true
+			m.statusPending = true
 			if m.adapter.Discoverable {
 				m.statusMessage = i18n.T.DiscoverableDeactivating
 			} else {
 				m.statusMessage = i18n.T.DiscoverableActivating
 			}
-			return m, toggleAdapterDiscoverableCmd(m.manager, m.adapter.Discoverable)
+			return m, queuedCmd(m.manager, adapterOperations, toggleAdapterDiscoverableCmd(m.manager, m.adapter.Discoverable))
 		}
 
 	case "b":
 		// Toggle Pairable
 		if m.manager != nil && m.adapter != nil {
-			m.busy = true
+			m.statusPending = true
 			if m.adapter.Pairable {
 				m.statusMessage = i18n.T.PairableDeactivating
 			} else {
 				m.statusMessage = i18n.T.PairableActivating
 			}
-			return m, toggleAdapterPairableCmd(m.manager, m.adapter.Pairable)
+			return m, queuedCmd(m.manager, adapterOperations, toggleAdapterPairableCmd(m.manager, m.adapter.Pairable))
+		}
+
+	case "w":
+		// Wait for a phone to pair with us
+		return m.startIncomingPairing()
+
+	case "a":
+		// Switch to the next adapter
+		if m.manager != nil {
+			if next := m.nextAdapter(); next != nil {
+				m.statusPending = true
+				m.statusMessage = fmt.Sprintf(i18n.T.AdapterSwitching, next.ID())
+				return m, queuedCmd(m.manager, adapterOperations, switchAdapterCmd(m.manager, string(next.Path), m.scanning))
+			}
 		}
 
+	case "t":
+		// Cycle the discovery transport
+		if m.manager != nil {
+			m.statusPending = true
+			return m, queuedCmd(m.manager, adapterOperations, setDiscoveryFilterCmd(m.manager, nextTransportFilter(m.manager.DiscoveryFilter())))
+		}
+
+	case "f":
+		// Cycle the discovery RSSI threshold
+		if m.manager != nil {
+			m.statusPending = true
+			return m, queuedCmd(m.manager, adapterOperations, setDiscoveryFilterCmd(m.manager, nextRSSIFilter(m.manager.DiscoveryFilter())))
+		}
+
+	case "c":
+		// Cycle the device type shown in the list
+		m.typeFilter = nextTypeFilter(m.typeFilter)
+		m.statusMessage = fmt.Sprintf(i18n.T.TypeFilterSet, categoryLabel(m.typeFilter))
+		m.isError = false
+		m.initDevicesTable()
+		m.updateViewportContent()
+		return m, nil
+
 	case "l":
 		// Toggle Language
 		i18n.ToggleLanguage()
@@ -215,6 +347,21 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.updateViewportContent()
 		return m, nil
 
+	case "i":
+		// Toggle the detail panel of the selected device
+		m.showDetails = !m.showDetails
+		m.initDevicesTable()
+		m.updateViewportContent()
+		return m, nil
+
+	case "esc":
+		if m.showDetails {
+			m.showDetails = false
+			m.initDevicesTable()
+			m.updateViewportContent()
+		}
+		return m, nil
+
 	case "?":
 		// Toggle help display
 		m.showHelp = !m.showHelp
@@ -227,33 +374,132 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handlePasskeyConfirmation handles passkey confirmation.
 func (m Model) handlePasskeyConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// A code typed on the remote device needs no answer here
+	if m.typedRemotely() {
+		switch msg.String() {
+		case "n", "esc":
+			m.closePairingPrompt()
+			m.updateViewportContent()
+		case "ctrl+c", "q":
+			return m.quit()
+		}
+		return m, nil
+	}
+
 	switch msg.String() {
 	case "enter", "y":
 		// Confirm pairing
 		if m.agent != nil {
-			m.agent.GetConfirmChannel() <- true
+			m.agent.Confirm(m.pairingDevice.Path)
 		}
-		m.pairingPasskey = nil
+		m.closePairingPrompt()
 		m.statusMessage = i18n.T.StatusConfirmingPairing
+		m.updateViewportContent()
 		return m, nil
 
 	case "n", "esc":
 		// Cancel pairing
 		if m.agent != nil {
-			m.agent.GetConfirmChannel() <- false
+			m.agent.Reject(m.pairingDevice.Path)
 		}
-		m.pairingPasskey = nil
-		m.busy = false
+		m.closePairingPrompt()
+		m.statusPending = false
 		m.statusMessage = i18n.T.PairingCancelled
+		m.updateViewportContent()
+		return m, nil
+
+	case "ctrl+c", "q":
+		if m.agent != nil {
+			m.agent.Reject(m.pairingDevice.Path)
+		}
+		return m.quit()
+	}
+
+	return m, nil
+}
+
+// handleAuthorizationPrompt handles allowing or denying a device's
+// request.
+func (m Model) handleAuthorizationPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	request := m.authorization
+	switch msg.String() {
+	case "enter", "y":
+		if m.agent != nil {
+			m.agent.Confirm(request.device.Path)
+		}
+		m.authorization = nil
+		m.statusMessage = fmt.Sprintf(i18n.T.AuthorizeAllowed, deviceListName(request.device), request.profile())
+		m.isError = false
+		m.updateViewportContent()
+		return m, nil
+
+	case "n", "esc":
+		if m.agent != nil {
+			m.agent.Reject(request.device.Path)
+		}
+		m.authorization = nil
+		m.statusMessage = fmt.Sprintf(i18n.T.AuthorizeDenied, deviceListName(request.device), request.profile())
+		m.isError = false
+		m.updateViewportContent()
 		return m, nil
 
 	case "ctrl+c", "q":
 		if m.agent != nil {
-			m.agent.GetConfirmChannel() <- false
+			m.agent.Reject(request.device.Path)
+		}
+		return m.quit()
+	}
+
+	return m, nil
+}
+
+// handleInputPrompt handles typing into the PIN or passkey prompt.
+func (m Model) handleInputPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		if !m.input.complete() {
+			return m, nil
+		}
+		if m.agent != nil {
+			if m.input.passkey {
+				passkey, _ := strconv.ParseUint(m.input.value, 10, 32)
+				m.agent.AnswerPasskey(m.input.device.Path, uint32(passkey))
+			} else {
+				m.agent.AnswerPinCode(m.input.device.Path, m.input.value)
+			}
+		}
+		m.input = nil
+		m.statusMessage = i18n.T.StatusConfirmingPairing
+		m.updateViewportContent()
+		return m, nil
+
+	case tea.KeyEsc:
+		if m.agent != nil {
+			m.agent.Reject(m.input.device.Path)
+		}
+		m.input = nil
+		m.statusMessage = i18n.T.PairingCancelled
+		m.updateViewportContent()
+		return m, nil
+
+	case tea.KeyCtrlC:
+		if m.agent != nil {
+			m.agent.Reject(m.input.device.Path)
 		}
 		return m.quit()
+
+	case tea.KeyBackspace:
+		if m.input.value != "" {
+			m.input.value = m.input.value[:len(m.input.value)-1]
+		}
+
+	case tea.KeyRunes:
+		if value := m.input.value + string(msg.Runes); m.input.accepts(value) {
+			m.input.value = value
+		}
 	}
 
+	m.updateViewportContent()
 	return m, nil
 }
 
@@ -268,25 +514,34 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// The device's row shows progress while the rest of the list stays usable
+	m.statusPending = true
+	ctx, cancel := context.WithCancel(context.Background())
+	var id int
+	var cmd tea.Cmd
 	if dev.Connected {
 		// Disconnect device
-		m.busy = true
 		m.statusMessage = fmt.Sprintf(i18n.T.Disconnecting, dev.GetDisplayName())
-		return m, disconnectFromDeviceCmd(m.manager, dev)
+		id = m.startOperation(dev.Address, deviceOperation{label: i18n.T.OpDisconnecting, cancel: cancel})
+		m.reconnect.UserDisconnected(dev.Address)
+		cmd = disconnectFromDeviceCmd(ctx, m.manager, dev)
 	} else {
+		m.reconnect.UserConnected(dev.Address)
 		// Connect device
-		m.busy = true
 		if dev.Paired {
 			m.statusMessage = fmt.Sprintf(i18n.T.Connecting, dev.GetDisplayName())
+			id = m.startOperation(dev.Address, deviceOperation{label: i18n.T.OpConnecting, cancel: cancel})
 		} else {
 			m.statusMessage = fmt.Sprintf(i18n.T.Pairing, dev.GetDisplayName())
+			id = m.startOperation(dev.Address, deviceOperation{label: i18n.T.OpPairing, pairing: true, cancel: cancel})
 			m.waitingForPasskey = true
 		}
-		return m, tea.Batch(
-			connectToDeviceCmd(m.manager, dev),
-			waitForPasskeyCmd(m.agent),
-		)
+		cmd = connectToDeviceCmd(ctx, m.progress, m.manager, m.agent, dev)
 	}
+
+	m.initDevicesTable()
+	m.updateViewportContent()
+	return m, tea.Batch(deviceOperationCmd(m.manager, dev, id, cmd), m.startSpinner())
 }
 
 // handleForget handles the forget device action.
@@ -301,14 +556,109 @@ func (m Model) handleForget() (tea.Model, tea.Cmd) {
 	}
 
 	if dev.Paired {
-		m.busy = true
+		m.statusPending = true
 		m.statusMessage = fmt.Sprintf(i18n.T.Forgetting, dev.GetDisplayName())
-		return m, forgetDeviceCmd(m.manager, dev)
+		ctx, cancel := context.WithCancel(context.Background())
+		id := m.startOperation(dev.Address, deviceOperation{label: i18n.T.OpForgetting, cancel: cancel})
+		m.initDevicesTable()
+		m.updateViewportContent()
+		return m, tea.Batch(deviceOperationCmd(m.manager, dev, id, forgetDeviceCmd(ctx, m.progress, m.manager, dev)), m.startSpinner())
 	}
 
 	return m, nil
 }
 
+// handleProfile connects or disconnects the profile selected in the
+// detail panel, leaving the device's other profiles alone.
+func (m Model) handleProfile(connect bool) (tea.Model, tea.Cmd) {
+	dev := m.GetSelectedDevice()
+	if m.manager == nil || dev == nil {
+		return m, nil
+	}
+	uuid, ok := m.profileOf(dev)
+	if !ok {
+		m.statusMessage = fmt.Sprintf(i18n.T.NoProfiles, dev.GetDisplayName())
+		m.isError = true
+		m.updateViewportContent()
+		return m, nil
+	}
+
+	name := models.ProfileLabel(uuid)
+	ctx, cancel := context.WithCancel(context.Background())
+	m.statusPending = true
+	m.isError = false
+	var id int
+	if connect {
+		m.statusMessage = fmt.Sprintf(i18n.T.ConnectingProfile, name, dev.GetDisplayName())
+		id = m.startOperation(dev.Address, deviceOperation{label: fmt.Sprintf(i18n.T.OpConnectingProfile, name), cancel: cancel})
+		m.reconnect.UserConnected(dev.Address)
+	} else {
+		m.statusMessage = fmt.Sprintf(i18n.T.DisconnectingProfile, name, dev.GetDisplayName())
+		id = m.startOperation(dev.Address, deviceOperation{label: fmt.Sprintf(i18n.T.OpDisconnectingProfile, name), cancel: cancel})
+		// The link drops along with the device's only profile. With other
+		// profiles it stays up, and handleProfileResult or the
+		// DeviceDisconnected event tell whether this one was the last.
+		if len(dev.Profiles()) == 1 {
+			m.reconnect.UserDisconnected(dev.Address)
+		}
+	}
+
+	m.initDevicesTable()
+	m.updateViewportContent()
+	cmd := profileCmd(ctx, m.progress, m.manager, dev, uuid, connect)
+	return m, tea.Batch(deviceOperationCmd(m.manager, dev, id, cmd), m.startSpinner())
+}
+
+// handleProfileResult reports how connecting or disconnecting a profile
+// went.
+func (m Model) handleProfileResult(msg ProfileResultMsg) (tea.Model, tea.Cmd) {
+	name := m.deviceName(msg.Address)
+	m.statusPending = false
+	m.isError = false
+
+	switch {
+	case errors.Is(msg.Err, context.Canceled):
+		m.statusMessage = fmt.Sprintf(i18n.T.OperationCancelled, name)
+	case msg.Err != nil:
+		m.statusMessage = describeError(msg.Err)
+		m.isError = true
+	case msg.Connect:
+		m.statusMessage = fmt.Sprintf(i18n.T.ProfileConnected, models.ProfileLabel(msg.UUID), name)
+	default:
+		m.statusMessage = fmt.Sprintf(i18n.T.ProfileDisconnected, models.ProfileLabel(msg.UUID), name)
+		if dev, ok := m.devices[msg.Address]; ok && !dev.Connected {
+			// That was the last connected profile
+			m.reconnect.UserDisconnected(msg.Address)
+		}
+	}
+
+	m.updateViewportContent()
+	return m, nil
+}
+
+// handleCancelOperation abandons the running operation of the selected
+// device. A pairing is also stopped in BlueZ, which keeps pairing when
+// nobody waits for it anymore.
+func (m Model) handleCancelOperation() (tea.Model, tea.Cmd) {
+	dev := m.GetSelectedDevice()
+	if dev == nil || len(m.operations[dev.Address]) == 0 {
+		return m, nil
+	}
+
+	op := m.operations[dev.Address][0]
+	if op.cancel != nil {
+		op.cancel()
+	}
+	m.statusMessage = fmt.Sprintf(i18n.T.OperationCancelling, dev.GetDisplayName())
+	m.isError = false
+	m.updateViewportContent()
+
+	if op.pairing && m.manager != nil {
+		return m, cancelPairingCmd(m.manager, dev)
+	}
+	return m, nil
+}
+
 // handleInit handles the initialization message.
 func (m Model) handleInit(msg InitMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
@@ -319,17 +669,25 @@ func (m Model) handleInit(msg InitMsg) (tea.Model, tea.Cmd) {
 	m.manager = msg.Manager
 	m.agent = msg.Agent
 	m.scanning = msg.Scanning // Use the actual scanning state from init
-	if msg.Scanning {
+	if !m.manager.HasAdapter() {
+		m.resumeScanning = config.Global == nil || config.Global.AutoStartScanning
+		m.statusMessage = i18n.T.NoAdapter
+	} else if msg.Scanning {
 		m.statusMessage = i18n.T.ScanEnabled
 	} else {
 		m.statusMessage = i18n.T.ScanPaused
 	}
 	m.initDevicesTable()
 	m.updateViewportContent()
-	return m, tea.Batch(
+
+	cmds := []tea.Cmd{
 		updateDevicesCmd(m.manager),
 		updateAdapterInfoCmd(m.manager),
-	)
+	}
+	if m.manager.IsWatching() {
+		cmds = append(cmds, listenEventsCmd(m.manager))
+	}
+	return m, tea.Batch(cmds...)
 }
 
 // handleScanning handles scanning state change.
@@ -347,6 +705,8 @@ func (m Model) handleScanning(msg ScanningMsg) (tea.Model, tea.Cmd) {
 // handleDeviceUpdate handles device updates.
 func (m Model) handleDeviceUpdate(msg DeviceUpdateMsg) (tea.Model, tea.Cmd) {
 	// Update only new or modified devices
+	var incomingCmd tea.Cmd
+	var reconnectCmds []tea.Cmd
 	for addr, newDev := range msg.Devices {
 		if oldDev, exists := m.devices[addr]; exists {
 			// Keep LastSeen if device already existed
@@ -357,30 +717,471 @@ func (m Model) handleDeviceUpdate(msg DeviceUpdateMsg) (tea.Model, tea.Cmd) {
 			// New device - add to deviceOrder to maintain stable ordering
 			m.deviceOrder = append(m.deviceOrder, addr)
 		}
+		if cmd := m.checkIncomingPaired(m.devices[addr], newDev); cmd != nil {
+			incomingCmd = cmd
+		}
+		reconnectCmds = append(reconnectCmds, m.checkReconnect(m.devices[addr], newDev))
 		m.devices[addr] = newDev
 	}
 	m.initDevicesTable()
 	m.updateViewportContent()
+	return m, tea.Batch(append(reconnectCmds, incomingCmd, m.startSpinner())...)
+}
+
+// handleBluetoothEvent applies a single change pushed by BlueZ and keeps listening.
+func (m Model) handleBluetoothEvent(msg BluetoothEventMsg) (tea.Model, tea.Cmd) {
+	ev := msg.Event
+
+	switch ev.Kind {
+	case bluetooth.DeviceChanged:
+		if oldDev, exists := m.devices[ev.Address]; exists {
+			// Keep LastSeen if device already existed
+			if !oldDev.Connected && !ev.Device.Connected {
+				ev.Device.LastSeen = oldDev.LastSeen
+			}
+		} else {
+			m.deviceOrder = append(m.deviceOrder, ev.Address)
+		}
+		incomingCmd := m.checkIncomingPaired(m.devices[ev.Address], ev.Device)
+		reconnectCmd := m.checkReconnect(m.devices[ev.Address], ev.Device)
+		m.devices[ev.Address] = ev.Device
+		cmd := tea.Batch(incomingCmd, reconnectCmd, m.startSpinner())
+		m.initDevicesTable()
+		if cmd != nil {
+			m.updateViewportContent()
+			return m, tea.Batch(cmd, listenEventsCmd(m.manager))
+		}
+
+	case bluetooth.DeviceRemoved:
+		m.removeDevice(ev.Address)
+		m.initDevicesTable()
+
+	case bluetooth.DeviceDisconnected:
+		// Disconnected on this host without going through the TUI, e.g.
+		// with "blugo disconnect": leave it disconnected
+		if ev.Reason == bluetooth.DisconnectedLocally {
+			m.reconnect.UserDisconnected(ev.Address)
+		}
+
+	case bluetooth.AdapterChanged:
+		m.updateAdapterEntry(ev.Adapter)
+		if ev.Adapter.Path == m.manager.GetAdapter() {
+			m.adapter = ev.Adapter
+			m.scanning = ev.Adapter.Discovering
+		}
+
+	case bluetooth.AdapterRemoved:
+		m.removeAdapterEntry(ev.Adapter.Path)
+		m.statusMessage = fmt.Sprintf(i18n.T.AdapterRemovedMsg, ev.Adapter.ID())
+		m.isError = true
+
+	case bluetooth.AdapterSelected:
+		return m.handleAdapterHotplug(ev.Adapter)
+
+	case bluetooth.ServiceLost:
+		m.bluezUnavailable = true
+		m.resumeScanning = m.resumeScanning || m.scanning
+		m.scanning = false
+		m.adapter = nil
+		m.adapters = nil
+		m.devices = make(map[string]*models.Device)
+		m.deviceOrder = make([]string, 0)
+		m.initDevicesTable()
+
+	case bluetooth.ServiceRestored:
+		m.bluezUnavailable = false
+		model, cmd := m.handleAdapterHotplug(ev.Adapter)
+		m = model.(Model)
+		if ev.Adapter != nil {
+			m.statusMessage = i18n.T.BluezRestored
+			m.updateViewportContent()
+		}
+		return m, tea.Batch(cmd, registerAgentCmd(m.agent, m.manager))
+	}
+
+	m.updateViewportContent()
+	return m, listenEventsCmd(m.manager)
+}
+
+// handleAdapterHotplug switches the UI to the adapter chosen by the manager
+// after a hotplug, or to the "no adapter" state when adapter is nil.
+func (m Model) handleAdapterHotplug(adapter *models.Adapter) (tea.Model, tea.Cmd) {
+	// Remember to scan again if the lost adapter was scanning
+	m.resumeScanning = m.resumeScanning || m.scanning
+	m.scanning = false
+
+	m.adapter = adapter
+	m.devices = make(map[string]*models.Device)
+	m.deviceOrder = make([]string, 0)
+	m.initDevicesTable()
+
+	cmds := []tea.Cmd{listenEventsCmd(m.manager)}
+	if adapter == nil {
+		m.statusMessage = i18n.T.NoAdapter
+		m.isError = true
+	} else {
+		m.updateAdapterEntry(adapter)
+		m.statusMessage = fmt.Sprintf(i18n.T.AdapterSwitched, adapter.ID())
+		m.isError = false
+		cmds = append(cmds, updateDevicesCmd(m.manager), updateAdapterInfoCmd(m.manager))
+		if m.resumeScanning {
+			m.resumeScanning = false
+			cmds = append(cmds, toggleScanningCmd(m.manager, false))
+		}
+	}
+
+	m.updateViewportContent()
+	return m, tea.Batch(cmds...)
+}
+
+// handleConfirmPasskey shows the passkey the user has to compare with the
+// one on the device. Requests also arrive when a device pairs with us on
+// its own initiative, so the prompt always names the device asking.
+func (m Model) handleConfirmPasskey(msg agent.ConfirmPasskeyMsg) (tea.Model, tea.Cmd) {
+	m.replacePendingRequest(msg.Device)
+	passkey := msg.Passkey
+	m.pairingPasskey = &passkey
+	m.pairingDevice = msg.Device
+	m.waitingForPasskey = false
+	m.updateViewportContent()
+	return m, nil
+}
+
+// handlePinCodeTry reports which configured PIN the agent is trying.
+func (m Model) handlePinCodeTry(msg agent.PinCodeTryMsg) (tea.Model, tea.Cmd) {
+	m.statusMessage = fmt.Sprintf(i18n.T.PinTrying, msg.PinCode, deviceListName(msg.Device))
+	m.isError = false
+	m.updateViewportContent()
+	return m, nil
+}
+
+// handlePinCodeRequest opens the PIN prompt for the device being paired.
+func (m Model) handlePinCodeRequest(msg agent.PinCodeRequestMsg) (tea.Model, tea.Cmd) {
+	m.replacePendingRequest(msg.Device)
+	m.input = &inputPrompt{device: msg.Device}
+	m.updateViewportContent()
+	return m, nil
+}
+
+// handlePasskeyRequest opens the passkey prompt for the device being paired.
+func (m Model) handlePasskeyRequest(msg agent.PasskeyRequestMsg) (tea.Model, tea.Cmd) {
+	m.replacePendingRequest(msg.Device)
+	m.input = &inputPrompt{device: msg.Device, passkey: true}
+	m.updateViewportContent()
 	return m, nil
 }
 
-// handlePasskeyDisplay handles passkey display.
-func (m Model) handlePasskeyDisplay(msg PasskeyDisplayMsg) (tea.Model, tea.Cmd) {
-	if m.waitingForPasskey {
-		m.pairingPasskey = &msg.Passkey
-		m.waitingForPasskey = false
+// handleDisplayPasskey shows the passkey to type on the remote device and
+// keeps the prompt's progress up to date as digits are typed there.
+func (m Model) handleDisplayPasskey(msg agent.DisplayPasskeyMsg) (tea.Model, tea.Cmd) {
+	// Devices pairing on their own initiative are shown too, but only from
+	// the first call, so a prompt the user hid stays hidden while typing
+	showing := m.pairingPasskey != nil && m.passkeyEntered != nil && *m.pairingPasskey == msg.Passkey
+	if !m.waitingForPasskey && !showing && msg.Entered > 0 {
+		return m, nil
 	}
+	passkey, entered := msg.Passkey, msg.Entered
+	m.replacePendingRequest(msg.Device)
+	m.pairingPasskey = &passkey
+	m.passkeyEntered = &entered
+	m.pairingDevice = msg.Device
+	m.waitingForPasskey = false
 	m.updateViewportContent()
 	return m, nil
 }
 
-// handleConnectResult handles connection result.
-func (m Model) handleConnectResult(msg ConnectResultMsg) (tea.Model, tea.Cmd) {
-	m.busy = false
+// handleDisplayPinCode shows the PIN to type on the remote device.
+func (m Model) handleDisplayPinCode(msg agent.DisplayPinCodeMsg) (tea.Model, tea.Cmd) {
+	m.replacePendingRequest(msg.Device)
+	m.pairingPinCode = msg.PinCode
+	m.pairingDevice = msg.Device
 	m.waitingForPasskey = false
+	m.updateViewportContent()
+	return m, nil
+}
+
+// handleAuthorizeRequest asks the user whether a device may pair or use
+// a service.
+func (m Model) handleAuthorizeRequest(msg agent.AuthorizeRequestMsg) (tea.Model, tea.Cmd) {
+	m.replacePendingRequest(msg.Device)
+	m.authorization = &authorizationPrompt{device: msg.Device, uuid: msg.UUID}
+	m.updateViewportContent()
+	return m, nil
+}
 
+// handleAuthorizationDenied reports a request refused by the
+// authorization policy, so a device failing to connect is explained.
+func (m Model) handleAuthorizationDenied(msg agent.AuthorizationDeniedMsg) (tea.Model, tea.Cmd) {
+	request := authorizationPrompt{device: msg.Device, uuid: msg.UUID}
+	m.statusMessage = fmt.Sprintf(i18n.T.AuthorizationPolicyDenied, deviceListName(msg.Device), request.profile())
+	m.isError = true
+	m.updateViewportContent()
+	return m, nil
+}
+
+// startIncomingPairing starts the pair from phone wizard, remembering the
+// adapter settings it changes.
+func (m Model) startIncomingPairing() (tea.Model, tea.Cmd) {
+	if m.manager == nil || m.adapter == nil {
+		return m, nil
+	}
+	if !m.adapter.Powered {
+		m.statusMessage = i18n.T.IncomingNotPowered
+		m.isError = true
+		m.updateViewportContent()
+		return m, nil
+	}
+
+	now := time.Now()
+	m.incoming = &incomingPairing{
+		started:         now,
+		deadline:        now.Add(incomingPairingDuration),
+		wasDiscoverable: m.adapter.Discoverable,
+		wasPairable:     m.adapter.Pairable,
+	}
+	m.statusMessage = i18n.T.IncomingStarting
+	m.isError = false
+	m.updateViewportContent()
+	return m, tea.Batch(startIncomingPairingCmd(m.manager), incomingPairingTickCmd(now))
+}
+
+// handleIncomingPairing handles the adapter being made visible.
+func (m Model) handleIncomingPairing(msg IncomingPairingMsg) (tea.Model, tea.Cmd) {
+	if m.incoming == nil {
+		return m, nil
+	}
 	if msg.Err != nil {
-		m.statusMessage = fmt.Sprintf("❌ %s", msg.Err.Error())
+		cmd := m.stopIncomingPairing()
+		m.statusMessage = fmt.Sprintf("%s: %s", i18n.T.ErrorChangeProperty, describeError(msg.Err))
+		m.isError = true
+		m.updateViewportContent()
+		return m, cmd
+	}
+	m.statusMessage = ""
+	m.updateViewportContent()
+	return m, updateAdapterInfoCmd(m.manager)
+}
+
+// handleIncomingPairingTick advances the countdown and gives up once it
+// ends, unless a request from the phone is still being answered.
+func (m Model) handleIncomingPairingTick(msg IncomingPairingTickMsg) (tea.Model, tea.Cmd) {
+	if m.incoming == nil || !m.incoming.started.Equal(msg.Started) {
+		return m, nil
+	}
+	if m.incoming.remaining(time.Now()) == 0 && m.pendingRequest() == nil {
+		cmd := m.stopIncomingPairing()
+		m.statusMessage = i18n.T.IncomingTimeout
+		m.isError = true
+		m.updateViewportContent()
+		return m, cmd
+	}
+	m.updateViewportContent()
+	return m, incomingPairingTickCmd(msg.Started)
+}
+
+// handleIncomingPairingKey handles keys while waiting for a phone.
+func (m Model) handleIncomingPairingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "n":
+		cmd := m.stopIncomingPairing()
+		m.statusMessage = i18n.T.IncomingCancelled
+		m.isError = false
+		m.updateViewportContent()
+		return m, cmd
+
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// checkIncomingPaired ends the pair from phone wizard when dev, previously
+// known as old, has just paired with us, and trusts it if configured to.
+func (m *Model) checkIncomingPaired(old, dev *models.Device) tea.Cmd {
+	if m.incoming == nil || !dev.Paired || (old != nil && old.Paired) || !m.incoming.pairedWith(dev) {
+		return nil
+	}
+
+	cmds := []tea.Cmd{m.stopIncomingPairing()}
+	m.statusMessage = fmt.Sprintf(i18n.T.IncomingPaired, dev.GetDisplayName())
+	m.isError = false
+	if config.Global != nil && config.Global.AutoTrustOnPair && !dev.Trusted {
+		cmds = append(cmds, trustDeviceCmd(m.manager, dev))
+		m.statusMessage = fmt.Sprintf(i18n.T.IncomingPairedTrusted, dev.GetDisplayName())
+	}
+	return tea.Batch(cmds...)
+}
+
+// checkReconnect schedules a reconnect when dev, previously known as old,
+// is marked for auto-reconnect and came back into range or dropped out.
+func (m *Model) checkReconnect(old, dev *models.Device) tea.Cmd {
+	delay, ok := m.reconnect.Changed(old, dev)
+	if !ok {
+		return nil
+	}
+	return reconnectDueCmd(dev.Address, delay)
+}
+
+// handleToggleAutoReconnect marks or unmarks the selected device for
+// auto-reconnect and saves the choice. Only trusted devices are
+// reconnected, so marking one trusts it too.
+func (m Model) handleToggleAutoReconnect() (tea.Model, tea.Cmd) {
+	dev := m.GetSelectedDevice()
+	if dev == nil {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.isError = false
+	switch {
+	case m.reconnect.Enabled(dev.Address):
+		m.reconnect.SetEnabled(dev.Address, false)
+		m.statusMessage = fmt.Sprintf(i18n.T.AutoReconnectOff, dev.GetDisplayName())
+	case !dev.Paired:
+		m.statusMessage = fmt.Sprintf(i18n.T.AutoReconnectNotPaired, dev.GetDisplayName())
+		m.isError = true
+		m.updateViewportContent()
+		return m, nil
+	default:
+		m.reconnect.SetEnabled(dev.Address, true)
+		m.statusMessage = fmt.Sprintf(i18n.T.AutoReconnectOn, dev.GetDisplayName())
+		if !dev.Trusted && m.manager != nil {
+			cmd = trustDeviceCmd(m.manager, dev)
+			m.statusMessage = fmt.Sprintf(i18n.T.AutoReconnectOnTrusted, dev.GetDisplayName())
+		}
+	}
+
+	m.saveAutoReconnect()
+	m.initDevicesTable()
+	m.updateViewportContent()
+	return m, cmd
+}
+
+// saveAutoReconnect writes the devices marked for auto-reconnect to the
+// config file.
+func (m *Model) saveAutoReconnect() {
+	if config.Global != nil {
+		config.Global.AutoReconnectDevices = m.reconnect.Addresses()
+		_ = config.Global.Save()
+	}
+}
+
+// handleReconnectDue reconnects a device marked for auto-reconnect,
+// unless it came back meanwhile or the user is busy with it.
+func (m Model) handleReconnectDue(msg ReconnectDueMsg) (tea.Model, tea.Cmd) {
+	dev, ok := m.devices[msg.Address]
+	if !ok || m.manager == nil || len(m.operations[msg.Address]) > 0 {
+		m.reconnect.Skip(msg.Address)
+		return m, nil
+	}
+	if dev.Connected {
+		m.reconnect.Succeeded(msg.Address)
+		return m, nil
+	}
+	if !m.reconnect.Pending(msg.Address) {
+		// Unmarked or disconnected by the user while waiting
+		return m, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	id := m.startOperation(dev.Address, deviceOperation{label: i18n.T.OpReconnecting, cancel: cancel})
+	m.statusPending = true
+	m.statusMessage = fmt.Sprintf(i18n.T.Reconnecting, dev.GetDisplayName())
+	m.isError = false
+	m.initDevicesTable()
+	m.updateViewportContent()
+	return m, tea.Batch(deviceOperationCmd(m.manager, dev, id, reconnectDeviceCmd(ctx, m.manager, dev)), m.startSpinner())
+}
+
+// handleReconnectResult schedules the next attempt after a failed
+// reconnect, until the reconnector gives up.
+func (m Model) handleReconnectResult(msg ReconnectResultMsg) (tea.Model, tea.Cmd) {
+	name := m.deviceName(msg.Address)
+	m.statusPending = false
+	m.isError = false
+
+	var cmd tea.Cmd
+	switch {
+	case msg.Err == nil:
+		m.reconnect.Succeeded(msg.Address)
+		m.statusMessage = fmt.Sprintf(i18n.T.Reconnected, name)
+	case errors.Is(msg.Err, context.Canceled):
+		m.reconnect.Skip(msg.Address)
+		m.statusMessage = fmt.Sprintf(i18n.T.OperationCancelled, name)
+	default:
+		if delay, ok := m.reconnect.Failed(msg.Address); ok {
+			m.statusMessage = fmt.Sprintf(i18n.T.ReconnectFailed, name, delay.Round(time.Second), describeError(msg.Err))
+			cmd = reconnectDueCmd(msg.Address, delay)
+		} else {
+			m.statusMessage = fmt.Sprintf(i18n.T.ReconnectGaveUp, name, describeError(msg.Err))
+			m.isError = true
+		}
+	}
+
+	m.updateViewportContent()
+	return m, cmd
+}
+
+// stopIncomingPairing ends the pair from phone wizard and returns the
+// command putting the adapter back the way it was.
+func (m *Model) stopIncomingPairing() tea.Cmd {
+	wizard := m.incoming
+	m.incoming = nil
+	if m.manager == nil {
+		return nil
+	}
+	return restoreAdapterCmd(m.manager, wizard.wasDiscoverable, wizard.wasPairable)
+}
+
+// handleRequestCancelled closes the prompt the agent gave up on.
+func (m Model) handleRequestCancelled(msg agent.RequestCancelledMsg) (tea.Model, tea.Cmd) {
+	pending := m.pendingRequest()
+	if pending == nil || pending.Path != msg.Device.Path {
+		return m, nil
+	}
+	m.input = nil
+	m.authorization = nil
+	m.closePairingPrompt()
+	if msg.TimedOut {
+		m.statusMessage = fmt.Sprintf(i18n.T.RequestTimeout, deviceListName(msg.Device))
+		m.isError = true
+	} else {
+		m.statusMessage = i18n.T.PairingCancelled
+		m.isError = false
+	}
+	m.updateViewportContent()
+	return m, nil
+}
+
+// handleDeviceOperation drops a finished operation from its device's
+// queue, then handles the operation's result.
+func (m Model) handleDeviceOperation(msg DeviceOperationMsg) (tea.Model, tea.Cmd) {
+	m.finishOperation(msg.Address, msg.ID)
+	m.initDevicesTable()
+	m.updateViewportContent()
+	return m.Update(msg.Result)
+}
+
+// handleOperationAttempt shows which attempt of a retried operation is
+// running, and waits for the next report.
+func (m Model) handleOperationAttempt(msg OperationAttemptMsg) (tea.Model, tea.Cmd) {
+	m.statusMessage = fmt.Sprintf(i18n.T.OperationRetrying, m.deviceName(msg.Address), msg.Attempt, msg.Attempts)
+	m.statusPending = true
+	m.isError = false
+	m.updateViewportContent()
+	return m, listenProgressCmd(m.progress)
+}
+
+// handleConnectResult handles connection result.
+func (m Model) handleConnectResult(msg ConnectResultMsg) (tea.Model, tea.Cmd) {
+	m.statusPending = false
+	m.waitingForPasskey = m.pairingInProgress()
+
+	if errors.Is(msg.Err, context.Canceled) {
+		m.statusMessage = fmt.Sprintf(i18n.T.OperationCancelled, m.deviceName(msg.Address))
+		m.isError = false
+	} else if msg.Err != nil {
+		m.statusMessage = fmt.Sprintf("❌ %s", describeError(msg.Err))
 		m.isError = true
 	} else {
 		if dev, ok := m.devices[msg.Address]; ok {
@@ -397,9 +1198,14 @@ func (m Model) handleConnectResult(msg ConnectResultMsg) (tea.Model, tea.Cmd) {
 		m.isError = false
 	}
 
-	// Auto-close passkey prompt immediately on any connection result (success or failure)
+	// Auto-close the device's passkey prompt immediately on any connection result (success or failure)
 	// This provides better responsiveness
-	m.pairingPasskey = nil
+	if m.pairingDevice == nil || m.pairingDevice.Address == msg.Address {
+		m.closePairingPrompt()
+	}
+	if m.input != nil && m.input.device.Address == msg.Address {
+		m.input = nil
+	}
 
 	m.updateViewportContent()
 	return m, updateDevicesCmd(m.manager)
@@ -407,7 +1213,7 @@ func (m Model) handleConnectResult(msg ConnectResultMsg) (tea.Model, tea.Cmd) {
 
 // handleStatus handles status messages.
 func (m Model) handleStatus(msg StatusMsg) (tea.Model, tea.Cmd) {
-	m.busy = false
+	m.statusPending = false
 	m.statusMessage = msg.Message
 	m.isError = msg.IsError
 	m.updateViewportContent()
@@ -416,19 +1222,17 @@ func (m Model) handleStatus(msg StatusMsg) (tea.Model, tea.Cmd) {
 
 // handleForgetDevice handles device forgetting.
 func (m Model) handleForgetDevice(msg ForgetDeviceMsg) (tea.Model, tea.Cmd) {
-	m.busy = false
+	m.statusPending = false
 	m.statusMessage = msg.Message
 	m.isError = false
 
 	// Remove the device from our local cache
-	delete(m.devices, msg.Address)
+	m.removeDevice(msg.Address)
 
-	// Remove from deviceOrder if present
-	for i, addr := range m.deviceOrder {
-		if addr == msg.Address {
-			m.deviceOrder = append(m.deviceOrder[:i], m.deviceOrder[i+1:]...)
-			break
-		}
+	// Reconnecting needs pairing again, so stop trying and forget the mark
+	if m.reconnect.Enabled(msg.Address) {
+		m.reconnect.SetEnabled(msg.Address, false)
+		m.saveAutoReconnect()
 	}
 
 	// Reinitialize table with updated devices
@@ -440,16 +1244,84 @@ func (m Model) handleForgetDevice(msg ForgetDeviceMsg) (tea.Model, tea.Cmd) {
 // handleAdapterUpdate handles adapter information update.
 func (m Model) handleAdapterUpdate(msg AdapterUpdateMsg) (tea.Model, tea.Cmd) {
 	m.adapter = msg.Adapter
+	if msg.Adapters != nil {
+		m.adapters = msg.Adapters
+	}
+	m.updateViewportContent()
+	return m, nil
+}
+
+// updateAdapterEntry replaces the adapter with the same path in the list.
+func (m *Model) updateAdapterEntry(adapter *models.Adapter) {
+	for i, a := range m.adapters {
+		if a.Path == adapter.Path {
+			m.adapters[i] = adapter
+			return
+		}
+	}
+	m.adapters = append(m.adapters, adapter)
+}
+
+// removeAdapterEntry drops the adapter with the given path from the list.
+func (m *Model) removeAdapterEntry(path dbus.ObjectPath) {
+	for i, a := range m.adapters {
+		if a.Path == path {
+			m.adapters = append(m.adapters[:i], m.adapters[i+1:]...)
+			return
+		}
+	}
+}
+
+// handleAdapterSwitched handles a change of the active adapter.
+// The device list is rebuilt from scratch for the new adapter.
+func (m Model) handleAdapterSwitched(msg AdapterSwitchedMsg) (tea.Model, tea.Cmd) {
+	m.statusPending = false
+
+	if msg.Adapter != nil {
+		m.adapter = msg.Adapter
+		m.devices = make(map[string]*models.Device)
+		m.deviceOrder = make([]string, 0)
+		m.scanning = msg.Scanning
+		m.initDevicesTable()
+	}
+
+	if msg.Err != nil {
+		m.statusMessage = describeError(msg.Err)
+		m.isError = true
+	} else {
+		m.statusMessage = fmt.Sprintf(i18n.T.AdapterSwitched, msg.Adapter.ID())
+		m.isError = false
+	}
+
+	m.updateViewportContent()
+	return m, tea.Batch(
+		updateDevicesCmd(m.manager),
+		updateAdapterInfoCmd(m.manager),
+	)
+}
+
+// handleDiscoveryFilter handles the result of changing the discovery filter.
+func (m Model) handleDiscoveryFilter(msg DiscoveryFilterMsg) (tea.Model, tea.Cmd) {
+	m.statusPending = false
+
+	if msg.Err != nil {
+		m.statusMessage = describeError(msg.Err)
+		m.isError = true
+	} else {
+		m.statusMessage = fmt.Sprintf(i18n.T.DiscoveryFilterSet, discoveryFilterSummary(msg.Filter))
+		m.isError = false
+	}
+
 	m.updateViewportContent()
 	return m, nil
 }
 
 // handleAdapterPropertyChanged handles adapter property change.
 func (m Model) handleAdapterPropertyChanged(msg AdapterPropertyChangedMsg) (tea.Model, tea.Cmd) {
-	m.busy = false
+	m.statusPending = false
 
 	if msg.Err != nil {
-		m.statusMessage = fmt.Sprintf("%s %s: %s", i18n.T.ErrorChangeProperty, msg.Property, msg.Err.Error())
+		m.statusMessage = fmt.Sprintf("%s %s: %s", i18n.T.ErrorChangeProperty, msg.Property, describeError(msg.Err))
 		m.isError = true
 		m.updateViewportContent()
 		return m, nil
@@ -458,22 +1330,22 @@ func (m Model) handleAdapterPropertyChanged(msg AdapterPropertyChangedMsg) (tea.
 	// Success messages based on property
 	switch msg.Property {
 	case "Powered":
-		if m.adapter != nil && m.adapter.Powered {
-			m.statusMessage = i18n.T.AdapterPoweredOff
-		} else {
+		if msg.Value {
 			m.statusMessage = i18n.T.AdapterPoweredOn
+		} else {
+			m.statusMessage = i18n.T.AdapterPoweredOff
 		}
 	case "Discoverable":
-		if m.adapter != nil && m.adapter.Discoverable {
-			m.statusMessage = i18n.T.DiscoverableOff
-		} else {
+		if msg.Value {
 			m.statusMessage = i18n.T.DiscoverableOn
+		} else {
+			m.statusMessage = i18n.T.DiscoverableOff
 		}
 	case "Pairable":
-		if m.adapter != nil && m.adapter.Pairable {
-			m.statusMessage = i18n.T.PairableOff
-		} else {
+		if msg.Value {
 			m.statusMessage = i18n.T.PairableOn
+		} else {
+			m.statusMessage = i18n.T.PairableOff
 		}
 	}
 
@@ -485,11 +1357,15 @@ func (m Model) handleAdapterPropertyChanged(msg AdapterPropertyChangedMsg) (tea.
 }
 
 // handleTick handles periodic tick.
+// Polling the devices only runs as a fallback when BlueZ signals are not
+// available; the tick itself keeps going either way.
 func (m Model) handleTick() (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	cmds = append(cmds, tickCmd())
 	if m.manager != nil {
-		cmds = append(cmds, updateDevicesCmd(m.manager))
+		if !m.manager.IsWatching() {
+			cmds = append(cmds, updateDevicesCmd(m.manager))
+		}
 		cmds = append(cmds, updateAdapterInfoCmd(m.manager))
 	}
 	return m, tea.Batch(cmds...)
@@ -500,7 +1376,15 @@ func (m Model) quit() (tea.Model, tea.Cmd) {
 	if m.manager != nil && m.scanning {
 		_ = m.manager.StopDiscovery()
 	}
+	if m.manager != nil && m.incoming != nil {
+		_ = m.manager.SetAdapterDiscoverable(m.incoming.wasDiscoverable)
+		_ = m.manager.SetAdapterPairable(m.incoming.wasPairable)
+	}
 	if m.manager != nil {
+		// Give pairing requests back to the desktop agent
+		if m.agent != nil {
+			m.agent.Unregister(m.manager.GetConnection())
+		}
 		_ = m.manager.Close()
 	}
 	return m, tea.Quit
@@ -0,0 +1,329 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ivangsm/blugo/internal/agent"
+	"github.com/ivangsm/blugo/internal/bluetooth"
+	"github.com/ivangsm/blugo/internal/i18n"
+	"github.com/ivangsm/blugo/internal/models"
+)
+
+func TestModel_HandleBluetoothEvent_ServiceLost(t *testing.T) {
+	m := NewModel()
+	m.scanning = true
+	m.adapter = &models.Adapter{Path: "/org/bluez/hci0"}
+	m.devices["AA:BB:CC:DD:EE:FF"] = &models.Device{Address: "AA:BB:CC:DD:EE:FF"}
+	m.deviceOrder = []string{"AA:BB:CC:DD:EE:FF"}
+
+	model, cmd := m.handleBluetoothEvent(BluetoothEventMsg{Event: bluetooth.Event{Kind: bluetooth.ServiceLost}})
+	got := model.(Model)
+
+	if !got.bluezUnavailable {
+		t.Errorf("bluezUnavailable should be set")
+	}
+	if got.scanning {
+		t.Errorf("scanning should be cleared")
+	}
+	if !got.resumeScanning {
+		t.Errorf("resumeScanning should remember the previous scan")
+	}
+	if got.adapter != nil || len(got.devices) != 0 || len(got.deviceOrder) != 0 {
+		t.Errorf("adapter and devices should be cleared")
+	}
+	if cmd == nil {
+		t.Errorf("handler should keep listening for events")
+	}
+}
+
+func TestModel_HandleBluetoothEvent_DeviceChanged(t *testing.T) {
+	m := NewModel()
+	dev := &models.Device{Address: "AA:BB:CC:DD:EE:FF", Name: "Mouse"}
+
+	model, _ := m.handleBluetoothEvent(BluetoothEventMsg{Event: bluetooth.Event{
+		Kind:    bluetooth.DeviceChanged,
+		Address: dev.Address,
+		Device:  dev,
+	}})
+	got := model.(Model)
+
+	if got.devices[dev.Address] != dev {
+		t.Errorf("device should be stored")
+	}
+	if len(got.deviceOrder) != 1 {
+		t.Errorf("deviceOrder length = %d, want 1", len(got.deviceOrder))
+	}
+
+	model, _ = got.handleBluetoothEvent(BluetoothEventMsg{Event: bluetooth.Event{
+		Kind:    bluetooth.DeviceRemoved,
+		Address: dev.Address,
+	}})
+	got = model.(Model)
+
+	if len(got.devices) != 0 || len(got.deviceOrder) != 0 {
+		t.Errorf("device should be removed")
+	}
+}
+
+func TestModel_HandleBluetoothEvent_DeviceDisconnected(t *testing.T) {
+	tests := []struct {
+		reason        string
+		wantReconnect bool
+	}{
+		{bluetooth.DisconnectedLocally, false},
+		{"org.bluez.Reason.Timeout", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			m := newOperationsTestModel()
+			dev := m.GetSelectedDevice()
+			dev.Connected, dev.Trusted = true, true
+			m.reconnect.SetEnabled(dev.Address, true)
+
+			model, _ := m.handleBluetoothEvent(BluetoothEventMsg{Event: bluetooth.Event{Kind: bluetooth.DeviceDisconnected, Address: dev.Address, Reason: tt.reason}})
+			m = model.(Model)
+
+			dropped := *dev
+			dropped.Connected = false
+			if _, ok := m.reconnect.Changed(dev, &dropped); ok != tt.wantReconnect {
+				t.Errorf("reconnect scheduled = %v, want %v", ok, tt.wantReconnect)
+			}
+		})
+	}
+}
+
+func TestModel_HandleAdapterPropertyChanged(t *testing.T) {
+	i18n.SetLanguage(i18n.English)
+	tests := []struct {
+		name  string
+		msg   AdapterPropertyChangedMsg
+		want  string
+		stale bool // adapter state the UI holds when the result arrives
+	}{
+		{"powered on", AdapterPropertyChangedMsg{Property: "Powered", Value: true, Success: true}, i18n.T.AdapterPoweredOn, false},
+		{"powered on, signal first", AdapterPropertyChangedMsg{Property: "Powered", Value: true, Success: true}, i18n.T.AdapterPoweredOn, true},
+		{"discoverable off", AdapterPropertyChangedMsg{Property: "Discoverable", Success: true}, i18n.T.DiscoverableOff, false},
+		{"pairable on, signal first", AdapterPropertyChangedMsg{Property: "Pairable", Value: true, Success: true}, i18n.T.PairableOn, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel()
+			m.adapter = &models.Adapter{Powered: tt.stale, Discoverable: tt.stale, Pairable: tt.stale}
+
+			model, _ := m.handleAdapterPropertyChanged(tt.msg)
+			if got := model.(Model).statusMessage; got != tt.want {
+				t.Errorf("statusMessage = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_InputPrompt(t *testing.T) {
+	dev := &models.Device{Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF", Address: "AA:BB:CC:DD:EE:FF", Name: "GPS"}
+
+	tests := []struct {
+		name    string
+		request tea.Msg
+		keys    []string
+		want    string
+	}{
+		{"PIN accepts letters", agent.PinCodeRequestMsg{Device: dev}, []string{"12", "q", "\b", "5"}, "125"},
+		{"passkey only digits", agent.PasskeyRequestMsg{Device: dev}, []string{"12", "a", "3456", "7"}, "123456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel()
+			m.agent = agent.NewAgent(nil)
+
+			model, _ := m.Update(tt.request)
+			m = model.(Model)
+			if m.input == nil || m.input.device != dev {
+				t.Fatalf("input prompt should be opened for the requesting device")
+			}
+
+			for _, key := range tt.keys {
+				msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+				if key == "\b" {
+					msg = tea.KeyMsg{Type: tea.KeyBackspace}
+				}
+				model, _ = m.handleKeyPress(msg)
+				m = model.(Model)
+			}
+			if m.input.value != tt.want {
+				t.Errorf("value = %q, want %q", m.input.value, tt.want)
+			}
+
+			model, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+			m = model.(Model)
+			if m.input != nil {
+				t.Errorf("Enter should send the answer and close the prompt")
+			}
+		})
+	}
+}
+
+func TestModel_InputPrompt_IncompletePasskey(t *testing.T) {
+	m := NewModel()
+	m.input = &inputPrompt{device: &models.Device{}, passkey: true, value: "123"}
+
+	model, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.(Model).input == nil {
+		t.Errorf("Enter should be ignored until all six digits are typed")
+	}
+}
+
+func TestModel_HandleDisplayPasskey(t *testing.T) {
+	dev := &models.Device{Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF"}
+
+	m := NewModel()
+	model, _ := m.handleDisplayPasskey(agent.DisplayPasskeyMsg{Device: dev, Passkey: 123456, Entered: 2})
+	if model.(Model).pairingPasskey != nil {
+		t.Fatalf("progress for a prompt that is not shown should be ignored")
+	}
+
+	for entered := uint16(0); entered <= 3; entered++ {
+		model, _ = m.handleDisplayPasskey(agent.DisplayPasskeyMsg{Device: dev, Passkey: 123456, Entered: entered})
+		m = model.(Model)
+	}
+	if m.pairingPasskey == nil || *m.pairingPasskey != 123456 {
+		t.Fatalf("pairingPasskey = %v, want 123456", m.pairingPasskey)
+	}
+	if m.passkeyEntered == nil || *m.passkeyEntered != 3 {
+		t.Errorf("passkeyEntered = %v, want 3", m.passkeyEntered)
+	}
+
+	// Hiding the prompt must not answer the agent, which is not waiting
+	model, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(Model)
+	if m.pairingPasskey != nil || m.passkeyEntered != nil {
+		t.Errorf("Esc should hide the prompt")
+	}
+}
+
+func TestModel_HandleRequestCancelled(t *testing.T) {
+	dev := &models.Device{Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF", Address: "AA:BB:CC:DD:EE:FF"}
+	other := &models.Device{Path: "/org/bluez/hci0/dev_11_22_33_44_55_66"}
+
+	m := NewModel()
+	m.input = &inputPrompt{device: dev, value: "12"}
+
+	model, _ := m.handleRequestCancelled(agent.RequestCancelledMsg{Device: other, TimedOut: true})
+	m = model.(Model)
+	if m.input == nil {
+		t.Errorf("a cancellation for another device should keep the prompt")
+	}
+
+	model, _ = m.handleRequestCancelled(agent.RequestCancelledMsg{Device: dev, TimedOut: true})
+	m = model.(Model)
+	if m.input != nil {
+		t.Errorf("prompt should be closed")
+	}
+	if !m.isError {
+		t.Errorf("a timeout should be reported as an error")
+	}
+}
+
+func TestModel_HandleConfirmPasskey_Unsolicited(t *testing.T) {
+	phone := &models.Device{Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF", Name: "Phone"}
+	keyboard := &models.Device{Path: "/org/bluez/hci0/dev_11_22_33_44_55_66", Name: "Keyboard"}
+
+	// A PIN prompt for the keyboard is open when the phone starts pairing
+	m := NewModel()
+	m.input = &inputPrompt{device: keyboard, value: "12"}
+	model, _ := m.Update(agent.ConfirmPasskeyMsg{Device: phone, Passkey: 123456})
+	m = model.(Model)
+
+	if m.input != nil {
+		t.Errorf("the keyboard's prompt should make room for the phone's")
+	}
+	if m.pendingRequest() != phone {
+		t.Fatalf("pendingRequest() = %v, want the phone", m.pendingRequest())
+	}
+	prompt := m.renderPasskeyPrompt()
+	for _, want := range []string{"123456", "Phone"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt should contain %q:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestModel_HandleDisplayPinCode(t *testing.T) {
+	dev := &models.Device{Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF", Name: "Keyboard"}
+
+	m := NewModel()
+	m.waitingForPasskey = true
+	model, _ := m.Update(agent.DisplayPinCodeMsg{Device: dev, PinCode: "482913"})
+	m = model.(Model)
+
+	if m.pairingPinCode != "482913" || m.pairingDevice != dev {
+		t.Fatalf("PIN prompt = %q for %v, want 482913 for the keyboard", m.pairingPinCode, m.pairingDevice)
+	}
+	prompt := m.renderPasskeyPrompt()
+	for _, want := range []string{"482913", "Keyboard"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt should contain %q:\n%s", want, prompt)
+		}
+	}
+
+	model, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(Model).pairingPromptOpen() {
+		t.Errorf("Esc should hide the PIN")
+	}
+}
+
+func TestModel_AuthorizationPrompt(t *testing.T) {
+	dev := &models.Device{Path: "/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF", Name: "Phone"}
+	uuid := "0000110b-0000-1000-8000-00805f9b34fb"
+
+	tests := []struct {
+		name string
+		key  tea.KeyMsg
+	}{
+		{"allowed", tea.KeyMsg{Type: tea.KeyEnter}},
+		{"denied", tea.KeyMsg{Type: tea.KeyEsc}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel()
+			model, _ := m.Update(agent.AuthorizeRequestMsg{Device: dev, UUID: uuid})
+			m = model.(Model)
+
+			if m.pendingRequest() != dev {
+				t.Fatalf("pendingRequest() = %v, want the phone", m.pendingRequest())
+			}
+			prompt := m.renderAuthorizationPrompt()
+			for _, want := range []string{"Phone", "Audio Sink (A2DP)"} {
+				if !strings.Contains(prompt, want) {
+					t.Errorf("prompt should contain %q:\n%s", want, prompt)
+				}
+			}
+
+			model, _ = m.handleKeyPress(tt.key)
+			m = model.(Model)
+			if m.authorization != nil {
+				t.Errorf("the prompt should be closed")
+			}
+			if !strings.Contains(m.statusMessage, "Phone") {
+				t.Errorf("statusMessage = %q, want it to name the device", m.statusMessage)
+			}
+		})
+	}
+}
+
+func TestModel_RenderAgentStatus(t *testing.T) {
+	m := NewModel()
+	if strings.Contains(m.renderHeader(), "agent") {
+		t.Errorf("the header should not mention the agent before initialization")
+	}
+
+	m.agent = agent.NewAgent(nil)
+	if header := m.renderHeader(); !strings.Contains(header, "No pairing agent") {
+		t.Errorf("an unregistered agent should be reported:\n%s", header)
+	}
+}
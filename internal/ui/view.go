@@ -36,11 +36,31 @@ func (m Model) renderFullContent() string {
 	// Header
 	sections = append(sections, m.renderHeader())
 
+	// BlueZ unavailable banner
+	if m.bluezUnavailable {
+		sections = append(sections, "", m.renderUnavailableBanner())
+	}
+
+	// Pair from phone wizard (if active)
+	if m.incoming != nil {
+		sections = append(sections, "", m.renderIncomingPairing(), "")
+	}
+
 	// Passkey prompt (if exists)
-	if m.pairingPasskey != nil {
+	if m.pairingPromptOpen() {
 		sections = append(sections, "", m.renderPasskeyPrompt(), "")
 	}
 
+	// PIN or passkey prompt (if exists)
+	if m.input != nil {
+		sections = append(sections, "", m.renderInputPrompt(), "")
+	}
+
+	// Authorization prompt (if exists)
+	if m.authorization != nil {
+		sections = append(sections, "", m.renderAuthorizationPrompt(), "")
+	}
+
 	// Status bar (if exists)
 	if m.statusMessage != "" {
 		sections = append(sections, "", m.renderStatusBar())
@@ -48,8 +68,17 @@ func (m Model) renderFullContent() string {
 
 	sections = append(sections, "")
 
-	// Main content - always one column
-	sections = append(sections, m.renderSingleColumnLayout())
+	// Main content: the device list, plus the detail panel when open.
+	// Wide terminals show it as a side panel, narrow ones as a modal.
+	switch {
+	case m.showDetails && m.detailsSideBySide():
+		columnWidth := m.columnWidth()
+		sections = append(sections, m.renderTwoColumnLayout(m.renderDevicesTable(), m.renderDeviceDetails(columnWidth)))
+	case m.showDetails:
+		sections = append(sections, m.renderDeviceDetails(maxWidth))
+	default:
+		sections = append(sections, m.renderSingleColumnLayout())
+	}
 
 	sections = append(sections, "")
 
@@ -105,16 +134,9 @@ func (m Model) renderSingleColumnLayout() string {
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
-// renderTwoColumnLayout renders the two column layout.
-func (m Model) renderTwoColumnLayout() string {
-	leftColumn := m.renderFoundDevicesSection()
-	rightColumn := m.renderConnectedDevicesSection()
-
-	// Use effective width with maximum limit
-	effectiveWidth := min(m.width, 160)
-
-	// Calculate the width of each column with space between them
-	columnWidth := (effectiveWidth - 8) / 2
+// renderTwoColumnLayout renders two columns side by side.
+func (m Model) renderTwoColumnLayout(leftColumn, rightColumn string) string {
+	columnWidth := m.columnWidth()
 
 	// Apply width to columns
 	leftStyled := lipgloss.NewStyle().Width(columnWidth).Render(leftColumn)
@@ -136,7 +158,7 @@ func (m Model) renderFoundDevicesSection() string {
 	sections := []string{}
 
 	// Header
-	header := renderSectionHeader(Emoji(EmojiAvailable), i18n.T.AvailableDevices, len(foundDevices), isFocused)
+	header := renderSectionHeader(Emoji(EmojiAvailable), m.availableDevicesTitle(), len(foundDevices), isFocused)
 	sections = append(sections, header)
 	sections = append(sections, m.renderSeparator())
 